@@ -13,12 +13,16 @@ import (
 	handlers "github.com/kuahbanyak/go-crud/internal/adapters/handlers/http"
 	"github.com/kuahbanyak/go-crud/internal/adapters/handlers/http/middleware"
 	"github.com/kuahbanyak/go-crud/internal/adapters/repositories/mssql"
+	"github.com/kuahbanyak/go-crud/internal/domain/services"
 	"github.com/kuahbanyak/go-crud/internal/infrastructure/config"
 	"github.com/kuahbanyak/go-crud/internal/infrastructure/database"
 	"github.com/kuahbanyak/go-crud/internal/infrastructure/jobs"
 	"github.com/kuahbanyak/go-crud/internal/infrastructure/logger"
+	"github.com/kuahbanyak/go-crud/internal/infrastructure/messaging/publisher"
+	"github.com/kuahbanyak/go-crud/internal/infrastructure/messaging/rabbitmq"
 	"github.com/kuahbanyak/go-crud/internal/infrastructure/scheduler"
 	"github.com/kuahbanyak/go-crud/internal/infrastructure/server"
+	"github.com/kuahbanyak/go-crud/internal/infrastructure/storage"
 	"github.com/kuahbanyak/go-crud/internal/shared/utils"
 	"github.com/kuahbanyak/go-crud/internal/usecases"
 )
@@ -54,6 +58,40 @@ func main() {
 		log.Fatal("Failed to get sql.DB:", err)
 	}
 
+	notificationService := publisher.NewLogNotificationService()
+	if cfg.RabbitMQ.Host != "" {
+		rabbitConn, err := rabbitmq.NewConnection(rabbitmq.Config{
+			Host:     cfg.RabbitMQ.Host,
+			Port:     cfg.RabbitMQ.Port,
+			User:     cfg.RabbitMQ.User,
+			Password: cfg.RabbitMQ.Password,
+			Vhost:    cfg.RabbitMQ.Vhost,
+		})
+		if err == nil {
+			if err = rabbitConn.SetupInfrastructure(); err != nil {
+				rabbitConn.Close()
+			}
+		}
+		if err != nil {
+			logger.Error("Failed to connect to RabbitMQ, notifications will only be logged:", err)
+		} else {
+			defer rabbitConn.Close()
+			notificationService = publisher.NewNotificationService(publisher.NewEventPublisher(rabbitConn))
+			logger.Info("RabbitMQ connected successfully")
+		}
+	}
+
+	var fileStorage services.FileStorage
+	switch cfg.Storage.Driver {
+	case "local":
+		fileStorage, err = storage.NewLocalStorage(cfg.Storage.LocalPath)
+		if err != nil {
+			log.Fatal("Failed to prepare local file storage:", err)
+		}
+	default:
+		log.Fatal("Unsupported storage driver:", cfg.Storage.Driver)
+	}
+
 	validator := utils.NewValidator()
 	authService := utils.NewJWTService(cfg.JWT.Secret, cfg.JWT.Expiration)
 	middleware.SetAuthService(authService)
@@ -63,18 +101,43 @@ func main() {
 	waitingListRepo := mssql.NewWaitingListRepository(db)
 	settingRepo := mssql.NewSettingRepository(db)
 	maintenanceItemRepo := mssql.NewMaintenanceItemRepository(db)
+	maintenanceItemPartRepo := mssql.NewMaintenanceItemPartRepository(db)
+	approvalTokenRepo := mssql.NewApprovalTokenRepository(db)
+	maintenanceItemApprovalRepo := mssql.NewMaintenanceItemApprovalRepository(db)
+	attachmentRepo := mssql.NewMaintenanceItemAttachmentRepository(db)
+	maintenanceItemLogRepo := mssql.NewMaintenanceItemLogRepository(db)
+	inspectionTemplateRepo := mssql.NewInspectionTemplateRepository(db)
+	inspectionRepo := mssql.NewInspectionRepository(db)
+	estimateRepo := mssql.NewEstimateRepository(db)
+	warrantyClaimRepo := mssql.NewWarrantyClaimRepository(db)
 	invoiceRepo := mssql.NewInvoiceRepository(sqlDB)
 	roleRepo := mssql.NewRoleRepository(db)
+	serviceBayRepo := mssql.NewServiceBayRepository(db)
+	shopClosureRepo := mssql.NewShopClosureRepository(db)
+	transitionRepo := mssql.NewWaitingListTransitionRepository(db)
+	standbyRepo := mssql.NewStandbyRepository(db)
+	kioskRepo := mssql.NewKioskDeviceRepository(db)
+	serviceTypeRepo := mssql.NewServiceTypeRepository(db)
+	servicePackageRepo := mssql.NewServicePackageRepository(db)
 
 	settingUsecase := usecases.NewSettingUsecase(settingRepo)
+	shopClosureUsecase := usecases.NewShopClosureUsecase(shopClosureRepo, settingUsecase)
 	userUsecase := usecases.NewUserUsecase(userRepo, authService)
 	productUsecase := usecases.NewProductUsecase(productRepo, validator)
 	vehicleUsecase := usecases.NewVehicleUseCase(vehicleRepo)
-	waitingListUsecase := usecases.NewWaitingListUsecase(waitingListRepo, vehicleRepo, userRepo, settingUsecase)
-	maintenanceItemUsecase := usecases.NewMaintenanceItemUsecase(maintenanceItemRepo, waitingListRepo, userRepo)
+	serviceTypeUsecase := usecases.NewServiceTypeUsecase(serviceTypeRepo)
+	servicePackageUsecase := usecases.NewServicePackageUsecase(servicePackageRepo, productRepo)
+	maintenanceItemUsecase := usecases.NewMaintenanceItemUsecase(maintenanceItemRepo, maintenanceItemPartRepo, waitingListRepo, userRepo, productRepo, servicePackageUsecase, settingUsecase, approvalTokenRepo, maintenanceItemApprovalRepo, maintenanceItemLogRepo, estimateRepo, notificationService, utils.NewApprovalTokenService(cfg.JWT.Secret), cfg.Server.PublicURL)
+	waitingListUsecase := usecases.NewWaitingListUsecase(waitingListRepo, vehicleRepo, userRepo, serviceBayRepo, transitionRepo, standbyRepo, settingUsecase, shopClosureUsecase, serviceTypeUsecase, maintenanceItemUsecase, notificationService, utils.NewCheckInTokenService(cfg.JWT.Secret))
+	attachmentUsecase := usecases.NewMaintenanceAttachmentUsecase(attachmentRepo, maintenanceItemRepo, waitingListRepo, fileStorage)
+	inspectionUsecase := usecases.NewInspectionUsecase(inspectionTemplateRepo, inspectionRepo, waitingListRepo, maintenanceItemUsecase, fileStorage)
+	warrantyUsecase := usecases.NewWarrantyUsecase(warrantyClaimRepo, maintenanceItemRepo, waitingListRepo, vehicleRepo, maintenanceItemUsecase)
 	invoiceUsecase := usecases.NewInvoiceUsecase(invoiceRepo, waitingListRepo, userRepo)
 	analyticsUsecase := usecases.NewAnalyticsUsecase(sqlDB)
 	roleUsecase := usecases.NewRoleUsecase(roleRepo, userRepo)
+	serviceBayUsecase := usecases.NewServiceBayUsecase(serviceBayRepo, waitingListRepo)
+	kioskUsecase := usecases.NewKioskUsecase(kioskRepo, vehicleRepo, waitingListUsecase, authService)
+	middleware.SetKioskAuthenticator(kioskUsecase)
 
 	ctx := context.Background()
 	if err := settingRepo.SeedDefaults(ctx); err != nil {
@@ -83,6 +146,12 @@ func main() {
 		logger.Info("Default settings seeded successfully")
 	}
 
+	if err := serviceTypeRepo.SeedDefaults(ctx); err != nil {
+		logger.Error("Failed to seed default service types:", err)
+	} else {
+		logger.Info("Default service types seeded successfully")
+	}
+
 	// Seed default roles
 	if err := database.SeedDefaultRoles(db); err != nil {
 		logger.Error("Failed to seed default roles:", err)
@@ -101,19 +170,37 @@ func main() {
 	invoiceHandler := handlers.NewInvoiceHandler(invoiceUsecase)
 	analyticsHandler := handlers.NewAnalyticsHandler(analyticsUsecase)
 	roleHandler := handlers.NewRoleHandler(roleUsecase)
+	serviceBayHandler := handlers.NewServiceBayHandler(serviceBayUsecase)
+	shopClosureHandler := handlers.NewShopClosureHandler(shopClosureUsecase, settingUsecase)
+	kioskHandler := handlers.NewKioskHandler(kioskUsecase)
+	serviceTypeHandler := handlers.NewServiceTypeHandler(serviceTypeUsecase)
+	servicePackageHandler := handlers.NewServicePackageHandler(servicePackageUsecase)
+	attachmentHandler := handlers.NewMaintenanceAttachmentHandler(attachmentUsecase)
+	inspectionHandler := handlers.NewInspectionHandler(inspectionUsecase)
+	warrantyHandler := handlers.NewWarrantyHandler(warrantyUsecase)
 
-	srv := server.NewHTTPServer(cfg, userHandler, productHandler, waitingListHandler, settingHandler, vehicleHandler, maintenanceItemHandler, healthHandler, versionHandler, invoiceHandler, analyticsHandler, roleHandler)
+	srv := server.NewHTTPServer(cfg, userHandler, productHandler, waitingListHandler, settingHandler, vehicleHandler, maintenanceItemHandler, healthHandler, versionHandler, invoiceHandler, analyticsHandler, roleHandler, serviceBayHandler, shopClosureHandler, kioskHandler, serviceTypeHandler, servicePackageHandler, attachmentHandler, inspectionHandler, warrantyHandler)
 
 	sched, err := scheduler.NewScheduler()
 	if err != nil {
 		log.Fatal("Failed to create scheduler:", err)
 	}
 
-	dailyCleanupJob := jobs.NewDailyCleanupJob(waitingListRepo, settingUsecase)
+	dailyCleanupJob := jobs.NewDailyCleanupJob(waitingListRepo, waitingListUsecase, standbyRepo, settingUsecase)
 	if err := sched.RegisterJob(dailyCleanupJob); err != nil {
 		log.Fatal("Failed to register daily cleanup job:", err)
 	}
 
+	noShowSweeperJob := jobs.NewNoShowSweeperJob(waitingListRepo, waitingListUsecase, settingUsecase)
+	if err := sched.RegisterJob(noShowSweeperJob); err != nil {
+		log.Fatal("Failed to register no-show sweeper job:", err)
+	}
+
+	approvalFollowUpJob := jobs.NewApprovalFollowUpJob(maintenanceItemUsecase, settingUsecase)
+	if err := sched.RegisterJob(approvalFollowUpJob); err != nil {
+		log.Fatal("Failed to register approval follow-up job:", err)
+	}
+
 	logger.Info("Starting job scheduler...")
 	sched.Start()
 	logger.Info("Job scheduler started successfully")
@@ -132,12 +132,22 @@ func generateEmailBody(event *events.EmailNotificationEvent) string {
 	switch event.Template {
 	case "queue_assigned":
 		return formatQueueAssignedEmail(event.TemplateData)
+	case "queue_rescheduled":
+		return formatQueueRescheduledEmail(event.TemplateData)
+	case "customer_called":
+		return formatCustomerCalledEmail(event.TemplateData)
+	case "queue_no_show":
+		return formatQueueNoShowEmail(event.TemplateData)
+	case "standby_promoted":
+		return formatStandbyPromotedEmail(event.TemplateData)
 	case "service_started":
 		return formatServiceStartedEmail(event.TemplateData)
 	case "issue_discovered":
 		return formatIssueDiscoveredEmail(event.TemplateData)
 	case "approval_needed":
 		return formatApprovalNeededEmail(event.TemplateData)
+	case "estimate_sent":
+		return formatEstimateSentEmail(event.TemplateData)
 	case "service_completed":
 		return formatServiceCompletedEmail(event.TemplateData)
 	default:
@@ -151,6 +161,27 @@ func formatQueueAssignedEmail(data map[string]interface{}) string {
 		data["vehicle_brand"], data["vehicle_model"], data["license_plate"])
 }
 
+func formatQueueRescheduledEmail(data map[string]interface{}) string {
+	return fmt.Sprintf("Dear %v,\n\nYour service booking has been moved.\nWas: Queue #%v on %v\nNow: Queue #%v on %v\nType: %v\nVehicle: %v\n\nBest regards",
+		data["customer_name"], data["previous_queue_number"], data["previous_service_date"],
+		data["queue_number"], data["service_date"], data["service_type"], data["license_plate"])
+}
+
+func formatCustomerCalledEmail(data map[string]interface{}) string {
+	return fmt.Sprintf("Dear %v,\n\nIt's your turn!\nQueue #%v\nPlease bring your vehicle (%v) to the service desk.\n\nBest regards",
+		data["customer_name"], data["queue_number"], data["license_plate"])
+}
+
+func formatQueueNoShowEmail(data map[string]interface{}) string {
+	return fmt.Sprintf("Dear %v,\n\nWe called queue #%v on %v but could not find you, so the ticket was closed.\nYou are welcome to take a new number or book another day.\n\nBest regards",
+		data["customer_name"], data["queue_number"], data["service_date"])
+}
+
+func formatStandbyPromotedEmail(data map[string]interface{}) string {
+	return fmt.Sprintf("Dear %v,\n\nA place opened up on %v and your standby request has been turned into queue #%v.\nVehicle: %v\n\nBest regards",
+		data["customer_name"], data["service_date"], data["queue_number"], data["license_plate"])
+}
+
 func formatServiceStartedEmail(data map[string]interface{}) string {
 	return fmt.Sprintf("Dear %v,\n\nYour service has started!\nQueue #%v\nType: %v\nVehicle: %v\n\nBest regards",
 		data["customer_name"], data["queue_number"], data["service_type"], data["vehicle"])
@@ -167,6 +198,11 @@ func formatApprovalNeededEmail(data map[string]interface{}) string {
 		data["customer_name"], data["item_count"], data["total_cost"], data["approval_url"])
 }
 
+func formatEstimateSentEmail(data map[string]interface{}) string {
+	return fmt.Sprintf("Dear %v,\n\nVersion %v of the repair estimate for %v is ready.\nItems: %v\nTotal: $%.2f\nPlease accept or decline it in the app before we continue.\n\nBest regards",
+		data["customer_name"], data["version"], data["license_plate"], data["line_count"], data["total"])
+}
+
 func formatServiceCompletedEmail(data map[string]interface{}) string {
 	return fmt.Sprintf("Dear %v,\n\nService complete!\nQueue #%v\nType: %v\nCompleted: %v\nCost: $%.2f\n\nThank you!",
 		data["customer_name"], data["queue_number"], data["service_type"],
@@ -74,3 +74,14 @@ func (h *AnalyticsHandler) GetMechanicPerformance(w http.ResponseWriter, r *http
 
 	response.SuccessWithContext(r.Context(), w, http.StatusOK, "Mechanic performance retrieved successfully", performance)
 }
+
+// GetWarrantyStats returns warranty claim statistics
+func (h *AnalyticsHandler) GetWarrantyStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.usecase.GetWarrantyStats(r.Context())
+	if err != nil {
+		response.ErrorWithContext(r.Context(), w, http.StatusInternalServerError, "Failed to get warranty stats", err.Error())
+		return
+	}
+
+	response.SuccessWithContext(r.Context(), w, http.StatusOK, "Warranty statistics retrieved successfully", stats)
+}
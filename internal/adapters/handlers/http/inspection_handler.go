@@ -0,0 +1,281 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kuahbanyak/go-crud/internal/domain/services"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/kuahbanyak/go-crud/pkg/response"
+)
+
+type InspectionHandler struct {
+	inspectionUsecase *usecases.InspectionUsecase
+}
+
+func NewInspectionHandler(inspectionUsecase *usecases.InspectionUsecase) *InspectionHandler {
+	return &InspectionHandler{
+		inspectionUsecase: inspectionUsecase,
+	}
+}
+func (h *InspectionHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	var req dto.InspectionTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	template, err := h.inspectionUsecase.CreateTemplate(r.Context(), &req)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to create inspection template", err.Error())
+		return
+	}
+	response.Success(w, http.StatusCreated, "Inspection template created successfully", template)
+}
+func (h *InspectionHandler) GetAllTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.inspectionUsecase.GetAllTemplates(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get inspection templates", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Inspection templates retrieved successfully", templates)
+}
+func (h *InspectionHandler) GetActiveTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.inspectionUsecase.GetActiveTemplates(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get inspection templates", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Inspection templates retrieved successfully", templates)
+}
+func (h *InspectionHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	template, err := h.inspectionUsecase.GetTemplate(r.Context(), id)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, "Inspection template not found", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Inspection template retrieved successfully", template)
+}
+func (h *InspectionHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	var req dto.InspectionTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	template, err := h.inspectionUsecase.UpdateTemplate(r.Context(), id, &req)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to update inspection template", err.Error())
+		return
+	}
+	response.Success(w, http.StatusOK, "Inspection template updated successfully", template)
+}
+func (h *InspectionHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	if err := h.inspectionUsecase.DeleteTemplate(r.Context(), id); err != nil {
+		response.Error(w, http.StatusNotFound, "Failed to delete inspection template", err.Error())
+		return
+	}
+	response.Success(w, http.StatusOK, "Inspection template deleted successfully", nil)
+}
+func (h *InspectionHandler) StartInspection(w http.ResponseWriter, r *http.Request) {
+	mechanicID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	var req dto.StartInspectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	inspection, err := h.inspectionUsecase.StartInspection(r.Context(), mechanicID, req)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to start inspection", err.Error())
+		return
+	}
+	response.Success(w, http.StatusCreated, "Inspection started successfully", inspection)
+}
+func (h *InspectionHandler) GetInspection(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid inspection ID", err)
+		return
+	}
+	inspection, err := h.inspectionUsecase.GetInspection(r.Context(), id)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, "Inspection not found", err.Error())
+		return
+	}
+	response.Success(w, http.StatusOK, "Inspection retrieved successfully", inspection)
+}
+func (h *InspectionHandler) GetInspectionByWaitingList(w http.ResponseWriter, r *http.Request) {
+	waitingListID, err := types.ParseMSSQLUUID(mux.Vars(r)["waiting_list_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid waiting list ID", err)
+		return
+	}
+	inspection, err := h.inspectionUsecase.GetInspectionByWaitingList(r.Context(), waitingListID)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, "Inspection not found", err.Error())
+		return
+	}
+	response.Success(w, http.StatusOK, "Inspection retrieved successfully", inspection)
+}
+func (h *InspectionHandler) RecordResults(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid inspection ID", err)
+		return
+	}
+	var req dto.RecordInspectionResultsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	inspection, err := h.inspectionUsecase.RecordResults(r.Context(), id, req)
+	if err != nil {
+		inspectionError(w, "Failed to record inspection results", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Inspection results recorded successfully", inspection)
+}
+func (h *InspectionHandler) CompleteInspection(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid inspection ID", err)
+		return
+	}
+	inspection, err := h.inspectionUsecase.CompleteInspection(r.Context(), id)
+	if err != nil {
+		inspectionError(w, "Failed to complete inspection", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Inspection completed successfully", inspection)
+}
+func (h *InspectionHandler) CreateFindings(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid inspection ID", err)
+		return
+	}
+	mechanicID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	items, err := h.inspectionUsecase.CreateFindings(r.Context(), mechanicID, id)
+	if err != nil {
+		inspectionError(w, "Failed to add findings as maintenance items", err)
+		return
+	}
+	response.Success(w, http.StatusCreated, "Findings added as maintenance items successfully", items)
+}
+func (h *InspectionHandler) UploadPhoto(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := types.ParseMSSQLUUID(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid inspection ID", err)
+		return
+	}
+	resultID, err := types.ParseMSSQLUUID(vars["result_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid result ID", err)
+		return
+	}
+	if err := r.ParseMultipartForm(attachmentFormMemory); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid multipart form", err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Missing file field", err.Error())
+		return
+	}
+	defer file.Close()
+	result, err := h.inspectionUsecase.UploadPhoto(r.Context(), id, resultID, header.Size, file)
+	if err != nil {
+		attachmentError(w, "Failed to upload photo", err)
+		return
+	}
+	response.Success(w, http.StatusCreated, "Photo uploaded successfully", result)
+}
+func (h *InspectionHandler) GetPhoto(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := types.ParseMSSQLUUID(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid inspection ID", err)
+		return
+	}
+	resultID, err := types.ParseMSSQLUUID(vars["result_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid result ID", err)
+		return
+	}
+	userID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+	contentType, content, err := h.inspectionUsecase.OpenPhoto(r.Context(), userID, role, id, resultID)
+	if err != nil {
+		inspectionError(w, "Failed to get photo", err)
+		return
+	}
+	defer content.Close()
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, content)
+}
+func (h *InspectionHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	waitingListID, err := types.ParseMSSQLUUID(mux.Vars(r)["waiting_list_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid waiting list ID", err)
+		return
+	}
+	userID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+	report, err := h.inspectionUsecase.GetReport(r.Context(), userID, role, waitingListID)
+	if err != nil {
+		inspectionError(w, "Failed to get inspection report", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Inspection report retrieved successfully", report)
+}
+func inspectionError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInspectionForbidden):
+		response.Error(w, http.StatusForbidden, message, err.Error())
+	case errors.Is(err, usecases.ErrInspectionCompleted), errors.Is(err, usecases.ErrFindingsAlreadyAdded):
+		response.Error(w, http.StatusConflict, message, err.Error())
+	case errors.Is(err, services.ErrFileNotFound):
+		response.Error(w, http.StatusNotFound, message, err.Error())
+	default:
+		response.Error(w, http.StatusBadRequest, message, err.Error())
+	}
+}
@@ -0,0 +1,204 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/kuahbanyak/go-crud/pkg/response"
+)
+
+const defaultKioskActivityLimit = 100
+
+// maxLicensePlateLength matches the kiosk activity log column, which records
+// every plate typed at a kiosk.
+const maxLicensePlateLength = 20
+
+type KioskHandler struct {
+	kioskUsecase *usecases.KioskUsecase
+}
+
+func NewKioskHandler(kioskUsecase *usecases.KioskUsecase) *KioskHandler {
+	return &KioskHandler{
+		kioskUsecase: kioskUsecase,
+	}
+}
+func (h *KioskHandler) RegisterDevice(w http.ResponseWriter, r *http.Request) {
+	var req dto.RegisterKioskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	device, token, err := h.kioskUsecase.RegisterDevice(r.Context(), req.Name)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to register kiosk", err)
+		return
+	}
+	resp := buildKioskDeviceResponse(device)
+	resp.Token = token
+	response.Success(w, http.StatusCreated, "Kiosk registered successfully, store the token now as it is not shown again", resp)
+}
+func (h *KioskHandler) GetDevices(w http.ResponseWriter, r *http.Request) {
+	devices, err := h.kioskUsecase.GetDevices(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get kiosks", err)
+		return
+	}
+	resp := make([]dto.KioskDeviceResponse, len(devices))
+	for i, device := range devices {
+		resp[i] = buildKioskDeviceResponse(device)
+	}
+	response.Success(w, http.StatusOK, "Kiosks retrieved successfully", resp)
+}
+func (h *KioskHandler) RevokeDevice(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	if err := h.kioskUsecase.RevokeDevice(r.Context(), id); err != nil {
+		response.Error(w, http.StatusNotFound, "Failed to revoke kiosk", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Kiosk revoked successfully", nil)
+}
+func (h *KioskHandler) GetActivity(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	limit := defaultKioskActivityLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			response.Error(w, http.StatusBadRequest, "limit must be a positive number", nil)
+			return
+		}
+	}
+	activity, err := h.kioskUsecase.GetActivity(r.Context(), id, limit)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, "Failed to get kiosk activity", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Kiosk activity retrieved successfully", activity)
+}
+func (h *KioskHandler) LookupVehicle(w http.ResponseWriter, r *http.Request) {
+	deviceID := r.Context().Value("kiosk_id").(types.MSSQLUUID)
+	plate := r.URL.Query().Get("license_plate")
+	if plate == "" {
+		response.Error(w, http.StatusBadRequest, "license_plate is required", nil)
+		return
+	}
+	if len(plate) > maxLicensePlateLength {
+		response.Error(w, http.StatusBadRequest, "license_plate is too long", nil)
+		return
+	}
+	vehicle, err := h.kioskUsecase.LookupVehicle(r.Context(), deviceID, plate)
+	if err != nil {
+		kioskError(w, "Failed to look up vehicle", err)
+		return
+	}
+	resp := dto.KioskVehicleResponse{
+		VehicleID:    vehicle.ID,
+		LicensePlate: vehicle.LicensePlate,
+		Brand:        vehicle.Brand,
+		Model:        vehicle.Model,
+		OwnerName:    usecases.MaskName(vehicle.Owner.Name),
+	}
+	response.Success(w, http.StatusOK, "Vehicle found", resp)
+}
+func (h *KioskHandler) TakeQueue(w http.ResponseWriter, r *http.Request) {
+	deviceID := r.Context().Value("kiosk_id").(types.MSSQLUUID)
+	var req dto.KioskTakeQueueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if req.LicensePlate == "" || req.ServiceType == "" {
+		response.Error(w, http.StatusBadRequest, "license_plate and service_type are required", nil)
+		return
+	}
+	if len(req.LicensePlate) > maxLicensePlateLength {
+		response.Error(w, http.StatusBadRequest, "license_plate is too long", nil)
+		return
+	}
+	waitingList := &entities.WaitingList{
+		ServiceType:   req.ServiceType,
+		EstimatedTime: req.EstimatedTime,
+		Notes:         req.Notes,
+	}
+	if err := h.kioskUsecase.TakeQueue(r.Context(), deviceID, req.LicensePlate, waitingList); err != nil {
+		kioskError(w, "Failed to take queue number", err)
+		return
+	}
+	resp := dto.KioskTicketResponse{
+		QueueNumber:  waitingList.QueueNumber,
+		ServiceDate:  waitingList.ServiceDate,
+		ServiceType:  waitingList.ServiceType,
+		Status:       string(waitingList.Status),
+		LicensePlate: usecases.MaskLicensePlate(req.LicensePlate),
+	}
+	response.Success(w, http.StatusCreated, "Queue number taken successfully", resp)
+}
+func (h *KioskHandler) CreateGuest(w http.ResponseWriter, r *http.Request) {
+	deviceID := r.Context().Value("kiosk_id").(types.MSSQLUUID)
+	var req dto.KioskGuestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if len(req.LicensePlate) > maxLicensePlateLength {
+		response.Error(w, http.StatusBadRequest, "license_plate is too long", nil)
+		return
+	}
+	guest := &entities.User{Name: req.Name, Phone: req.Phone}
+	vehicle := &entities.Vehicle{
+		LicensePlate: req.LicensePlate,
+		Brand:        req.Brand,
+		Model:        req.Model,
+		Year:         req.Year,
+	}
+	if err := h.kioskUsecase.CreateGuest(r.Context(), deviceID, guest, vehicle); err != nil {
+		kioskError(w, "Failed to register guest", err)
+		return
+	}
+	resp := dto.KioskVehicleResponse{
+		VehicleID:    vehicle.ID,
+		LicensePlate: vehicle.LicensePlate,
+		Brand:        vehicle.Brand,
+		Model:        vehicle.Model,
+		OwnerName:    usecases.MaskName(guest.Name),
+	}
+	response.Success(w, http.StatusCreated, "Guest registered successfully", resp)
+}
+func kioskError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrVehicleNotRegistered):
+		response.Error(w, http.StatusNotFound, "No vehicle registered with this license plate", err.Error())
+	case errors.Is(err, usecases.ErrVehicleAlreadyRegistered):
+		response.Error(w, http.StatusConflict, "This license plate is already registered", err.Error())
+	case errors.Is(err, usecases.ErrShopClosed):
+		response.Error(w, http.StatusUnprocessableEntity, "The shop is closed today", err.Error())
+	case errors.Is(err, repositories.ErrDailyLimitReached):
+		response.Error(w, http.StatusConflict, "No tickets left for today", err.Error())
+	default:
+		response.Error(w, http.StatusBadRequest, message, err.Error())
+	}
+}
+func buildKioskDeviceResponse(device *entities.KioskDevice) dto.KioskDeviceResponse {
+	return dto.KioskDeviceResponse{
+		ID:         device.ID,
+		Name:       device.Name,
+		RevokedAt:  device.RevokedAt,
+		LastSeenAt: device.LastSeenAt,
+		CreatedAt:  device.CreatedAt,
+	}
+}
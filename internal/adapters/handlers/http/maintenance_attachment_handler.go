@@ -0,0 +1,156 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/services"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/kuahbanyak/go-crud/pkg/response"
+)
+
+// attachmentFormMemory is how much of a multipart upload is kept in memory;
+// the rest is spooled to a temporary file.
+const attachmentFormMemory = 8 << 20
+
+// attachmentFormOverhead is what an upload body may carry on top of the
+// largest allowed file: multipart boundaries and part headers.
+const attachmentFormOverhead = 1 << 20
+
+type MaintenanceAttachmentHandler struct {
+	attachmentUsecase *usecases.MaintenanceAttachmentUsecase
+}
+
+func NewMaintenanceAttachmentHandler(attachmentUsecase *usecases.MaintenanceAttachmentUsecase) *MaintenanceAttachmentHandler {
+	return &MaintenanceAttachmentHandler{
+		attachmentUsecase: attachmentUsecase,
+	}
+}
+func (h *MaintenanceAttachmentHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	itemID, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid item ID", err)
+		return
+	}
+	uploaderID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, usecases.MaxAttachmentSize()+attachmentFormOverhead)
+	if err := r.ParseMultipartForm(attachmentFormMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Error(w, http.StatusRequestEntityTooLarge, "Failed to upload attachment", usecases.ErrAttachmentTooLarge.Error())
+			return
+		}
+		response.Error(w, http.StatusBadRequest, "Invalid multipart form", err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Missing file field", err.Error())
+		return
+	}
+	defer file.Close()
+	attachment, err := h.attachmentUsecase.Upload(r.Context(), uploaderID, itemID, header.Filename, header.Size, file)
+	if err != nil {
+		attachmentError(w, "Failed to upload attachment", err)
+		return
+	}
+	response.Success(w, http.StatusCreated, "Attachment uploaded successfully", usecases.BuildAttachmentResponse(attachment))
+}
+func (h *MaintenanceAttachmentHandler) List(w http.ResponseWriter, r *http.Request) {
+	itemID, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid item ID", err)
+		return
+	}
+	userID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+	attachments, err := h.attachmentUsecase.List(r.Context(), userID, role, itemID)
+	if err != nil {
+		attachmentError(w, "Failed to get attachments", err)
+		return
+	}
+	resp := make([]dto.AttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		resp = append(resp, usecases.BuildAttachmentResponse(attachment))
+	}
+	response.Success(w, http.StatusOK, "Attachments retrieved successfully", resp)
+}
+func (h *MaintenanceAttachmentHandler) Download(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, false)
+}
+func (h *MaintenanceAttachmentHandler) Thumbnail(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, true)
+}
+func (h *MaintenanceAttachmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	attachmentID, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid attachment ID", err)
+		return
+	}
+	if err := h.attachmentUsecase.Delete(r.Context(), attachmentID); err != nil {
+		attachmentError(w, "Failed to delete attachment", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Attachment deleted successfully", nil)
+}
+func (h *MaintenanceAttachmentHandler) serve(w http.ResponseWriter, r *http.Request, thumbnail bool) {
+	attachmentID, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid attachment ID", err)
+		return
+	}
+	userID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+	attachment, content, err := h.attachmentUsecase.Open(r.Context(), userID, role, attachmentID, thumbnail)
+	if err != nil {
+		attachmentError(w, "Failed to get attachment", err)
+		return
+	}
+	defer content.Close()
+	contentType := attachment.ContentType
+	disposition := "inline"
+	if thumbnail {
+		contentType = "image/jpeg"
+	} else if attachment.Kind == entities.AttachmentKindDocument {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("%s; filename=%q", disposition, attachment.FileName))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, content)
+}
+func attachmentError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrAttachmentTypeNotAllowed):
+		response.Error(w, http.StatusUnsupportedMediaType, message, err.Error())
+	case errors.Is(err, usecases.ErrAttachmentTooLarge):
+		response.Error(w, http.StatusRequestEntityTooLarge, message, err.Error())
+	case errors.Is(err, usecases.ErrAttachmentForbidden):
+		response.Error(w, http.StatusForbidden, message, err.Error())
+	case errors.Is(err, services.ErrFileNotFound):
+		response.Error(w, http.StatusNotFound, message, err.Error())
+	default:
+		response.Error(w, http.StatusBadRequest, message, err.Error())
+	}
+}
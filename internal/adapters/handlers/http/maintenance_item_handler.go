@@ -1,15 +1,20 @@
 package http
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
 	"github.com/kuahbanyak/go-crud/internal/adapters/handlers/http/middleware"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
 	"github.com/kuahbanyak/go-crud/internal/infrastructure/logger"
+	"github.com/kuahbanyak/go-crud/internal/shared/constants"
 	"github.com/kuahbanyak/go-crud/internal/shared/dto"
 	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/shared/utils"
 	"github.com/kuahbanyak/go-crud/internal/usecases"
 	apperrors "github.com/kuahbanyak/go-crud/pkg/errors"
 	"github.com/kuahbanyak/go-crud/pkg/response"
@@ -46,24 +51,36 @@ func (h *MaintenanceItemHandler) CreateInitialItems(w http.ResponseWriter, r *ht
 		return
 	}
 
-	// Decode and validate request body
-	var requests []dto.CreateMaintenanceItemRequest
-	if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+	// Decode and validate request body: either an array of items or an
+	// object with a package code and extra items
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		appErr := apperrors.NewBadRequestError("Invalid request body")
+		response.ErrorFromAppError(r.Context(), w, appErr)
+		return
+	}
+	var request dto.CreateInitialItemsRequest
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(body, &request.Items)
+	} else {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
 		appErr := apperrors.NewBadRequestError("Invalid request body")
 		response.ErrorFromAppError(r.Context(), w, appErr)
 		return
 	}
 
-	// Validate at least one item
-	if len(requests) == 0 {
-		appErr := apperrors.NewBadRequestError("At least one maintenance item is required")
+	// Validate at least one item or a package
+	if len(request.Items) == 0 && request.PackageCode == "" {
+		appErr := apperrors.NewBadRequestError("At least one maintenance item or a package code is required")
 		response.ErrorFromAppError(r.Context(), w, appErr)
 		return
 	}
 
 	// Validate each item
 	validationErrors := make(apperrors.ValidationErrors)
-	for i, req := range requests {
+	for i, req := range request.Items {
 		prefix := fmt.Sprintf("item[%d]", i)
 
 		if req.Category == "" {
@@ -99,7 +116,8 @@ func (h *MaintenanceItemHandler) CreateInitialItems(w http.ResponseWriter, r *ht
 	_ = customerID // Use if needed
 
 	// Create maintenance items
-	if err := h.maintenanceItemUsecase.CreateInitialItems(r.Context(), waitingListID, requests); err != nil {
+	items, err := h.maintenanceItemUsecase.CreateInitialItems(r.Context(), waitingListID, request)
+	if err != nil {
 		// Log detailed error for debugging
 		logger.ErrorWithContext(r.Context(), "Failed to create maintenance items", map[string]interface{}{
 			"waiting_list_id": waitingListID.String(),
@@ -112,12 +130,21 @@ func (h *MaintenanceItemHandler) CreateInitialItems(w http.ResponseWriter, r *ht
 			return
 		}
 
+		if errors.Is(err, usecases.ErrUnknownServicePackage) {
+			response.ErrorFromAppError(r.Context(), w, apperrors.NewNotFoundError("Service package"))
+			return
+		}
+		if errors.Is(err, usecases.ErrPackageNotApplicable) {
+			response.ErrorFromAppError(r.Context(), w, apperrors.NewBadRequestError(err.Error()))
+			return
+		}
+
 		appErr := apperrors.NewInternalError("Failed to create maintenance items", err)
 		response.ErrorFromAppError(r.Context(), w, appErr)
 		return
 	}
 
-	response.SuccessWithContext(r.Context(), w, http.StatusCreated, "Maintenance items created successfully", nil)
+	response.SuccessWithContext(r.Context(), w, http.StatusCreated, "Maintenance items created successfully", items)
 }
 func (h *MaintenanceItemHandler) AddDiscoveredItem(w http.ResponseWriter, r *http.Request) {
 	var req dto.AddDiscoveredItemRequest
@@ -182,6 +209,10 @@ func (h *MaintenanceItemHandler) ApproveItems(w http.ResponseWriter, r *http.Req
 		return
 	}
 	if err := h.maintenanceItemUsecase.ApproveItems(r.Context(), customerID, req); err != nil {
+		if errors.Is(err, repositories.ErrItemNotAwaitingApproval) {
+			response.Error(w, http.StatusConflict, "Item is no longer waiting for approval", err.Error())
+			return
+		}
 		response.Error(w, http.StatusInternalServerError, "Failed to process approval", err)
 		return
 	}
@@ -191,6 +222,23 @@ func (h *MaintenanceItemHandler) ApproveItems(w http.ResponseWriter, r *http.Req
 	}
 	response.Success(w, http.StatusOK, message, nil)
 }
+func (h *MaintenanceItemHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	itemID, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid item ID", err)
+		return
+	}
+	customerID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	if err := h.maintenanceItemUsecase.RemoveInitialItem(r.Context(), customerID, itemID); err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to remove maintenance item", err.Error())
+		return
+	}
+	response.Success(w, http.StatusOK, "Maintenance item removed successfully", nil)
+}
 func (h *MaintenanceItemHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	itemID, err := types.ParseMSSQLUUID(vars["id"])
@@ -216,14 +264,21 @@ func (h *MaintenanceItemHandler) CompleteItem(w http.ResponseWriter, r *http.Req
 		response.Error(w, http.StatusBadRequest, "Invalid item ID", err)
 		return
 	}
-	var req struct {
-		ActualCost float64 `json:"actual_cost"`
-	}
+	var req dto.CompleteMaintenanceItemRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
 		return
 	}
-	if err := h.maintenanceItemUsecase.CompleteItem(r.Context(), itemID, req.ActualCost); err != nil {
+	role, _ := r.Context().Value("role").(string)
+	if req.OverrideStock && role != constants.RoleAdmin {
+		response.Error(w, http.StatusForbidden, "Only admins can override stock checks", nil)
+		return
+	}
+	if err := h.maintenanceItemUsecase.CompleteItem(r.Context(), itemID, req.ActualCost, req.OverrideStock); err != nil {
+		if errors.Is(err, repositories.ErrInsufficientStock) {
+			response.Error(w, http.StatusConflict, "Not enough stock to complete maintenance item", err.Error())
+			return
+		}
 		response.Error(w, http.StatusInternalServerError, "Failed to complete maintenance item", err)
 		return
 	}
@@ -242,3 +297,238 @@ func (h *MaintenanceItemHandler) DeleteItem(w http.ResponseWriter, r *http.Reque
 	}
 	response.Success(w, http.StatusOK, "Maintenance item deleted successfully", nil)
 }
+func (h *MaintenanceItemHandler) CancelItem(w http.ResponseWriter, r *http.Request) {
+	itemID, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid item ID", err)
+		return
+	}
+	if err := h.maintenanceItemUsecase.CancelItem(r.Context(), itemID); err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to cancel maintenance item", err.Error())
+		return
+	}
+	response.Success(w, http.StatusOK, "Maintenance item canceled successfully", nil)
+}
+func (h *MaintenanceItemHandler) AddPart(w http.ResponseWriter, r *http.Request) {
+	itemID, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid item ID", err)
+		return
+	}
+	var req dto.AddItemPartRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	part, err := h.maintenanceItemUsecase.AddPart(r.Context(), itemID, req)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to add part", err.Error())
+		return
+	}
+	response.Success(w, http.StatusCreated, "Part added successfully", usecases.BuildItemPartResponse(part))
+}
+func (h *MaintenanceItemHandler) RemovePart(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	itemID, err := types.ParseMSSQLUUID(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid item ID", err)
+		return
+	}
+	partID, err := types.ParseMSSQLUUID(vars["part_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid part ID", err)
+		return
+	}
+	if err := h.maintenanceItemUsecase.RemovePart(r.Context(), itemID, partID); err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to remove part", err.Error())
+		return
+	}
+	response.Success(w, http.StatusOK, "Part removed successfully", nil)
+}
+func (h *MaintenanceItemHandler) SendApprovalLink(w http.ResponseWriter, r *http.Request) {
+	waitingListID, err := types.ParseMSSQLUUID(mux.Vars(r)["waiting_list_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid waiting list ID", err)
+		return
+	}
+	link, err := h.maintenanceItemUsecase.SendApprovalLink(r.Context(), waitingListID)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to send approval link", err.Error())
+		return
+	}
+	response.Success(w, http.StatusOK, "Approval link sent successfully", link)
+}
+func (h *MaintenanceItemHandler) GetPendingApprovalByToken(w http.ResponseWriter, r *http.Request) {
+	pending, err := h.maintenanceItemUsecase.GetPendingApprovalByToken(r.Context(), mux.Vars(r)["token"])
+	if err != nil {
+		approvalTokenError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Pending approvals retrieved successfully", pending)
+}
+func (h *MaintenanceItemHandler) DecideByToken(w http.ResponseWriter, r *http.Request) {
+	var req dto.ApprovalLinkDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if err := h.maintenanceItemUsecase.DecideByToken(r.Context(), mux.Vars(r)["token"], req); err != nil {
+		approvalTokenError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Decisions recorded successfully", nil)
+}
+func (h *MaintenanceItemHandler) RecordPhoneDecisions(w http.ResponseWriter, r *http.Request) {
+	waitingListID, err := types.ParseMSSQLUUID(mux.Vars(r)["waiting_list_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid waiting list ID", err)
+		return
+	}
+	staffID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	var req dto.RecordPhoneApprovalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if err := h.maintenanceItemUsecase.RecordPhoneDecisions(r.Context(), staffID, waitingListID, req); err != nil {
+		if errors.Is(err, repositories.ErrItemNotAwaitingApproval) {
+			response.Error(w, http.StatusConflict, "Item is no longer waiting for approval", err.Error())
+			return
+		}
+		response.Error(w, http.StatusBadRequest, "Failed to record phone approval", err.Error())
+		return
+	}
+	response.Success(w, http.StatusOK, "Phone approval recorded successfully", nil)
+}
+func approvalTokenError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, utils.ErrApprovalTokenInvalid):
+		response.Error(w, http.StatusNotFound, "Approval link not found", err.Error())
+	case errors.Is(err, utils.ErrApprovalTokenExpired), errors.Is(err, usecases.ErrApprovalLinkUsed):
+		response.Error(w, http.StatusGone, "Approval link is no longer valid", err.Error())
+	case errors.Is(err, repositories.ErrItemNotAwaitingApproval):
+		response.Error(w, http.StatusConflict, "Item is no longer waiting for approval", err.Error())
+	default:
+		response.Error(w, http.StatusBadRequest, "Failed to process approval", err.Error())
+	}
+}
+func (h *MaintenanceItemHandler) GetApprovalCalls(w http.ResponseWriter, r *http.Request) {
+	calls, err := h.maintenanceItemUsecase.GetApprovalCalls(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get approval calls", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Approval calls retrieved successfully", calls)
+}
+func (h *MaintenanceItemHandler) GetItemLogs(w http.ResponseWriter, r *http.Request) {
+	itemID, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid item ID", err)
+		return
+	}
+	logs, err := h.maintenanceItemUsecase.GetItemLogs(r.Context(), itemID)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, "Failed to get item logs", err.Error())
+		return
+	}
+	response.Success(w, http.StatusOK, "Item logs retrieved successfully", logs)
+}
+func (h *MaintenanceItemHandler) IssueEstimate(w http.ResponseWriter, r *http.Request) {
+	waitingListID, err := types.ParseMSSQLUUID(mux.Vars(r)["waiting_list_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid waiting list ID", err)
+		return
+	}
+	staffID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	estimate, err := h.maintenanceItemUsecase.IssueEstimate(r.Context(), staffID, waitingListID)
+	if err != nil {
+		estimateError(w, "Failed to issue estimate", err)
+		return
+	}
+	response.Success(w, http.StatusCreated, "Estimate issued successfully", estimate)
+}
+func (h *MaintenanceItemHandler) GetEstimates(w http.ResponseWriter, r *http.Request) {
+	waitingListID, err := types.ParseMSSQLUUID(mux.Vars(r)["waiting_list_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid waiting list ID", err)
+		return
+	}
+	userID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+	estimates, err := h.maintenanceItemUsecase.GetEstimates(r.Context(), userID, role, waitingListID)
+	if err != nil {
+		estimateError(w, "Failed to get estimates", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Estimates retrieved successfully", estimates)
+}
+func (h *MaintenanceItemHandler) GetLatestEstimate(w http.ResponseWriter, r *http.Request) {
+	waitingListID, err := types.ParseMSSQLUUID(mux.Vars(r)["waiting_list_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid waiting list ID", err)
+		return
+	}
+	userID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+	estimate, err := h.maintenanceItemUsecase.GetLatestEstimate(r.Context(), userID, role, waitingListID)
+	if err != nil {
+		estimateError(w, "Failed to get estimate", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Estimate retrieved successfully", estimate)
+}
+func (h *MaintenanceItemHandler) DecideEstimate(w http.ResponseWriter, r *http.Request) {
+	estimateID, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid estimate ID", err)
+		return
+	}
+	customerID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	var req dto.DecideEstimateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if appErr := middleware.ValidateStruct(&req); appErr != nil {
+		response.ErrorFromAppError(r.Context(), w, appErr)
+		return
+	}
+	estimate, err := h.maintenanceItemUsecase.DecideEstimate(r.Context(), customerID, estimateID, req)
+	if err != nil {
+		estimateError(w, "Failed to record estimate decision", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Estimate decision recorded successfully", estimate)
+}
+func estimateError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrEstimateNotFound):
+		response.Error(w, http.StatusNotFound, message, err.Error())
+	case errors.Is(err, usecases.ErrEstimateForbidden):
+		response.Error(w, http.StatusForbidden, message, err.Error())
+	case errors.Is(err, repositories.ErrEstimateNotOpen):
+		response.Error(w, http.StatusConflict, message, err.Error())
+	default:
+		response.Error(w, http.StatusBadRequest, message, err.Error())
+	}
+}
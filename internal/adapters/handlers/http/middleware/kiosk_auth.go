@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/pkg/response"
+)
+
+// KioskAuthenticator resolves a kiosk device token to the device ID.
+type KioskAuthenticator interface {
+	AuthenticateKiosk(ctx context.Context, token string) (types.MSSQLUUID, error)
+}
+
+var kioskAuthenticator KioskAuthenticator
+
+func SetKioskAuthenticator(authenticator KioskAuthenticator) {
+	kioskAuthenticator = authenticator
+}
+
+// KioskAuth accepts requests carrying a valid X-Kiosk-Token header and stores
+// the device ID in the request context under "kiosk_id".
+func KioskAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("X-Kiosk-Token")
+		if token == "" {
+			response.Error(w, http.StatusUnauthorized, "X-Kiosk-Token header required", nil)
+			return
+		}
+		if kioskAuthenticator == nil {
+			response.Error(w, http.StatusInternalServerError, "Kiosk authentication not initialized", nil)
+			return
+		}
+		deviceID, err := kioskAuthenticator.AuthenticateKiosk(r.Context(), token)
+		if err != nil {
+			response.Error(w, http.StatusUnauthorized, "Invalid kiosk token", nil)
+			return
+		}
+		ctx := context.WithValue(r.Context(), "kiosk_id", deviceID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// KioskRateLimit limits requests per kiosk device. It must run after KioskAuth.
+func KioskRateLimit(limiter *RateLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			deviceID, ok := r.Context().Value("kiosk_id").(types.MSSQLUUID)
+			if !ok {
+				response.Error(w, http.StatusUnauthorized, "Kiosk device not found in context", nil)
+				return
+			}
+			if !limiter.Allow(deviceID.String()) {
+				response.Error(w, http.StatusTooManyRequests, "Rate limit exceeded. Please try again later.", nil)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -5,16 +5,24 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/kuahbanyak/go-crud/pkg/response"
 )
 
-const MaxRequestSize = 10 << 20 // 10MB
+const (
+	MaxRequestSize = 10 << 20 // 10MB
+	MaxUploadSize  = 55 << 20 // 55MB, multipart uploads; handlers check their own per-file limits
+)
 
 func ValidateRequestSize(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "POST" || r.Method == "PUT" || r.Method == "PATCH" {
-			r.Body = http.MaxBytesReader(w, r.Body, MaxRequestSize)
+			limit := int64(MaxRequestSize)
+			if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+				limit = MaxUploadSize
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
 		}
 		next.ServeHTTP(w, r)
 	})
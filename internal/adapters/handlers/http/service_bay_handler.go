@@ -0,0 +1,104 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/kuahbanyak/go-crud/pkg/response"
+)
+
+type ServiceBayHandler struct {
+	serviceBayUsecase *usecases.ServiceBayUsecase
+}
+
+func NewServiceBayHandler(serviceBayUsecase *usecases.ServiceBayUsecase) *ServiceBayHandler {
+	return &ServiceBayHandler{
+		serviceBayUsecase: serviceBayUsecase,
+	}
+}
+func (h *ServiceBayHandler) CreateServiceBay(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateServiceBayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if req.Code == "" || req.Name == "" {
+		response.Error(w, http.StatusBadRequest, "code and name are required", nil)
+		return
+	}
+	bay := &entities.ServiceBay{
+		Code:     req.Code,
+		Name:     req.Name,
+		IsActive: req.IsActive == nil || *req.IsActive,
+		Notes:    req.Notes,
+	}
+	if err := h.serviceBayUsecase.CreateServiceBay(r.Context(), bay); err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to create service bay", err)
+		return
+	}
+	response.Success(w, http.StatusCreated, "Service bay created successfully", bay)
+}
+func (h *ServiceBayHandler) GetAllServiceBays(w http.ResponseWriter, r *http.Request) {
+	bays, err := h.serviceBayUsecase.GetAllServiceBays(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get service bays", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Service bays retrieved successfully", bays)
+}
+func (h *ServiceBayHandler) GetServiceBay(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	bay, err := h.serviceBayUsecase.GetServiceBay(r.Context(), id)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, "Service bay not found", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Service bay retrieved successfully", bay)
+}
+func (h *ServiceBayHandler) UpdateServiceBay(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	var req dto.UpdateServiceBayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	bay, err := h.serviceBayUsecase.UpdateServiceBay(r.Context(), id, &req)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to update service bay", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Service bay updated successfully", bay)
+}
+func (h *ServiceBayHandler) DeleteServiceBay(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	if err := h.serviceBayUsecase.DeleteServiceBay(r.Context(), id); err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to delete service bay", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Service bay deleted successfully", nil)
+}
+func (h *ServiceBayHandler) GetBayStatus(w http.ResponseWriter, r *http.Request) {
+	overview, err := h.serviceBayUsecase.GetBayOverview(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get service bay status", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Service bay status retrieved successfully", overview)
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/kuahbanyak/go-crud/pkg/response"
+)
+
+type ServicePackageHandler struct {
+	packageUsecase *usecases.ServicePackageUsecase
+}
+
+func NewServicePackageHandler(packageUsecase *usecases.ServicePackageUsecase) *ServicePackageHandler {
+	return &ServicePackageHandler{
+		packageUsecase: packageUsecase,
+	}
+}
+func (h *ServicePackageHandler) CreatePackage(w http.ResponseWriter, r *http.Request) {
+	var req dto.ServicePackageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	pkg, err := h.packageUsecase.CreatePackage(r.Context(), &req)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to create service package", err.Error())
+		return
+	}
+	response.Success(w, http.StatusCreated, "Service package created successfully", pkg)
+}
+func (h *ServicePackageHandler) GetAllPackages(w http.ResponseWriter, r *http.Request) {
+	packages, err := h.packageUsecase.GetAllPackages(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get service packages", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Service packages retrieved successfully", packages)
+}
+func (h *ServicePackageHandler) GetActivePackages(w http.ResponseWriter, r *http.Request) {
+	packages, err := h.packageUsecase.GetActivePackages(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get service packages", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Service packages retrieved successfully", packages)
+}
+func (h *ServicePackageHandler) GetPackage(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	pkg, err := h.packageUsecase.GetPackage(r.Context(), id)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, "Service package not found", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Service package retrieved successfully", pkg)
+}
+func (h *ServicePackageHandler) UpdatePackage(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	var req dto.ServicePackageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	pkg, err := h.packageUsecase.UpdatePackage(r.Context(), id, &req)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to update service package", err.Error())
+		return
+	}
+	response.Success(w, http.StatusOK, "Service package updated successfully", pkg)
+}
+func (h *ServicePackageHandler) DeletePackage(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	if err := h.packageUsecase.DeletePackage(r.Context(), id); err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to delete service package", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Service package deleted successfully", nil)
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/kuahbanyak/go-crud/pkg/response"
+)
+
+type ServiceTypeHandler struct {
+	serviceTypeUsecase *usecases.ServiceTypeUsecase
+}
+
+func NewServiceTypeHandler(serviceTypeUsecase *usecases.ServiceTypeUsecase) *ServiceTypeHandler {
+	return &ServiceTypeHandler{
+		serviceTypeUsecase: serviceTypeUsecase,
+	}
+}
+func (h *ServiceTypeHandler) CreateServiceType(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateServiceTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if req.Code == "" || req.Name == "" || req.Category == "" {
+		response.Error(w, http.StatusBadRequest, "code, name and category are required", nil)
+		return
+	}
+	serviceType := &entities.ServiceType{
+		Code:            req.Code,
+		Name:            req.Name,
+		Category:        req.Category,
+		DefaultDuration: req.DefaultDuration,
+		BaseLaborPrice:  req.BaseLaborPrice,
+		IsActive:        req.IsActive == nil || *req.IsActive,
+	}
+	if err := h.serviceTypeUsecase.CreateServiceType(r.Context(), serviceType); err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to create service type", err)
+		return
+	}
+	response.Success(w, http.StatusCreated, "Service type created successfully", serviceType)
+}
+func (h *ServiceTypeHandler) GetAllServiceTypes(w http.ResponseWriter, r *http.Request) {
+	serviceTypes, err := h.serviceTypeUsecase.GetAllServiceTypes(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get service types", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Service types retrieved successfully", serviceTypes)
+}
+func (h *ServiceTypeHandler) GetActiveServiceTypes(w http.ResponseWriter, r *http.Request) {
+	serviceTypes, err := h.serviceTypeUsecase.GetActiveServiceTypes(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get service types", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Service types retrieved successfully", serviceTypes)
+}
+func (h *ServiceTypeHandler) GetServiceType(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	serviceType, err := h.serviceTypeUsecase.GetServiceType(r.Context(), id)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, "Service type not found", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Service type retrieved successfully", serviceType)
+}
+func (h *ServiceTypeHandler) UpdateServiceType(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	var req dto.UpdateServiceTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	serviceType, err := h.serviceTypeUsecase.UpdateServiceType(r.Context(), id, &req)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to update service type", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Service type updated successfully", serviceType)
+}
+func (h *ServiceTypeHandler) DeleteServiceType(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	if err := h.serviceTypeUsecase.DeleteServiceType(r.Context(), id); err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to delete service type", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Service type deleted successfully", nil)
+}
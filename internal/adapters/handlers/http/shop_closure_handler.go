@@ -0,0 +1,150 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/kuahbanyak/go-crud/pkg/response"
+)
+
+const maxCalendarDays = 90
+
+type ShopClosureHandler struct {
+	closureUsecase *usecases.ShopClosureUsecase
+	settingUsecase *usecases.SettingUsecase
+}
+
+func NewShopClosureHandler(closureUsecase *usecases.ShopClosureUsecase, settingUsecase *usecases.SettingUsecase) *ShopClosureHandler {
+	return &ShopClosureHandler{
+		closureUsecase: closureUsecase,
+		settingUsecase: settingUsecase,
+	}
+}
+func (h *ShopClosureHandler) CreateClosure(w http.ResponseWriter, r *http.Request) {
+	closure, err := decodeClosure(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if err := h.closureUsecase.CreateClosure(r.Context(), closure); err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to create closure", err)
+		return
+	}
+	response.Success(w, http.StatusCreated, "Closure created successfully", closure)
+}
+func (h *ShopClosureHandler) GetAllClosures(w http.ResponseWriter, r *http.Request) {
+	closures, err := h.closureUsecase.GetAllClosures(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get closures", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Closures retrieved successfully", closures)
+}
+func (h *ShopClosureHandler) GetClosure(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	closure, err := h.closureUsecase.GetClosure(r.Context(), id)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, "Closure not found", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Closure retrieved successfully", closure)
+}
+func (h *ShopClosureHandler) UpdateClosure(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	updates, err := decodeClosure(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	closure, err := h.closureUsecase.UpdateClosure(r.Context(), id, updates)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to update closure", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Closure updated successfully", closure)
+}
+func (h *ShopClosureHandler) DeleteClosure(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	if err := h.closureUsecase.DeleteClosure(r.Context(), id); err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to delete closure", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Closure deleted successfully", nil)
+}
+
+// GetCalendar returns open/closed status per day, starting today and covering
+// the advance booking window unless from/days are given.
+func (h *ShopClosureHandler) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	from := time.Now()
+	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "Invalid from date format (use YYYY-MM-DD)", err)
+			return
+		}
+		from = parsed
+	}
+	days := h.settingUsecase.GetFutureBookingDays(r.Context()) + 1
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed <= 0 {
+			response.Error(w, http.StatusBadRequest, "days must be a positive number", err)
+			return
+		}
+		days = parsed
+	}
+	if days > maxCalendarDays {
+		days = maxCalendarDays
+	}
+	calendar, err := h.closureUsecase.GetCalendar(r.Context(), from, days)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get shop calendar", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Shop calendar retrieved successfully", calendar)
+}
+func decodeClosure(r *http.Request) (*entities.ShopClosure, error) {
+	var req dto.ShopClosureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, err
+	}
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, errors.New("invalid start_date format (use YYYY-MM-DD)")
+	}
+	closure := &entities.ShopClosure{
+		Name:        req.Name,
+		StartDate:   startDate,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		IsRecurring: req.IsRecurring,
+	}
+	if req.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return nil, errors.New("invalid end_date format (use YYYY-MM-DD)")
+		}
+		closure.EndDate = endDate
+	}
+	return closure, nil
+}
@@ -40,11 +40,12 @@ func (h *UserHandler) toUserResponse(user *entities.User) dto.UserResponse {
 	}
 
 	return dto.UserResponse{
-		ID:    user.ID,
-		Email: user.Email,
-		Name:  user.Name,
-		Phone: user.Phone,
-		Roles: rolesResponse,
+		ID:         user.ID,
+		Email:      user.Email,
+		Name:       user.Name,
+		Phone:      user.Phone,
+		SkillLevel: user.SkillLevel,
+		Roles:      rolesResponse,
 	}
 }
 
@@ -210,8 +211,9 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	updateData := &entities.User{
-		Name:  req.FirstName + " " + req.LastName,
-		Phone: req.Phone,
+		Name:       req.FirstName + " " + req.LastName,
+		Phone:      req.Phone,
+		SkillLevel: req.SkillLevel,
 	}
 	updatedUser, err := h.userUsecase.UpdateUser(r.Context(), id, updateData)
 	if err != nil {
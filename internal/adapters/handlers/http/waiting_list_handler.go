@@ -2,15 +2,20 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/constants"
 	"github.com/kuahbanyak/go-crud/internal/shared/dto"
 	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/shared/utils"
 	"github.com/kuahbanyak/go-crud/internal/usecases"
 	"github.com/kuahbanyak/go-crud/pkg/response"
 )
@@ -49,24 +54,104 @@ func (h *WaitingListHandler) TakeQueueNumber(w http.ResponseWriter, r *http.Requ
 		Notes:         req.Notes,
 	}
 	if err := h.waitingListUsecase.TakeQueueNumber(r.Context(), waitingList); err != nil {
+		if errors.Is(err, usecases.ErrUnknownServiceType) {
+			response.Error(w, http.StatusBadRequest, "Pick a service type from the catalog", err.Error())
+			return
+		}
+		if errors.Is(err, usecases.ErrShopClosed) {
+			response.Error(w, http.StatusUnprocessableEntity, "The shop is closed on the selected date", err.Error())
+			return
+		}
+		if errors.Is(err, repositories.ErrDailyLimitReached) {
+			response.Error(w, http.StatusConflict, "This date is fully booked, join the standby list instead", err.Error())
+			return
+		}
 		response.Error(w, http.StatusInternalServerError, "Failed to take queue number", err)
 		return
 	}
 	resp := dto.WaitingListResponse{
-		ID:            waitingList.ID,
-		QueueNumber:   waitingList.QueueNumber,
-		VehicleID:     waitingList.VehicleID,
-		CustomerID:    waitingList.CustomerID,
-		ServiceDate:   waitingList.ServiceDate,
-		ServiceType:   waitingList.ServiceType,
-		EstimatedTime: waitingList.EstimatedTime,
-		Status:        string(waitingList.Status),
-		Notes:         waitingList.Notes,
-		CreatedAt:     waitingList.CreatedAt,
-		UpdatedAt:     waitingList.UpdatedAt,
+		ID:              waitingList.ID,
+		QueueNumber:     waitingList.QueueNumber,
+		VehicleID:       waitingList.VehicleID,
+		CustomerID:      waitingList.CustomerID,
+		ServiceDate:     waitingList.ServiceDate,
+		ServiceType:     waitingList.ServiceType,
+		ServiceTypeCode: waitingList.ServiceTypeCode,
+		EstimatedTime:   waitingList.EstimatedTime,
+		Status:          string(waitingList.Status),
+		Notes:           waitingList.Notes,
+		CreatedAt:       waitingList.CreatedAt,
+		UpdatedAt:       waitingList.UpdatedAt,
 	}
 	response.Success(w, http.StatusCreated, "Queue number taken successfully", resp)
 }
+func (h *WaitingListHandler) BookSlot(w http.ResponseWriter, r *http.Request) {
+	var req dto.BookSlotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	customerID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	serviceDate, err := time.Parse("2006-01-02", req.ServiceDate)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid service_date format. Use YYYY-MM-DD", err)
+		return
+	}
+	appointmentAt, err := time.Parse("2006-01-02 15:04", req.ServiceDate+" "+req.SlotTime)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid slot_time format. Use HH:MM", err)
+		return
+	}
+	waitingList := &entities.WaitingList{
+		VehicleID:     req.VehicleID,
+		CustomerID:    customerID,
+		ServiceDate:   serviceDate,
+		ServiceType:   req.ServiceType,
+		EstimatedTime: req.EstimatedTime,
+		AppointmentAt: &appointmentAt,
+		Notes:         req.Notes,
+	}
+	if err := h.waitingListUsecase.BookSlot(r.Context(), waitingList); err != nil {
+		if errors.Is(err, usecases.ErrUnknownServiceType) {
+			response.Error(w, http.StatusBadRequest, "Pick a service type from the catalog", err.Error())
+			return
+		}
+		if errors.Is(err, repositories.ErrSlotUnavailable) {
+			response.Error(w, http.StatusConflict, "Time slot is no longer available", err.Error())
+			return
+		}
+		if errors.Is(err, usecases.ErrShopClosed) {
+			response.Error(w, http.StatusUnprocessableEntity, "The shop is closed at the selected time", err.Error())
+			return
+		}
+		if errors.Is(err, repositories.ErrDailyLimitReached) {
+			response.Error(w, http.StatusConflict, "This date is fully booked, pick another date", err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "Failed to book time slot", err)
+		return
+	}
+	resp := dto.WaitingListResponse{
+		ID:              waitingList.ID,
+		QueueNumber:     waitingList.QueueNumber,
+		VehicleID:       waitingList.VehicleID,
+		CustomerID:      waitingList.CustomerID,
+		ServiceDate:     waitingList.ServiceDate,
+		ServiceType:     waitingList.ServiceType,
+		ServiceTypeCode: waitingList.ServiceTypeCode,
+		EstimatedTime:   waitingList.EstimatedTime,
+		AppointmentAt:   waitingList.AppointmentAt,
+		Status:          string(waitingList.Status),
+		Notes:           waitingList.Notes,
+		CreatedAt:       waitingList.CreatedAt,
+		UpdatedAt:       waitingList.UpdatedAt,
+	}
+	response.Success(w, http.StatusCreated, "Time slot booked successfully", resp)
+}
 func (h *WaitingListHandler) GetMyQueue(w http.ResponseWriter, r *http.Request) {
 	customerID, ok := r.Context().Value("id").(types.MSSQLUUID)
 	if !ok {
@@ -81,16 +166,74 @@ func (h *WaitingListHandler) GetMyQueue(w http.ResponseWriter, r *http.Request)
 		})
 		return
 	}
-	if len(waitingLists) == 0 {
+	standby, err := h.waitingListUsecase.GetCustomerStandby(r.Context(), customerID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get standby entries", err)
+		return
+	}
+	if len(waitingLists) == 0 && len(standby) == 0 {
 		response.Success(w, http.StatusOK, "No queue entries found", []interface{}{})
 		return
 	}
-	resp := make([]dto.WaitingListWithDetailsResponse, len(waitingLists))
-	for i, wl := range waitingLists {
-		resp[i] = h.buildDetailResponse(wl)
+	resp := make([]dto.WaitingListWithDetailsResponse, 0, len(waitingLists)+len(standby))
+	for _, wl := range waitingLists {
+		resp = append(resp, h.buildDetailResponse(wl))
+	}
+	for _, entry := range standby {
+		resp = append(resp, h.buildStandbyDetailResponse(entry))
 	}
 	response.Success(w, http.StatusOK, "Queue entries retrieved successfully", resp)
 }
+func (h *WaitingListHandler) JoinStandby(w http.ResponseWriter, r *http.Request) {
+	var req dto.TakeQueueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	customerID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	serviceDate, err := time.Parse("2006-01-02", req.ServiceDate)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid service_date format. Use YYYY-MM-DD", err)
+		return
+	}
+	entry := &entities.StandbyEntry{
+		VehicleID:     req.VehicleID,
+		CustomerID:    customerID,
+		ServiceDate:   serviceDate,
+		ServiceType:   req.ServiceType,
+		EstimatedTime: req.EstimatedTime,
+		Notes:         req.Notes,
+	}
+	if err := h.waitingListUsecase.JoinStandby(r.Context(), entry); err != nil {
+		if errors.Is(err, usecases.ErrShopClosed) {
+			response.Error(w, http.StatusUnprocessableEntity, "The shop is closed on the selected date", err.Error())
+			return
+		}
+		if errors.Is(err, usecases.ErrTicketsAvailable) {
+			response.Error(w, http.StatusConflict, "Tickets are still available for this date", err.Error())
+			return
+		}
+		response.Error(w, http.StatusBadRequest, "Failed to join standby list", err.Error())
+		return
+	}
+	resp := dto.StandbyResponse{
+		ID:            entry.ID,
+		VehicleID:     entry.VehicleID,
+		CustomerID:    entry.CustomerID,
+		ServiceDate:   entry.ServiceDate,
+		ServiceType:   entry.ServiceType,
+		EstimatedTime: entry.EstimatedTime,
+		Status:        string(entry.Status),
+		Position:      entry.Position,
+		Notes:         entry.Notes,
+		CreatedAt:     entry.CreatedAt,
+	}
+	response.Success(w, http.StatusCreated, "Joined the standby list successfully", resp)
+}
 func (h *WaitingListHandler) GetTodayQueue(w http.ResponseWriter, r *http.Request) {
 	waitingLists, err := h.waitingListUsecase.GetTodayQueue(r.Context())
 	if err != nil {
@@ -98,8 +241,78 @@ func (h *WaitingListHandler) GetTodayQueue(w http.ResponseWriter, r *http.Reques
 		return
 	}
 	resp := h.buildWaitingListResponse(waitingLists, time.Now())
+	if resp.Bays, err = h.waitingListUsecase.GetBayOverview(r.Context(), waitingLists); err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get service bays", err)
+		return
+	}
 	response.Success(w, http.StatusOK, "Today's queue retrieved successfully", resp)
 }
+
+// StreamTodayQueue pushes today's queue board as Server-Sent Events: a
+// "snapshot" on connect followed by an "update" for every status change.
+func (h *WaitingListHandler) StreamTodayQueue(w http.ResponseWriter, r *http.Request) {
+	updates, unsubscribe := h.waitingListUsecase.SubscribeQueueBoard()
+	defer unsubscribe()
+	waitingLists, err := h.waitingListUsecase.GetTodayQueue(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get today's queue", err)
+		return
+	}
+	// The server's write timeout would otherwise cut the stream after a few seconds
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	snapshot := make([]dto.QueueBoardEntry, len(waitingLists))
+	for i, wl := range waitingLists {
+		snapshot[i] = usecases.NewQueueBoardEntry(wl)
+	}
+	if err := writeEvent(w, "snapshot", snapshot); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(20 * time.Second)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case entry, ok := <-updates:
+			if !ok {
+				return
+			}
+			if entry.ServiceDate != time.Now().Format("2006-01-02") {
+				continue
+			}
+			if err := writeEvent(w, "update", entry); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+func writeEvent(w http.ResponseWriter, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
 func (h *WaitingListHandler) GetQueueByDate(w http.ResponseWriter, r *http.Request) {
 	dateStr := r.URL.Query().Get("date")
 	if dateStr == "" {
@@ -117,6 +330,10 @@ func (h *WaitingListHandler) GetQueueByDate(w http.ResponseWriter, r *http.Reque
 		return
 	}
 	resp := h.buildWaitingListResponse(waitingLists, serviceDate)
+	if resp.Bays, err = h.waitingListUsecase.GetBayOverview(r.Context(), waitingLists); err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get service bays", err)
+		return
+	}
 	response.Success(w, http.StatusOK, "Queue retrieved successfully", resp)
 }
 func (h *WaitingListHandler) GetQueueByNumber(w http.ResponseWriter, r *http.Request) {
@@ -151,7 +368,7 @@ func (h *WaitingListHandler) CallCustomer(w http.ResponseWriter, r *http.Request
 		return
 	}
 	if err := h.waitingListUsecase.CallCustomer(r.Context(), id); err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to call customer", err)
+		statusChangeError(w, "Failed to call customer", err)
 		return
 	}
 	response.Success(w, http.StatusOK, "Customer called successfully", nil)
@@ -163,12 +380,53 @@ func (h *WaitingListHandler) StartService(w http.ResponseWriter, r *http.Request
 		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
 		return
 	}
-	if err := h.waitingListUsecase.StartService(r.Context(), id); err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to start service", err)
+	var req dto.StartServiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if err := h.waitingListUsecase.StartService(r.Context(), id, req.ServiceBayID); err != nil {
+		if errors.Is(err, repositories.ErrBayOccupied) {
+			response.Error(w, http.StatusConflict, "Service bay is not free", err.Error())
+			return
+		}
+		statusChangeError(w, "Failed to start service", err)
 		return
 	}
 	response.Success(w, http.StatusOK, "Service started successfully", nil)
 }
+func (h *WaitingListHandler) AssignMechanic(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	var req dto.AssignMechanicRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	waitingList, err := h.waitingListUsecase.AssignMechanic(r.Context(), id, req.MechanicID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to assign mechanic", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Mechanic assigned successfully", h.buildDetailResponse(waitingList))
+}
+func (h *WaitingListHandler) GetMyJobsToday(w http.ResponseWriter, r *http.Request) {
+	mechanicID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	waitingLists, err := h.waitingListUsecase.GetMechanicJobsToday(r.Context(), mechanicID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get today's jobs", err)
+		return
+	}
+	resp := h.buildWaitingListResponse(waitingLists, time.Now())
+	response.Success(w, http.StatusOK, "Today's jobs retrieved successfully", resp)
+}
 func (h *WaitingListHandler) CompleteService(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := types.ParseMSSQLUUID(vars["id"])
@@ -176,8 +434,13 @@ func (h *WaitingListHandler) CompleteService(w http.ResponseWriter, r *http.Requ
 		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
 		return
 	}
-	if err := h.waitingListUsecase.CompleteService(r.Context(), id); err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to complete service", err)
+	warning, err := h.waitingListUsecase.CompleteService(r.Context(), id)
+	if err != nil {
+		statusChangeError(w, "Failed to complete service", err)
+		return
+	}
+	if warning != nil {
+		response.Success(w, http.StatusOK, "Service completed; final cost exceeds the accepted estimate", warning)
 		return
 	}
 	response.Success(w, http.StatusOK, "Service completed successfully", nil)
@@ -189,12 +452,224 @@ func (h *WaitingListHandler) CancelQueue(w http.ResponseWriter, r *http.Request)
 		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
 		return
 	}
-	if err := h.waitingListUsecase.CancelQueue(r.Context(), id); err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to cancel queue", err)
+	var req dto.CancelQueueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	ctx := usecases.WithTransitionReason(r.Context(), req.Reason)
+	if err := h.waitingListUsecase.CancelQueue(ctx, id); err != nil {
+		statusChangeError(w, "Failed to cancel queue", err)
 		return
 	}
 	response.Success(w, http.StatusOK, "Queue cancelled successfully", nil)
 }
+func (h *WaitingListHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	userID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	waitingList, err := h.waitingListUsecase.GetWaitingList(r.Context(), id)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, "Queue not found", err)
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+	if role != constants.RoleAdmin && role != constants.RoleMechanic && waitingList.CustomerID != userID {
+		response.Error(w, http.StatusForbidden, "You can only view the history of your own queue", nil)
+		return
+	}
+	transitions, err := h.waitingListUsecase.GetTransitionHistory(r.Context(), id)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get queue history", err)
+		return
+	}
+	resp := make([]dto.StatusTransitionResponse, 0, len(transitions))
+	for _, t := range transitions {
+		item := dto.StatusTransitionResponse{
+			FromStatus: string(t.FromStatus),
+			ToStatus:   string(t.ToStatus),
+			ActorID:    t.ActorID,
+			Reason:     t.Reason,
+			CreatedAt:  t.CreatedAt,
+		}
+		if t.Actor != nil {
+			item.ActorName = t.Actor.Name
+		}
+		resp = append(resp, item)
+	}
+	response.Success(w, http.StatusOK, "Queue history retrieved successfully", resp)
+}
+
+func (h *WaitingListHandler) GetQRCode(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	userID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	waitingList, err := h.waitingListUsecase.GetWaitingList(r.Context(), id)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, "Queue not found", err)
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+	if role != constants.RoleAdmin && waitingList.CustomerID != userID {
+		response.Error(w, http.StatusForbidden, "You can only get the QR code of your own queue", nil)
+		return
+	}
+	size := 256
+	if sizeStr := r.URL.Query().Get("size"); sizeStr != "" {
+		size, err = strconv.Atoi(sizeStr)
+		if err != nil || size < 64 || size > 1024 {
+			response.Error(w, http.StatusBadRequest, "size must be between 64 and 1024", nil)
+			return
+		}
+	}
+	token, err := h.waitingListUsecase.IssueCheckInToken(r.Context(), waitingList)
+	if err != nil {
+		statusChangeError(w, "Failed to issue check-in code", err)
+		return
+	}
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "png":
+		png, err := utils.QRCodePNG(token, size)
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "Failed to render QR code", err)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = w.Write(png)
+	case "svg":
+		svg, err := utils.QRCodeSVG(token, size)
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "Failed to render QR code", err)
+			return
+		}
+		w.Header().Set("Content-Type", "image/svg+xml")
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = io.WriteString(w, svg)
+	default:
+		response.Error(w, http.StatusBadRequest, "format must be png or svg", nil)
+	}
+}
+func (h *WaitingListHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	var req dto.CheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if req.Token == "" {
+		response.Error(w, http.StatusBadRequest, "token is required", nil)
+		return
+	}
+	waitingList, err := h.waitingListUsecase.CheckIn(r.Context(), req.Token)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrCheckInTokenInvalid), errors.Is(err, utils.ErrCheckInTokenExpired):
+			response.Error(w, http.StatusBadRequest, "Check-in code is not valid", err.Error())
+		case errors.Is(err, usecases.ErrCheckInWrongDay):
+			response.Error(w, http.StatusUnprocessableEntity, "Ticket is not for today", err.Error())
+		default:
+			statusChangeError(w, "Failed to check in", err)
+		}
+		return
+	}
+	resp := dto.CheckInResponse{
+		ID:           waitingList.ID,
+		QueueNumber:  waitingList.QueueNumber,
+		Status:       string(waitingList.Status),
+		LicensePlate: waitingList.Vehicle.LicensePlate,
+		CustomerName: waitingList.Customer.Name,
+		ArrivedAt:    waitingList.ArrivedAt,
+	}
+	response.Success(w, http.StatusOK, "Customer checked in successfully", resp)
+}
+
+// statusChangeError answers 409 for status changes the ticket state machine
+// rejects and 500 for anything else.
+func statusChangeError(w http.ResponseWriter, message string, err error) {
+	if errors.Is(err, usecases.ErrInvalidTransition) {
+		response.Error(w, http.StatusConflict, message, err.Error())
+		return
+	}
+	response.Error(w, http.StatusInternalServerError, message, err)
+}
+func (h *WaitingListHandler) RescheduleQueue(w http.ResponseWriter, r *http.Request) {
+	id, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+	userID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	var req dto.RescheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	serviceDate, err := time.Parse("2006-01-02", req.ServiceDate)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid service_date format. Use YYYY-MM-DD", err)
+		return
+	}
+	waitingList, err := h.waitingListUsecase.GetWaitingList(r.Context(), id)
+	if err != nil {
+		response.Error(w, http.StatusNotFound, "Queue not found", err)
+		return
+	}
+	if role, _ := r.Context().Value("role").(string); role != constants.RoleAdmin && waitingList.CustomerID != userID {
+		response.Error(w, http.StatusForbidden, "You can only reschedule your own queue", nil)
+		return
+	}
+	waitingList, err = h.waitingListUsecase.RescheduleQueue(r.Context(), id, serviceDate)
+	if err != nil {
+		if errors.Is(err, usecases.ErrShopClosed) {
+			response.Error(w, http.StatusUnprocessableEntity, "The shop is closed on the selected date", err.Error())
+			return
+		}
+		response.Error(w, http.StatusBadRequest, "Failed to reschedule queue", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Queue rescheduled successfully", h.buildDetailResponse(waitingList))
+}
+func (h *WaitingListHandler) BulkReschedule(w http.ResponseWriter, r *http.Request) {
+	var req dto.BulkRescheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	fromDate, err := time.Parse("2006-01-02", req.FromDate)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid from_date format. Use YYYY-MM-DD", err)
+		return
+	}
+	toDate, err := time.Parse("2006-01-02", req.ToDate)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid to_date format. Use YYYY-MM-DD", err)
+		return
+	}
+	result, err := h.waitingListUsecase.BulkReschedule(r.Context(), fromDate, toDate)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to reschedule queue", err)
+		return
+	}
+	response.Success(w, http.StatusOK, fmt.Sprintf("%d tickets moved, %d failed", len(result.Moved), len(result.Failed)), result)
+}
 func (h *WaitingListHandler) MarkNoShow(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := types.ParseMSSQLUUID(vars["id"])
@@ -203,7 +678,7 @@ func (h *WaitingListHandler) MarkNoShow(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	if err := h.waitingListUsecase.MarkNoShow(r.Context(), id); err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to mark no-show", err)
+		statusChangeError(w, "Failed to mark no-show", err)
 		return
 	}
 	response.Success(w, http.StatusOK, "Marked as no-show successfully", nil)
@@ -221,25 +696,76 @@ func (h *WaitingListHandler) CheckAvailability(w http.ResponseWriter, r *http.Re
 			return
 		}
 	}
-	available, remaining, err := h.waitingListUsecase.CheckTicketAvailability(r.Context(), serviceDate)
+	availability, err := h.waitingListUsecase.GetDayAvailability(r.Context(), serviceDate)
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "Failed to check availability", err)
 		return
 	}
 	resp := map[string]interface{}{
 		"date":              serviceDate.Format("2006-01-02"),
-		"available":         available,
-		"remaining_tickets": remaining,
-		"max_tickets":       10,
+		"mode":              availability.Mode,
+		"available":         availability.Available,
+		"remaining_tickets": availability.RemainingTickets,
+		"max_tickets":       availability.MaxTickets,
+		"capacity_minutes":  availability.CapacityMinutes,
+		"used_minutes":      availability.UsedMinutes,
+		"remaining_minutes": availability.RemainingMinutes,
 		"message": func() string {
-			if available {
-				return fmt.Sprintf("%d tickets remaining for this date", remaining)
+			if availability.Available {
+				if availability.Mode == usecases.LimitModeCapacity {
+					return fmt.Sprintf("%d service minutes remaining for this date", availability.RemainingMinutes)
+				}
+				return fmt.Sprintf("%d tickets remaining for this date", availability.RemainingTickets)
+			}
+			if err := h.waitingListUsecase.CheckShopOpen(r.Context(), serviceDate); err != nil {
+				return err.Error()
+			}
+			if availability.Mode == usecases.LimitModeCapacity {
+				return "No service capacity left for this date"
 			}
 			return "No tickets available for this date (limit reached)"
 		}(),
 	}
 	response.Success(w, http.StatusOK, "Availability checked successfully", resp)
 }
+func (h *WaitingListHandler) GetAvailableSlots(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	from := time.Now()
+	if dateStr := query.Get("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "Invalid date format. Use YYYY-MM-DD", err)
+			return
+		}
+		from = parsed
+	} else {
+		from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	days := 1
+	if daysStr := query.Get("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 || parsed > 31 {
+			response.Error(w, http.StatusBadRequest, "days must be a number between 1 and 31", err)
+			return
+		}
+		days = parsed
+	}
+	duration := 0
+	if durationStr := query.Get("duration"); durationStr != "" {
+		parsed, err := strconv.Atoi(durationStr)
+		if err != nil || parsed < 0 {
+			response.Error(w, http.StatusBadRequest, "duration must be a positive number of minutes", err)
+			return
+		}
+		duration = parsed
+	}
+	slots, err := h.waitingListUsecase.GetAvailableSlots(r.Context(), from, days, duration)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get available slots", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Available slots retrieved successfully", slots)
+}
 func (h *WaitingListHandler) GetServiceProgress(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	idStr := vars["id"]
@@ -262,28 +788,12 @@ func (h *WaitingListHandler) GetServiceProgress(w http.ResponseWriter, r *http.R
 		response.Error(w, http.StatusForbidden, "You don't have permission to view this ticket", nil)
 		return
 	}
-	allTickets, err := h.waitingListUsecase.GetQueueByDate(r.Context(), waitingList.ServiceDate)
+	estimate, allTickets, err := h.waitingListUsecase.CheckServiceProgress(r.Context(), waitingList)
 	if err != nil {
 		response.Error(w, http.StatusInternalServerError, "Failed to retrieve queue information", err)
 		return
 	}
-	var currentlyServing int
-	var waitingAhead int
-	for _, ticket := range allTickets {
-		if ticket.Status == entities.WaitingListStatusInService {
-			currentlyServing = ticket.QueueNumber
-		}
-		if ticket.QueueNumber < waitingList.QueueNumber &&
-			(ticket.Status == entities.WaitingListStatusWaiting || ticket.Status == entities.WaitingListStatusCalled) {
-			waitingAhead++
-		}
-	}
-	estimatedWaitMin := waitingAhead * 30
-	if waitingList.Status == entities.WaitingListStatusInService ||
-		waitingList.Status == entities.WaitingListStatusCompleted {
-		estimatedWaitMin = 0
-	}
-	statusMessage := h.generateStatusMessage(waitingList.Status, waitingAhead, currentlyServing, waitingList.QueueNumber)
+	statusMessage := h.generateStatusMessage(waitingList.Status, estimate.WaitingAhead, estimate.CurrentlyServing, waitingList.QueueNumber)
 	resp := dto.ServiceProgressResponse{
 		ID:            waitingList.ID,
 		QueueNumber:   waitingList.QueueNumber,
@@ -293,15 +803,25 @@ func (h *WaitingListHandler) GetServiceProgress(w http.ResponseWriter, r *http.R
 		ServiceDate:   waitingList.ServiceDate,
 		EstimatedTime: waitingList.EstimatedTime,
 		QueuePosition: waitingList.QueueNumber,
-		PeopleAhead:   waitingAhead,
-		EstimatedWait: estimatedWaitMin,
+		PeopleAhead:   estimate.WaitingAhead,
+		EstimatedWait: estimate.WaitMinutes,
 		Timeline: dto.Timeline{
 			QueueTakenAt:   waitingList.CreatedAt,
+			ArrivedAt:      waitingList.ArrivedAt,
 			CalledAt:       waitingList.CalledAt,
 			ServiceStartAt: waitingList.ServiceStartAt,
 			ServiceEndAt:   waitingList.ServiceEndAt,
 		},
-		Notes: waitingList.Notes,
+		EstimatedCalledAt: estimate.EstimatedCalledAt,
+		EstimatedDoneAt:   estimate.EstimatedDoneAt,
+		Notes:             waitingList.Notes,
+	}
+	if waitingList.ServiceBay != nil {
+		resp.ServiceBayName = waitingList.ServiceBay.Name
+	}
+	if resp.Bays, err = h.waitingListUsecase.GetBayOverview(r.Context(), allTickets); err != nil {
+		response.Error(w, http.StatusInternalServerError, "Failed to get service bays", err)
+		return
 	}
 	if waitingList.Vehicle.ID.String() != "00000000-0000-0000-0000-000000000000" {
 		resp.VehicleBrand = waitingList.Vehicle.Brand
@@ -347,29 +867,67 @@ func (h *WaitingListHandler) buildWaitingListResponse(waitingLists []*entities.W
 }
 func (h *WaitingListHandler) buildDetailResponse(wl *entities.WaitingList) dto.WaitingListWithDetailsResponse {
 	resp := dto.WaitingListWithDetailsResponse{
-		ID:             wl.ID,
-		QueueNumber:    wl.QueueNumber,
-		VehicleID:      wl.VehicleID,
-		CustomerID:     wl.CustomerID,
-		ServiceDate:    wl.ServiceDate,
-		ServiceType:    wl.ServiceType,
-		EstimatedTime:  wl.EstimatedTime,
-		Status:         string(wl.Status),
-		CalledAt:       wl.CalledAt,
-		ServiceStartAt: wl.ServiceStartAt,
-		ServiceEndAt:   wl.ServiceEndAt,
-		Notes:          wl.Notes,
-		CreatedAt:      wl.CreatedAt,
-		UpdatedAt:      wl.UpdatedAt,
+		ID:                   wl.ID,
+		QueueNumber:          wl.QueueNumber,
+		VehicleID:            wl.VehicleID,
+		CustomerID:           wl.CustomerID,
+		ServiceDate:          wl.ServiceDate,
+		ServiceType:          wl.ServiceType,
+		ServiceTypeCode:      wl.ServiceTypeCode,
+		EstimatedTime:        wl.EstimatedTime,
+		AppointmentAt:        wl.AppointmentAt,
+		Status:               string(wl.Status),
+		ServiceBayID:         wl.ServiceBayID,
+		MechanicID:           wl.MechanicID,
+		RescheduledFromDate:  wl.RescheduledFromDate,
+		RescheduledFromQueue: wl.RescheduledFromQueue,
+		ArrivedAt:            wl.ArrivedAt,
+		CalledAt:             wl.CalledAt,
+		ServiceStartAt:       wl.ServiceStartAt,
+		ServiceEndAt:         wl.ServiceEndAt,
+		Notes:                wl.Notes,
+		CreatedAt:            wl.CreatedAt,
+		UpdatedAt:            wl.UpdatedAt,
 	}
 	if wl.Vehicle.ID.String() != "00000000-0000-0000-0000-000000000000" {
 		resp.VehicleBrand = wl.Vehicle.Brand
 		resp.VehicleModel = wl.Vehicle.Model
 		resp.LicensePlate = wl.Vehicle.LicensePlate
 	}
+	if wl.ServiceBay != nil {
+		resp.ServiceBayName = wl.ServiceBay.Name
+	}
+	if wl.Mechanic != nil {
+		resp.MechanicName = wl.Mechanic.Name
+	}
 	if wl.Customer.ID.String() != "00000000-0000-0000-0000-000000000000" {
 		resp.CustomerName = wl.Customer.Name
 		resp.CustomerPhone = wl.Customer.Phone
 	}
 	return resp
 }
+func (h *WaitingListHandler) buildStandbyDetailResponse(entry *entities.StandbyEntry) dto.WaitingListWithDetailsResponse {
+	resp := dto.WaitingListWithDetailsResponse{
+		ID:              entry.ID,
+		VehicleID:       entry.VehicleID,
+		CustomerID:      entry.CustomerID,
+		ServiceDate:     entry.ServiceDate,
+		ServiceType:     entry.ServiceType,
+		EstimatedTime:   entry.EstimatedTime,
+		Status:          "standby",
+		StandbyPosition: entry.Position,
+		Notes:           entry.Notes,
+		CreatedAt:       entry.CreatedAt,
+		UpdatedAt:       entry.UpdatedAt,
+	}
+	if entry.Vehicle.ID.String() != "00000000-0000-0000-0000-000000000000" {
+		resp.VehicleBrand = entry.Vehicle.Brand
+		resp.VehicleModel = entry.Vehicle.Model
+		resp.LicensePlate = entry.Vehicle.LicensePlate
+	}
+	if entry.Customer.ID.String() != "00000000-0000-0000-0000-000000000000" {
+		resp.CustomerName = entry.Customer.Name
+		resp.CustomerPhone = entry.Customer.Phone
+	}
+	return resp
+}
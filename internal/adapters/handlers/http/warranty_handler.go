@@ -0,0 +1,90 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kuahbanyak/go-crud/internal/adapters/handlers/http/middleware"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/kuahbanyak/go-crud/pkg/response"
+)
+
+type WarrantyHandler struct {
+	warrantyUsecase *usecases.WarrantyUsecase
+}
+
+func NewWarrantyHandler(warrantyUsecase *usecases.WarrantyUsecase) *WarrantyHandler {
+	return &WarrantyHandler{
+		warrantyUsecase: warrantyUsecase,
+	}
+}
+func (h *WarrantyHandler) GetActiveWarranties(w http.ResponseWriter, r *http.Request) {
+	vehicleID, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid vehicle ID", err)
+		return
+	}
+	userID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+	warranties, err := h.warrantyUsecase.GetActiveWarranties(r.Context(), userID, role, vehicleID)
+	if err != nil {
+		warrantyError(w, "Failed to get warranties", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Warranties retrieved successfully", warranties)
+}
+func (h *WarrantyHandler) OpenClaim(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := r.Context().Value("id").(types.MSSQLUUID)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+	var req dto.OpenWarrantyClaimRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if appErr := middleware.ValidateStruct(&req); appErr != nil {
+		response.ErrorFromAppError(r.Context(), w, appErr)
+		return
+	}
+	claim, err := h.warrantyUsecase.OpenClaim(r.Context(), staffID, req)
+	if err != nil {
+		warrantyError(w, "Failed to open warranty claim", err)
+		return
+	}
+	response.Success(w, http.StatusCreated, "Warranty claim opened successfully", claim)
+}
+func (h *WarrantyHandler) GetClaimsByVehicle(w http.ResponseWriter, r *http.Request) {
+	vehicleID, err := types.ParseMSSQLUUID(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid vehicle ID", err)
+		return
+	}
+	claims, err := h.warrantyUsecase.GetClaimsByVehicle(r.Context(), vehicleID)
+	if err != nil {
+		warrantyError(w, "Failed to get warranty claims", err)
+		return
+	}
+	response.Success(w, http.StatusOK, "Warranty claims retrieved successfully", claims)
+}
+func warrantyError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrWarrantyNotFound):
+		response.Error(w, http.StatusNotFound, message, err.Error())
+	case errors.Is(err, usecases.ErrWarrantyForbidden):
+		response.Error(w, http.StatusForbidden, message, err.Error())
+	case errors.Is(err, usecases.ErrWarrantyExpired), errors.Is(err, usecases.ErrWarrantyClaimExists):
+		response.Error(w, http.StatusConflict, message, err.Error())
+	default:
+		response.Error(w, http.StatusBadRequest, message, err.Error())
+	}
+}
@@ -0,0 +1,38 @@
+package mssql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type approvalTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewApprovalTokenRepository(db *gorm.DB) repositories.ApprovalTokenRepository {
+	return &approvalTokenRepository{db: db}
+}
+func (r *approvalTokenRepository) Create(ctx context.Context, token *entities.ApprovalToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+func (r *approvalTokenRepository) GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.ApprovalToken, error) {
+	var token entities.ApprovalToken
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+func (r *approvalTokenRepository) RevokeByWaitingList(ctx context.Context, waitingListID types.MSSQLUUID) error {
+	return r.db.WithContext(ctx).
+		Where("waiting_list_id = ?", waitingListID).
+		Delete(&entities.ApprovalToken{}).Error
+}
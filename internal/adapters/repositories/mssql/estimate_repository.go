@@ -0,0 +1,100 @@
+package mssql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type estimateRepository struct {
+	db *gorm.DB
+}
+
+func NewEstimateRepository(db *gorm.DB) repositories.EstimateRepository {
+	return &estimateRepository{db: db}
+}
+func (r *estimateRepository) Create(ctx context.Context, estimate *entities.Estimate) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var version int
+		err := tx.Unscoped().
+			Model(&entities.Estimate{}).
+			Select("COALESCE(MAX(version), 0)").
+			Where("waiting_list_id = ?", estimate.WaitingListID).
+			Scan(&version).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&entities.Estimate{}).
+			Where("waiting_list_id = ? AND status = ?", estimate.WaitingListID, entities.EstimateStatusSent).
+			Updates(map[string]interface{}{
+				"status":     entities.EstimateStatusSuperseded,
+				"updated_at": time.Now(),
+			}).Error
+		if err != nil {
+			return err
+		}
+		estimate.Version = version + 1
+		return tx.Create(estimate).Error
+	})
+}
+func (r *estimateRepository) GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.Estimate, error) {
+	return r.first(r.db.WithContext(ctx).Where("id = ?", id))
+}
+func (r *estimateRepository) GetByWaitingListID(ctx context.Context, waitingListID types.MSSQLUUID) ([]*entities.Estimate, error) {
+	var estimates []*entities.Estimate
+	err := r.preload(r.db.WithContext(ctx)).
+		Where("waiting_list_id = ?", waitingListID).
+		Order("version DESC").
+		Find(&estimates).Error
+	return estimates, err
+}
+func (r *estimateRepository) GetLatest(ctx context.Context, waitingListID types.MSSQLUUID) (*entities.Estimate, error) {
+	return r.first(r.db.WithContext(ctx).
+		Where("waiting_list_id = ?", waitingListID).
+		Order("version DESC"))
+}
+func (r *estimateRepository) GetLatestAccepted(ctx context.Context, waitingListID types.MSSQLUUID) (*entities.Estimate, error) {
+	return r.first(r.db.WithContext(ctx).
+		Where("waiting_list_id = ? AND status = ?", waitingListID, entities.EstimateStatusAccepted).
+		Order("version DESC"))
+}
+func (r *estimateRepository) Decide(ctx context.Context, estimate *entities.Estimate) error {
+	result := r.db.WithContext(ctx).
+		Model(&entities.Estimate{}).
+		Where("id = ? AND status = ?", estimate.ID, entities.EstimateStatusSent).
+		Updates(map[string]interface{}{
+			"status":        estimate.Status,
+			"decided_at":    estimate.DecidedAt,
+			"decision_note": estimate.DecisionNote,
+			"updated_at":    time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: version %d", repositories.ErrEstimateNotOpen, estimate.Version)
+	}
+	return nil
+}
+func (r *estimateRepository) first(query *gorm.DB) (*entities.Estimate, error) {
+	var estimate entities.Estimate
+	err := r.preload(query).First(&estimate).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &estimate, nil
+}
+func (r *estimateRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).Preload("Lines.Parts")
+}
@@ -0,0 +1,147 @@
+package mssql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type inspectionTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewInspectionTemplateRepository(db *gorm.DB) repositories.InspectionTemplateRepository {
+	return &inspectionTemplateRepository{db: db}
+}
+func (r *inspectionTemplateRepository) Create(ctx context.Context, template *entities.InspectionTemplate) error {
+	return r.db.WithContext(ctx).Create(template).Error
+}
+func (r *inspectionTemplateRepository) GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.InspectionTemplate, error) {
+	var template entities.InspectionTemplate
+	err := r.preload(r.db.WithContext(ctx)).Where("id = ?", id).First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+func (r *inspectionTemplateRepository) GetAll(ctx context.Context) ([]*entities.InspectionTemplate, error) {
+	var templates []*entities.InspectionTemplate
+	err := r.preload(r.db.WithContext(ctx)).Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+func (r *inspectionTemplateRepository) GetActive(ctx context.Context) ([]*entities.InspectionTemplate, error) {
+	var templates []*entities.InspectionTemplate
+	err := r.preload(r.db.WithContext(ctx)).Where("is_active = ?", true).Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+func (r *inspectionTemplateRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.Preload("Points", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	})
+}
+func (r *inspectionTemplateRepository) Update(ctx context.Context, template *entities.InspectionTemplate) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("template_id = ?", template.ID).Delete(&entities.InspectionTemplatePoint{}).Error; err != nil {
+			return err
+		}
+		points := template.Points
+		if err := tx.Omit("Points").Save(template).Error; err != nil {
+			return err
+		}
+		for i := range points {
+			points[i].ID = types.MSSQLUUID{}
+			points[i].TemplateID = template.ID
+		}
+		if len(points) > 0 {
+			if err := tx.Create(&points).Error; err != nil {
+				return err
+			}
+		}
+		template.Points = points
+		return nil
+	})
+}
+func (r *inspectionTemplateRepository) Delete(ctx context.Context, id types.MSSQLUUID) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&entities.InspectionTemplate{}).Error
+}
+
+type inspectionRepository struct {
+	db *gorm.DB
+}
+
+func NewInspectionRepository(db *gorm.DB) repositories.InspectionRepository {
+	return &inspectionRepository{db: db}
+}
+func (r *inspectionRepository) Create(ctx context.Context, inspection *entities.Inspection) error {
+	return r.db.WithContext(ctx).Omit("Mechanic").Create(inspection).Error
+}
+func (r *inspectionRepository) GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.Inspection, error) {
+	return r.first(ctx, "id = ?", id)
+}
+func (r *inspectionRepository) GetByWaitingListID(ctx context.Context, waitingListID types.MSSQLUUID) (*entities.Inspection, error) {
+	return r.first(ctx, "waiting_list_id = ?", waitingListID)
+}
+func (r *inspectionRepository) first(ctx context.Context, query string, args ...interface{}) (*entities.Inspection, error) {
+	var inspection entities.Inspection
+	err := r.db.WithContext(ctx).
+		Preload("Results", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Mechanic").
+		Where(query, args...).
+		Order("created_at DESC").
+		First(&inspection).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &inspection, nil
+}
+func (r *inspectionRepository) Update(ctx context.Context, inspection *entities.Inspection, results []*entities.InspectionResult) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Results", "Mechanic").Save(inspection).Error; err != nil {
+			return err
+		}
+		for _, result := range results {
+			if err := tx.Save(result).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+func (r *inspectionRepository) AddFindings(ctx context.Context, inspection *entities.Inspection, results []*entities.InspectionResult, items []*entities.MaintenanceItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		claim := tx.Model(&entities.Inspection{}).
+			Where("id = ? AND findings_at IS NULL", inspection.ID).
+			Update("findings_at", now)
+		if claim.Error != nil {
+			return claim.Error
+		}
+		if claim.RowsAffected == 0 {
+			return repositories.ErrFindingsAlreadyAdded
+		}
+		if err := tx.Create(&items).Error; err != nil {
+			return err
+		}
+		for i, result := range results {
+			result.MaintenanceItemID = &items[i].ID
+			if err := tx.Save(result).Error; err != nil {
+				return err
+			}
+		}
+		inspection.FindingsAt = &now
+		return nil
+	})
+}
@@ -0,0 +1,64 @@
+package mssql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type kioskDeviceRepository struct {
+	db *gorm.DB
+}
+
+func NewKioskDeviceRepository(db *gorm.DB) repositories.KioskDeviceRepository {
+	return &kioskDeviceRepository{db: db}
+}
+func (r *kioskDeviceRepository) Create(ctx context.Context, device *entities.KioskDevice) error {
+	return r.db.WithContext(ctx).Create(device).Error
+}
+func (r *kioskDeviceRepository) GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.KioskDevice, error) {
+	var device entities.KioskDevice
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&device).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &device, nil
+}
+func (r *kioskDeviceRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entities.KioskDevice, error) {
+	var device entities.KioskDevice
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&device).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &device, nil
+}
+func (r *kioskDeviceRepository) GetAll(ctx context.Context) ([]*entities.KioskDevice, error) {
+	var devices []*entities.KioskDevice
+	err := r.db.WithContext(ctx).Order("created_at ASC").Find(&devices).Error
+	return devices, err
+}
+func (r *kioskDeviceRepository) Update(ctx context.Context, device *entities.KioskDevice) error {
+	return r.db.WithContext(ctx).Save(device).Error
+}
+func (r *kioskDeviceRepository) CreateActivity(ctx context.Context, activity *entities.KioskActivity) error {
+	return r.db.WithContext(ctx).Create(activity).Error
+}
+func (r *kioskDeviceRepository) GetActivity(ctx context.Context, deviceID types.MSSQLUUID, limit int) ([]*entities.KioskActivity, error) {
+	var activity []*entities.KioskActivity
+	err := r.db.WithContext(ctx).
+		Where("kiosk_device_id = ?", deviceID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&activity).Error
+	return activity, err
+}
@@ -0,0 +1,77 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/infrastructure/database"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type maintenanceItemApprovalRepository struct {
+	db *gorm.DB
+	tm *database.TransactionManager
+}
+
+func NewMaintenanceItemApprovalRepository(db *gorm.DB) repositories.MaintenanceItemApprovalRepository {
+	return &maintenanceItemApprovalRepository{db: db, tm: database.NewTransactionManager(db)}
+}
+func (r *maintenanceItemApprovalRepository) RecordDecisions(ctx context.Context, approvals []*entities.MaintenanceItemApproval) error {
+	return r.tm.WithTransaction(ctx, func(tx *gorm.DB) error {
+		return recordDecisions(tx, approvals)
+	})
+}
+func (r *maintenanceItemApprovalRepository) RecordLinkDecisions(ctx context.Context, tokenID types.MSSQLUUID, approvals []*entities.MaintenanceItemApproval) error {
+	return r.tm.WithTransaction(ctx, func(tx *gorm.DB) error {
+		result := tx.Model(&entities.ApprovalToken{}).
+			Where("id = ? AND used_at IS NULL", tokenID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repositories.ErrApprovalTokenUsed
+		}
+		return recordDecisions(tx, approvals)
+	})
+}
+func recordDecisions(tx *gorm.DB, approvals []*entities.MaintenanceItemApproval) error {
+	now := time.Now()
+	for _, approval := range approvals {
+		updates := map[string]interface{}{
+			"status":     entities.MaintenanceItemStatusRejected,
+			"updated_at": now,
+		}
+		if approval.Decision == entities.ApprovalDecisionApproved {
+			updates["status"] = entities.MaintenanceItemStatusApproved
+			updates["approved_at"] = now
+		}
+		result := tx.Model(&entities.MaintenanceItem{}).
+			Where("id = ? AND status = ?", approval.MaintenanceItemID, entities.MaintenanceItemStatusInspected).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: %s", repositories.ErrItemNotAwaitingApproval, approval.MaintenanceItemID)
+		}
+		if err := tx.Omit("MaintenanceItem", "RecordedBy").Create(approval).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (r *maintenanceItemApprovalRepository) GetByWaitingListID(ctx context.Context, waitingListID types.MSSQLUUID) ([]*entities.MaintenanceItemApproval, error) {
+	var approvals []*entities.MaintenanceItemApproval
+	err := r.db.WithContext(ctx).
+		Preload("MaintenanceItem", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("RecordedBy").
+		Where("waiting_list_id = ?", waitingListID).
+		Order("created_at ASC").
+		Find(&approvals).Error
+	return approvals, err
+}
@@ -0,0 +1,45 @@
+package mssql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type maintenanceItemAttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewMaintenanceItemAttachmentRepository(db *gorm.DB) repositories.MaintenanceItemAttachmentRepository {
+	return &maintenanceItemAttachmentRepository{db: db}
+}
+func (r *maintenanceItemAttachmentRepository) Create(ctx context.Context, attachment *entities.MaintenanceItemAttachment) error {
+	return r.db.WithContext(ctx).Omit("UploadedBy").Create(attachment).Error
+}
+func (r *maintenanceItemAttachmentRepository) GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.MaintenanceItemAttachment, error) {
+	var attachment entities.MaintenanceItemAttachment
+	err := r.db.WithContext(ctx).Preload("UploadedBy").Where("id = ?", id).First(&attachment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+func (r *maintenanceItemAttachmentRepository) GetByItemID(ctx context.Context, itemID types.MSSQLUUID) ([]*entities.MaintenanceItemAttachment, error) {
+	var attachments []*entities.MaintenanceItemAttachment
+	err := r.db.WithContext(ctx).
+		Preload("UploadedBy").
+		Where("maintenance_item_id = ?", itemID).
+		Order("created_at ASC").
+		Find(&attachments).Error
+	return attachments, err
+}
+func (r *maintenanceItemAttachmentRepository) Delete(ctx context.Context, id types.MSSQLUUID) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&entities.MaintenanceItemAttachment{}).Error
+}
@@ -0,0 +1,61 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/infrastructure/database"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type maintenanceItemLogRepository struct {
+	db *gorm.DB
+	tm *database.TransactionManager
+}
+
+func NewMaintenanceItemLogRepository(db *gorm.DB) repositories.MaintenanceItemLogRepository {
+	return &maintenanceItemLogRepository{db: db, tm: database.NewTransactionManager(db)}
+}
+func (r *maintenanceItemLogRepository) Create(ctx context.Context, log *entities.MaintenanceItemLog) error {
+	return r.db.WithContext(ctx).Create(log).Error
+}
+func (r *maintenanceItemLogRepository) GetByItemID(ctx context.Context, itemID types.MSSQLUUID) ([]*entities.MaintenanceItemLog, error) {
+	var logs []*entities.MaintenanceItemLog
+	err := r.db.WithContext(ctx).
+		Where("maintenance_item_id = ?", itemID).
+		Order("created_at ASC").
+		Find(&logs).Error
+	return logs, err
+}
+func (r *maintenanceItemLogRepository) GetByItemIDs(ctx context.Context, itemIDs []types.MSSQLUUID) ([]*entities.MaintenanceItemLog, error) {
+	var logs []*entities.MaintenanceItemLog
+	if len(itemIDs) == 0 {
+		return logs, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("maintenance_item_id IN ?", itemIDs).
+		Order("created_at ASC").
+		Find(&logs).Error
+	return logs, err
+}
+func (r *maintenanceItemLogRepository) Skip(ctx context.Context, log *entities.MaintenanceItemLog) error {
+	return r.tm.WithTransaction(ctx, func(tx *gorm.DB) error {
+		result := tx.Model(&entities.MaintenanceItem{}).
+			Where("id = ? AND status = ?", log.MaintenanceItemID, entities.MaintenanceItemStatusInspected).
+			Updates(map[string]interface{}{
+				"status":     entities.MaintenanceItemStatusSkipped,
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: %s", repositories.ErrItemNotAwaitingApproval, log.MaintenanceItemID)
+		}
+		return tx.Create(log).Error
+	})
+}
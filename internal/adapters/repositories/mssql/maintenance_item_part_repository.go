@@ -0,0 +1,124 @@
+package mssql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/infrastructure/database"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type maintenanceItemPartRepository struct {
+	db *gorm.DB
+	tm *database.TransactionManager
+}
+
+func NewMaintenanceItemPartRepository(db *gorm.DB) repositories.MaintenanceItemPartRepository {
+	return &maintenanceItemPartRepository{db: db, tm: database.NewTransactionManager(db)}
+}
+func (r *maintenanceItemPartRepository) Create(ctx context.Context, part *entities.MaintenanceItemPart) error {
+	return r.db.WithContext(ctx).Omit("Product").Create(part).Error
+}
+func (r *maintenanceItemPartRepository) GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.MaintenanceItemPart, error) {
+	var part entities.MaintenanceItemPart
+	err := r.db.WithContext(ctx).Preload("Product").Where("id = ?", id).First(&part).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &part, nil
+}
+func (r *maintenanceItemPartRepository) GetByItemID(ctx context.Context, itemID types.MSSQLUUID) ([]*entities.MaintenanceItemPart, error) {
+	var parts []*entities.MaintenanceItemPart
+	err := r.db.WithContext(ctx).
+		Preload("Product").
+		Where("maintenance_item_id = ?", itemID).
+		Order("created_at ASC").
+		Find(&parts).Error
+	return parts, err
+}
+func (r *maintenanceItemPartRepository) Delete(ctx context.Context, id types.MSSQLUUID) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&entities.MaintenanceItemPart{}).Error
+}
+func (r *maintenanceItemPartRepository) CompleteItem(ctx context.Context, item *entities.MaintenanceItem, allowShortage bool) error {
+	return r.tm.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var parts []*entities.MaintenanceItemPart
+		err := tx.Where("maintenance_item_id = ? AND deducted_at IS NULL", item.ID).Find(&parts).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, part := range parts {
+			// Claim the part first so a concurrent completion cannot take
+			// its quantity out of stock a second time.
+			claim := tx.Model(&entities.MaintenanceItemPart{}).
+				Where("id = ? AND deducted_at IS NULL", part.ID).
+				Update("deducted_at", now)
+			if claim.Error != nil {
+				return claim.Error
+			}
+			if claim.RowsAffected != 1 {
+				continue
+			}
+			// The conditional UPDATE checks and takes the stock in one
+			// statement, so two completions cannot both use the last unit.
+			query := tx.Model(&entities.Product{}).Where("id = ?", part.ProductID)
+			if !allowShortage {
+				query = query.Where("stock >= ?", part.Quantity)
+			}
+			result := query.Update("stock", gorm.Expr("stock - ?", part.Quantity))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return shortageError(tx, part)
+			}
+		}
+		return tx.Omit(clause.Associations).Save(item).Error
+	})
+}
+func (r *maintenanceItemPartRepository) RestockItem(ctx context.Context, item *entities.MaintenanceItem) error {
+	return r.tm.WithTransaction(ctx, func(tx *gorm.DB) error {
+		var parts []*entities.MaintenanceItemPart
+		err := tx.Where("maintenance_item_id = ? AND deducted_at IS NOT NULL", item.ID).Find(&parts).Error
+		if err != nil {
+			return err
+		}
+		for _, part := range parts {
+			claim := tx.Model(&entities.MaintenanceItemPart{}).
+				Where("id = ? AND deducted_at IS NOT NULL", part.ID).
+				Update("deducted_at", nil)
+			if claim.Error != nil {
+				return claim.Error
+			}
+			if claim.RowsAffected != 1 {
+				continue // already returned by a concurrent restock
+			}
+			err := tx.Model(&entities.Product{}).
+				Where("id = ?", part.ProductID).
+				Update("stock", gorm.Expr("stock + ?", part.Quantity)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Omit(clause.Associations).Save(item).Error
+	})
+}
+func shortageError(tx *gorm.DB, part *entities.MaintenanceItemPart) error {
+	var product entities.Product
+	if err := tx.Where("id = ?", part.ProductID).First(&product).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("product %s no longer exists", part.ProductID)
+		}
+		return err
+	}
+	return fmt.Errorf("%w: %s needs %d, %d in stock", repositories.ErrInsufficientStock, product.Name, part.Quantity, product.Stock)
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
 	"github.com/kuahbanyak/go-crud/internal/shared/types"
 	"gorm.io/gorm"
 )
@@ -40,6 +41,7 @@ func (r *MaintenanceItemRepositoryImpl) GetByWaitingListID(ctx context.Context,
 	var items []*entities.MaintenanceItem
 	err := r.db.WithContext(ctx).
 		Preload("Mechanic").
+		Preload("Parts.Product").
 		Where("waiting_list_id = ?", waitingListID).
 		Order("created_at ASC").
 		Find(&items).Error
@@ -58,6 +60,7 @@ func (r *MaintenanceItemRepositoryImpl) GetByType(ctx context.Context, waitingLi
 	var items []*entities.MaintenanceItem
 	err := r.db.WithContext(ctx).
 		Preload("Mechanic").
+		Preload("Parts.Product").
 		Where("waiting_list_id = ? AND item_type = ?", waitingListID, itemType).
 		Order("created_at ASC").
 		Find(&items).Error
@@ -73,6 +76,26 @@ func (r *MaintenanceItemRepositoryImpl) GetPendingApproval(ctx context.Context,
 		Find(&items).Error
 	return items, err
 }
+func (r *MaintenanceItemRepositoryImpl) GetAllPendingApproval(ctx context.Context) ([]*entities.MaintenanceItem, error) {
+	var items []*entities.MaintenanceItem
+	err := r.db.WithContext(ctx).
+		Preload("WaitingList.Customer").
+		Preload("WaitingList.Vehicle").
+		Where("requires_approval = ? AND status = ?", true, entities.MaintenanceItemStatusInspected).
+		Order("waiting_list_id, inspected_at ASC").
+		Find(&items).Error
+	return items, err
+}
+func (r *MaintenanceItemRepositoryImpl) GetWarrantiesByVehicle(ctx context.Context, vehicleID types.MSSQLUUID) ([]*entities.MaintenanceItem, error) {
+	var items []*entities.MaintenanceItem
+	err := r.db.WithContext(ctx).
+		Joins("JOIN waiting_lists w ON w.id = maintenance_items.waiting_list_id AND w.deleted_at IS NULL").
+		Where("w.vehicle_id = ? AND maintenance_items.status = ? AND maintenance_items.warranty_claim_id IS NULL", vehicleID, entities.MaintenanceItemStatusCompleted).
+		Where("(maintenance_items.warranty_until IS NOT NULL OR maintenance_items.warranty_until_km > 0)").
+		Order("maintenance_items.completed_at DESC").
+		Find(&items).Error
+	return items, err
+}
 func (r *MaintenanceItemRepositoryImpl) GetInitialItems(ctx context.Context, waitingListID types.MSSQLUUID) ([]*entities.MaintenanceItem, error) {
 	return r.GetByType(ctx, waitingListID, entities.MaintenanceItemTypeInitial)
 }
@@ -122,20 +145,43 @@ func (r *MaintenanceItemRepositoryImpl) RejectItems(ctx context.Context, ids []t
 			"updated_at": now,
 		}).Error
 }
-func (r *MaintenanceItemRepositoryImpl) GetTotalCost(ctx context.Context, waitingListID types.MSSQLUUID) (estimated float64, actual float64, err error) {
+func (r *MaintenanceItemRepositoryImpl) GetTotalCost(ctx context.Context, waitingListID types.MSSQLUUID) (*repositories.MaintenanceCostTotals, error) {
 	type Result struct {
 		TotalEstimated float64
 		TotalActual    float64
+		TotalLabor     float64
+		TotalWarranty  float64
 	}
+	excluded := []entities.MaintenanceItemStatus{entities.MaintenanceItemStatusRejected, entities.MaintenanceItemStatusSkipped, entities.MaintenanceItemStatusCanceled}
 	var result Result
-	err = r.db.WithContext(ctx).
+	err := r.db.WithContext(ctx).
 		Model(&entities.MaintenanceItem{}).
-		Select("SUM(estimated_cost) as total_estimated, SUM(actual_cost) as total_actual").
-		Where("waiting_list_id = ? AND status NOT IN ?",
-			waitingListID,
-			[]entities.MaintenanceItemStatus{entities.MaintenanceItemStatusRejected, entities.MaintenanceItemStatusSkipped}).
+		Select(`SUM(CASE WHEN warranty_claim_id IS NULL THEN estimated_cost ELSE 0 END) as total_estimated,
+			SUM(CASE WHEN warranty_claim_id IS NULL THEN actual_cost ELSE 0 END) as total_actual,
+			SUM(CASE WHEN warranty_claim_id IS NULL THEN labor_cost ELSE 0 END) as total_labor,
+			SUM(CASE WHEN warranty_claim_id IS NULL THEN 0 ELSE actual_cost END) as total_warranty`).
+		Where("waiting_list_id = ? AND status NOT IN ?", waitingListID, excluded).
 		Scan(&result).Error
-	return result.TotalEstimated, result.TotalActual, err
+	if err != nil {
+		return nil, err
+	}
+	var parts float64
+	err = r.db.WithContext(ctx).
+		Table("maintenance_item_parts p").
+		Joins("JOIN maintenance_items i ON i.id = p.maintenance_item_id").
+		Select("COALESCE(SUM(p.quantity * p.unit_price), 0)").
+		Where("i.waiting_list_id = ? AND i.status NOT IN ? AND i.warranty_claim_id IS NULL AND i.deleted_at IS NULL AND p.deleted_at IS NULL", waitingListID, excluded).
+		Scan(&parts).Error
+	if err != nil {
+		return nil, err
+	}
+	return &repositories.MaintenanceCostTotals{
+		Estimated: result.TotalEstimated,
+		Actual:    result.TotalActual,
+		Parts:     parts,
+		Labor:     result.TotalLabor,
+		Warranty:  result.TotalWarranty,
+	}, nil
 }
 func (r *MaintenanceItemRepositoryImpl) CountByStatus(ctx context.Context, waitingListID types.MSSQLUUID) (map[string]int, error) {
 	type StatusCount struct {
@@ -0,0 +1,60 @@
+package mssql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type serviceBayRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceBayRepository(db *gorm.DB) repositories.ServiceBayRepository {
+	return &serviceBayRepository{db: db}
+}
+func (r *serviceBayRepository) Create(ctx context.Context, bay *entities.ServiceBay) error {
+	return r.db.WithContext(ctx).Create(bay).Error
+}
+func (r *serviceBayRepository) GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.ServiceBay, error) {
+	var bay entities.ServiceBay
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&bay).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &bay, nil
+}
+func (r *serviceBayRepository) GetByCode(ctx context.Context, code string) (*entities.ServiceBay, error) {
+	var bay entities.ServiceBay
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&bay).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &bay, nil
+}
+func (r *serviceBayRepository) GetAll(ctx context.Context) ([]*entities.ServiceBay, error) {
+	var bays []*entities.ServiceBay
+	err := r.db.WithContext(ctx).Order("code ASC").Find(&bays).Error
+	return bays, err
+}
+func (r *serviceBayRepository) GetActive(ctx context.Context) ([]*entities.ServiceBay, error) {
+	var bays []*entities.ServiceBay
+	err := r.db.WithContext(ctx).Where("is_active = ?", true).Order("code ASC").Find(&bays).Error
+	return bays, err
+}
+func (r *serviceBayRepository) Update(ctx context.Context, bay *entities.ServiceBay) error {
+	return r.db.WithContext(ctx).Save(bay).Error
+}
+func (r *serviceBayRepository) Delete(ctx context.Context, id types.MSSQLUUID) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&entities.ServiceBay{}).Error
+}
@@ -0,0 +1,91 @@
+package mssql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type servicePackageRepository struct {
+	db *gorm.DB
+}
+
+func NewServicePackageRepository(db *gorm.DB) repositories.ServicePackageRepository {
+	return &servicePackageRepository{db: db}
+}
+func (r *servicePackageRepository) Create(ctx context.Context, pkg *entities.ServicePackage) error {
+	return r.db.WithContext(ctx).Create(pkg).Error
+}
+func (r *servicePackageRepository) GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.ServicePackage, error) {
+	return r.first(ctx, "id = ?", id)
+}
+func (r *servicePackageRepository) GetByCode(ctx context.Context, code string) (*entities.ServicePackage, error) {
+	return r.first(ctx, "code = ?", code)
+}
+func (r *servicePackageRepository) first(ctx context.Context, query string, args ...interface{}) (*entities.ServicePackage, error) {
+	var pkg entities.ServicePackage
+	err := r.preload(r.db.WithContext(ctx)).Where(query, args...).First(&pkg).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &pkg, nil
+}
+func (r *servicePackageRepository) GetAll(ctx context.Context) ([]*entities.ServicePackage, error) {
+	var packages []*entities.ServicePackage
+	err := r.preload(r.db.WithContext(ctx)).Order("name ASC").Find(&packages).Error
+	return packages, err
+}
+func (r *servicePackageRepository) GetActive(ctx context.Context) ([]*entities.ServicePackage, error) {
+	var packages []*entities.ServicePackage
+	err := r.preload(r.db.WithContext(ctx)).Where("is_active = ?", true).Order("name ASC").Find(&packages).Error
+	return packages, err
+}
+func (r *servicePackageRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Items.Parts")
+}
+func (r *servicePackageRepository) Update(ctx context.Context, pkg *entities.ServicePackage) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := deletePackageItems(tx, pkg.ID); err != nil {
+			return err
+		}
+		items := pkg.Items
+		if err := tx.Omit("Items").Save(pkg).Error; err != nil {
+			return err
+		}
+		for i := range items {
+			items[i].ID = types.MSSQLUUID{}
+			items[i].PackageID = pkg.ID
+			for j := range items[i].Parts {
+				items[i].Parts[j].ID = types.MSSQLUUID{}
+			}
+		}
+		if len(items) > 0 {
+			if err := tx.Create(&items).Error; err != nil {
+				return err
+			}
+		}
+		pkg.Items = items
+		return nil
+	})
+}
+func (r *servicePackageRepository) Delete(ctx context.Context, id types.MSSQLUUID) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&entities.ServicePackage{}).Error
+}
+func deletePackageItems(tx *gorm.DB, packageID types.MSSQLUUID) error {
+	itemIDs := tx.Model(&entities.ServicePackageItem{}).Select("id").Where("package_id = ?", packageID)
+	if err := tx.Where("package_item_id IN (?)", itemIDs).Delete(&entities.ServicePackagePart{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("package_id = ?", packageID).Delete(&entities.ServicePackageItem{}).Error
+}
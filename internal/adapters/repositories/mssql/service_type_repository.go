@@ -0,0 +1,75 @@
+package mssql
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type serviceTypeRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceTypeRepository(db *gorm.DB) repositories.ServiceTypeRepository {
+	return &serviceTypeRepository{db: db}
+}
+func (r *serviceTypeRepository) Create(ctx context.Context, serviceType *entities.ServiceType) error {
+	return r.db.WithContext(ctx).Create(serviceType).Error
+}
+func (r *serviceTypeRepository) GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.ServiceType, error) {
+	return r.first(ctx, "id = ?", id)
+}
+func (r *serviceTypeRepository) GetByCode(ctx context.Context, code string) (*entities.ServiceType, error) {
+	return r.first(ctx, "code = ?", code)
+}
+func (r *serviceTypeRepository) GetByName(ctx context.Context, name string) (*entities.ServiceType, error) {
+	return r.first(ctx, "LOWER(name) = ?", strings.ToLower(strings.TrimSpace(name)))
+}
+func (r *serviceTypeRepository) first(ctx context.Context, query string, args ...interface{}) (*entities.ServiceType, error) {
+	var serviceType entities.ServiceType
+	err := r.db.WithContext(ctx).Where(query, args...).First(&serviceType).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &serviceType, nil
+}
+func (r *serviceTypeRepository) GetAll(ctx context.Context) ([]*entities.ServiceType, error) {
+	var serviceTypes []*entities.ServiceType
+	err := r.db.WithContext(ctx).Order("category ASC, name ASC").Find(&serviceTypes).Error
+	return serviceTypes, err
+}
+func (r *serviceTypeRepository) GetActive(ctx context.Context) ([]*entities.ServiceType, error) {
+	var serviceTypes []*entities.ServiceType
+	err := r.db.WithContext(ctx).Where("is_active = ?", true).Order("category ASC, name ASC").Find(&serviceTypes).Error
+	return serviceTypes, err
+}
+func (r *serviceTypeRepository) Update(ctx context.Context, serviceType *entities.ServiceType) error {
+	return r.db.WithContext(ctx).Save(serviceType).Error
+}
+func (r *serviceTypeRepository) Delete(ctx context.Context, id types.MSSQLUUID) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&entities.ServiceType{}).Error
+}
+func (r *serviceTypeRepository) SeedDefaults(ctx context.Context) error {
+	for _, serviceType := range entities.DefaultServiceTypes {
+		var count int64
+		err := r.db.WithContext(ctx).Unscoped().Model(&entities.ServiceType{}).Where("code = ?", serviceType.Code).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		if err := r.Create(ctx, &serviceType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
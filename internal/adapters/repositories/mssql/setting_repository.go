@@ -61,14 +61,14 @@ func (r *settingRepository) Delete(ctx context.Context, id types.MSSQLUUID) erro
 	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&entities.Setting{}).Error
 }
 func (r *settingRepository) SeedDefaults(ctx context.Context) error {
-	var count int64
-	if err := r.db.WithContext(ctx).Model(&entities.Setting{}).Count(&count).Error; err != nil {
-		return err
-	}
-	if count > 0 {
-		return nil
-	}
 	for _, setting := range entities.DefaultSettings {
+		existing, err := r.GetByKey(ctx, setting.Key)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			continue
+		}
 		if err := r.Create(ctx, &setting); err != nil {
 			return err
 		}
@@ -0,0 +1,44 @@
+package mssql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type shopClosureRepository struct {
+	db *gorm.DB
+}
+
+func NewShopClosureRepository(db *gorm.DB) repositories.ShopClosureRepository {
+	return &shopClosureRepository{db: db}
+}
+func (r *shopClosureRepository) Create(ctx context.Context, closure *entities.ShopClosure) error {
+	return r.db.WithContext(ctx).Create(closure).Error
+}
+func (r *shopClosureRepository) GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.ShopClosure, error) {
+	var closure entities.ShopClosure
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&closure).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &closure, nil
+}
+func (r *shopClosureRepository) GetAll(ctx context.Context) ([]*entities.ShopClosure, error) {
+	var closures []*entities.ShopClosure
+	err := r.db.WithContext(ctx).Order("start_date ASC").Find(&closures).Error
+	return closures, err
+}
+func (r *shopClosureRepository) Update(ctx context.Context, closure *entities.ShopClosure) error {
+	return r.db.WithContext(ctx).Save(closure).Error
+}
+func (r *shopClosureRepository) Delete(ctx context.Context, id types.MSSQLUUID) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&entities.ShopClosure{}).Error
+}
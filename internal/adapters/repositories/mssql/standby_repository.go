@@ -0,0 +1,101 @@
+package mssql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type standbyRepository struct {
+	db *gorm.DB
+}
+
+func NewStandbyRepository(db *gorm.DB) repositories.StandbyRepository {
+	return &standbyRepository{db: db}
+}
+func (r *standbyRepository) Create(ctx context.Context, entry *entities.StandbyEntry) error {
+	return r.db.WithContext(ctx).Omit("Vehicle", "Customer").Create(entry).Error
+}
+func (r *standbyRepository) GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.StandbyEntry, error) {
+	var entry entities.StandbyEntry
+	err := r.db.WithContext(ctx).
+		Preload("Vehicle").
+		Preload("Customer").
+		Where("id = ?", id).First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+func (r *standbyRepository) GetWaitingByDate(ctx context.Context, serviceDate time.Time) ([]*entities.StandbyEntry, error) {
+	var entries []*entities.StandbyEntry
+	startOfDay := time.Date(serviceDate.Year(), serviceDate.Month(), serviceDate.Day(), 0, 0, 0, 0, serviceDate.Location())
+	endOfDay := startOfDay.Add(24 * time.Hour)
+	err := r.db.WithContext(ctx).
+		Preload("Vehicle").
+		Preload("Customer").
+		Where("status = ? AND service_date >= ? AND service_date < ?", entities.StandbyStatusWaiting, startOfDay, endOfDay).
+		Order("created_at ASC").
+		Find(&entries).Error
+	return entries, err
+}
+func (r *standbyRepository) GetWaitingByCustomer(ctx context.Context, customerID types.MSSQLUUID, from time.Time) ([]*entities.StandbyEntry, error) {
+	var entries []*entities.StandbyEntry
+	today := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	err := r.db.WithContext(ctx).
+		Preload("Vehicle").
+		Preload("Customer").
+		Where("customer_id = ? AND status = ? AND service_date >= ?", customerID, entities.StandbyStatusWaiting, today).
+		Order("service_date ASC, created_at ASC").
+		Find(&entries).Error
+	return entries, err
+}
+func (r *standbyRepository) Promote(ctx context.Context, entry *entities.StandbyEntry, waitingList *entities.WaitingList, transition *entities.WaitingListTransition, limit repositories.DailyLimit) error {
+	if waitingList.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		waitingList.ID = types.NewMSSQLUUID()
+	}
+	now := time.Now()
+	tickets := &waitingListRepository{db: r.db}
+	err := tickets.withQueueNumber(ctx, waitingList, limit, func(tx *gorm.DB) error {
+		result := tx.Model(&entities.StandbyEntry{}).
+			Where("id = ? AND status = ?", entry.ID, entities.StandbyStatusWaiting).
+			Updates(map[string]interface{}{
+				"status":          entities.StandbyStatusPromoted,
+				"waiting_list_id": waitingList.ID,
+				"promoted_at":     now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repositories.ErrStandbyNotWaiting
+		}
+		if err := tx.Save(waitingList).Error; err != nil {
+			return err
+		}
+		return createTransition(tx, waitingList, transition)
+	})
+	if err != nil {
+		return err
+	}
+	entry.Status = entities.StandbyStatusPromoted
+	entry.WaitingListID = &waitingList.ID
+	entry.PromotedAt = &now
+	return nil
+}
+func (r *standbyRepository) ExpireBefore(ctx context.Context, before time.Time) (int64, error) {
+	startOfDay := time.Date(before.Year(), before.Month(), before.Day(), 0, 0, 0, 0, before.Location())
+	result := r.db.WithContext(ctx).
+		Model(&entities.StandbyEntry{}).
+		Where("status = ? AND service_date < ?", entities.StandbyStatusWaiting, startOfDay).
+		Update("status", entities.StandbyStatusExpired)
+	return result.RowsAffected, result.Error
+}
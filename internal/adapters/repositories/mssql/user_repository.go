@@ -109,7 +109,12 @@ func (r *userRepository) GetAllPaginated(ctx context.Context, pagParams paginati
 
 func (r *userRepository) GetByRole(ctx context.Context, role string) ([]*entities.User, error) {
 	var users []*entities.User
-	err := r.db.WithContext(ctx).Where("role = ?", role).Find(&users).Error
+	err := r.db.WithContext(ctx).
+		Preload("Roles").
+		Joins("INNER JOIN user_roles ON user_roles.user_id = users.id").
+		Joins("INNER JOIN roles ON roles.id = user_roles.role_id").
+		Where("roles.name = ?", role).
+		Find(&users).Error
 	return users, err
 }
 func (r *userRepository) Count(ctx context.Context) (int, error) {
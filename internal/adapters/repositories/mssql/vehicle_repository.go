@@ -6,6 +6,7 @@ import (
 
 	"github.com/kuahbanyak/go-crud/internal/domain/entities"
 	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/infrastructure/database"
 	"github.com/kuahbanyak/go-crud/internal/shared/types"
 	"github.com/kuahbanyak/go-crud/pkg/pagination"
 	"gorm.io/gorm"
@@ -13,14 +14,24 @@ import (
 
 type vehicleRepository struct {
 	db *gorm.DB
+	tm *database.TransactionManager
 }
 
 func NewVehicleRepository(db *gorm.DB) repositories.VehicleRepository {
-	return &vehicleRepository{db: db}
+	return &vehicleRepository{db: db, tm: database.NewTransactionManager(db)}
 }
 func (r *vehicleRepository) Create(ctx context.Context, vehicle *entities.Vehicle) error {
 	return r.db.WithContext(ctx).Create(vehicle).Error
 }
+func (r *vehicleRepository) CreateWithOwner(ctx context.Context, owner *entities.User, vehicle *entities.Vehicle) error {
+	return r.tm.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := tx.Create(owner).Error; err != nil {
+			return err
+		}
+		vehicle.OwnerID = owner.ID
+		return tx.Omit("Owner").Create(vehicle).Error
+	})
+}
 func (r *vehicleRepository) GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.Vehicle, error) {
 	var vehicle entities.Vehicle
 	err := r.db.WithContext(ctx).
@@ -42,6 +53,21 @@ func (r *vehicleRepository) GetByOwnerID(ctx context.Context, ownerID types.MSSQ
 		Find(&vehicles).Error
 	return vehicles, err
 }
+func (r *vehicleRepository) GetByLicensePlate(ctx context.Context, plate string) (*entities.Vehicle, error) {
+	var vehicle entities.Vehicle
+	err := r.db.WithContext(ctx).
+		Preload("Owner").
+		Where("REPLACE(REPLACE(UPPER(license_plate), ' ', ''), '-', '') = REPLACE(REPLACE(UPPER(?), ' ', ''), '-', '')", plate).
+		Order("created_at DESC").
+		First(&vehicle).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &vehicle, nil
+}
 func (r *vehicleRepository) Update(ctx context.Context, vehicle *entities.Vehicle) error {
 	return r.db.WithContext(ctx).Save(vehicle).Error
 }
@@ -2,14 +2,19 @@ package mssql
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/kuahbanyak/go-crud/internal/domain/entities"
 	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
 	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	mssqldb "github.com/microsoft/go-mssqldb"
 	"gorm.io/gorm"
 )
 
+const queueAllocationAttempts = 3
+
 type waitingListRepository struct {
 	db *gorm.DB
 }
@@ -25,6 +30,8 @@ func (r *waitingListRepository) GetByID(ctx context.Context, id types.MSSQLUUID)
 	err := r.db.WithContext(ctx).
 		Preload("Vehicle").
 		Preload("Customer").
+		Preload("ServiceBay").
+		Preload("Mechanic").
 		Where("id = ?", id).First(&waitingList).Error
 	if err != nil {
 		return nil, err
@@ -38,6 +45,8 @@ func (r *waitingListRepository) GetByQueueNumber(ctx context.Context, queueNumbe
 	err := r.db.WithContext(ctx).
 		Preload("Vehicle").
 		Preload("Customer").
+		Preload("ServiceBay").
+		Preload("Mechanic").
 		Where("queue_number = ? AND service_date >= ? AND service_date < ?", queueNumber, startOfDay, endOfDay).
 		First(&waitingList).Error
 	if err != nil {
@@ -50,6 +59,8 @@ func (r *waitingListRepository) GetByCustomerID(ctx context.Context, customerID
 	err := r.db.WithContext(ctx).
 		Preload("Vehicle").
 		Preload("Customer").
+		Preload("ServiceBay").
+		Preload("Mechanic").
 		Where("customer_id = ?", customerID.String()).
 		Order("service_date DESC, queue_number ASC").
 		Find(&waitingLists).Error
@@ -62,6 +73,8 @@ func (r *waitingListRepository) GetByServiceDate(ctx context.Context, serviceDat
 	err := r.db.WithContext(ctx).
 		Preload("Vehicle").
 		Preload("Customer").
+		Preload("ServiceBay").
+		Preload("Mechanic").
 		Where("service_date >= ? AND service_date < ?", startOfDay, endOfDay).
 		Order("queue_number ASC").
 		Find(&waitingLists).Error
@@ -74,25 +87,252 @@ func (r *waitingListRepository) GetByStatus(ctx context.Context, status entities
 	err := r.db.WithContext(ctx).
 		Preload("Vehicle").
 		Preload("Customer").
+		Preload("ServiceBay").
+		Preload("Mechanic").
 		Where("status = ? AND service_date >= ? AND service_date < ?", status, startOfDay, endOfDay).
 		Order("queue_number ASC").
 		Find(&waitingLists).Error
 	return waitingLists, err
 }
-func (r *waitingListRepository) GetNextQueueNumber(ctx context.Context, serviceDate time.Time) (int, error) {
-	var maxQueue int
+func (r *waitingListRepository) GetByMechanic(ctx context.Context, mechanicID types.MSSQLUUID, serviceDate time.Time) ([]*entities.WaitingList, error) {
+	var waitingLists []*entities.WaitingList
 	startOfDay := time.Date(serviceDate.Year(), serviceDate.Month(), serviceDate.Day(), 0, 0, 0, 0, serviceDate.Location())
 	endOfDay := startOfDay.Add(24 * time.Hour)
+	err := r.db.WithContext(ctx).
+		Preload("Vehicle").
+		Preload("Customer").
+		Preload("ServiceBay").
+		Preload("Mechanic").
+		Where("mechanic_id = ? AND service_date >= ? AND service_date < ?", mechanicID, startOfDay, endOfDay).
+		Order("queue_number ASC").
+		Find(&waitingLists).Error
+	return waitingLists, err
+}
+func (r *waitingListRepository) SaveWithQueueNumber(ctx context.Context, waitingList *entities.WaitingList, limit repositories.DailyLimit, transition *entities.WaitingListTransition) error {
+	return r.withQueueNumber(ctx, waitingList, limit, func(tx *gorm.DB) error {
+		if err := tx.Save(waitingList).Error; err != nil {
+			return err
+		}
+		return createTransition(tx, waitingList, transition)
+	})
+}
+func (r *waitingListRepository) CreateAppointment(ctx context.Context, waitingList *entities.WaitingList, defaultMinutes int, limit repositories.DailyLimit, transition *entities.WaitingListTransition) error {
+	serviceDate := waitingList.ServiceDate
+	startOfDay := time.Date(serviceDate.Year(), serviceDate.Month(), serviceDate.Day(), 0, 0, 0, 0, serviceDate.Location())
+	endOfDay := startOfDay.Add(24 * time.Hour)
+	minutes := waitingList.EstimatedTime
+	if minutes <= 0 {
+		minutes = defaultMinutes
+	}
+	slotStart := *waitingList.AppointmentAt
+	slotEnd := slotStart.Add(time.Duration(minutes) * time.Minute)
+	return r.withQueueNumber(ctx, waitingList, limit, func(tx *gorm.DB) error {
+		// The day's counter row is locked by now, so no other booking for
+		// this date can pass the overlap check until we commit.
+		var conflicts int64
+		err := tx.Model(&entities.WaitingList{}).
+			Where("service_date >= ? AND service_date < ?", startOfDay, endOfDay).
+			Where("appointment_at IS NOT NULL AND status NOT IN ?",
+				[]entities.WaitingListStatus{entities.WaitingListStatusCanceled, entities.WaitingListStatusNoShow}).
+			Where("appointment_at < ? AND DATEADD(MINUTE, CASE WHEN estimated_time > 0 THEN estimated_time ELSE ? END, appointment_at) > ?",
+				slotEnd, defaultMinutes, slotStart).
+			Count(&conflicts).Error
+		if err != nil {
+			return err
+		}
+		if conflicts > 0 {
+			return repositories.ErrSlotUnavailable
+		}
+		if err := tx.Create(waitingList).Error; err != nil {
+			return err
+		}
+		return createTransition(tx, waitingList, transition)
+	})
+}
+
+// createTransition stores the ticket's status change in tx, so history is
+// written or rolled back together with the ticket.
+func createTransition(tx *gorm.DB, waitingList *entities.WaitingList, transition *entities.WaitingListTransition) error {
+	if transition == nil {
+		return nil
+	}
+	transition.WaitingListID = waitingList.ID
+	return tx.Omit("Actor").Create(transition).Error
+}
+
+// withQueueNumber runs save in a transaction after taking the next number from
+// the day's queue_counters row. The UPDATE holds the row lock until commit, so
+// concurrent allocations for the same date run one after another and the
+// daily limit and capacity checks cannot be raced. Deadlocks and the duplicate key raised
+// when two requests create the first counter of a day are retried.
+func (r *waitingListRepository) withQueueNumber(ctx context.Context, waitingList *entities.WaitingList, limit repositories.DailyLimit, save func(tx *gorm.DB) error) error {
+	var err error
+	for attempt := 0; attempt < queueAllocationAttempts; attempt++ {
+		err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			number, err := allocateQueueNumber(tx, waitingList, limit)
+			if err != nil {
+				return err
+			}
+			waitingList.QueueNumber = number
+			return save(tx)
+		})
+		if err == nil || !isRetryableError(err) {
+			return err
+		}
+	}
+	return err
+}
+func allocateQueueNumber(tx *gorm.DB, waitingList *entities.WaitingList, limit repositories.DailyLimit) (int, error) {
+	serviceDate := waitingList.ServiceDate
+	startOfDay := time.Date(serviceDate.Year(), serviceDate.Month(), serviceDate.Day(), 0, 0, 0, 0, serviceDate.Location())
+	endOfDay := startOfDay.Add(24 * time.Hour)
+	day := startOfDay.Format("2006-01-02")
+	var numbers []int
+	err := tx.Raw(`UPDATE queue_counters SET last_number = last_number + 1, updated_at = ?
+		OUTPUT inserted.last_number WHERE service_date = ?`, time.Now(), day).
+		Scan(&numbers).Error
+	if err != nil {
+		return 0, err
+	}
+	var number int
+	if len(numbers) > 0 {
+		number = numbers[0]
+	} else {
+		// First ticket through the counter for this date. Soft-deleted rows
+		// still hold their number in idx_queue_date, so count them too.
+		var maxQueue int
+		err = tx.Unscoped().Model(&entities.WaitingList{}).
+			Where("service_date >= ? AND service_date < ?", startOfDay, endOfDay).
+			Select("COALESCE(MAX(queue_number), 0)").
+			Scan(&maxQueue).Error
+		if err != nil {
+			return 0, err
+		}
+		number = maxQueue + 1
+		err = tx.Exec(`INSERT INTO queue_counters (service_date, last_number, updated_at) VALUES (?, ?, ?)`,
+			day, number, time.Now()).Error
+		if err != nil {
+			return 0, err
+		}
+	}
+	var usage struct {
+		Tickets int
+		Minutes int
+	}
+	err = tx.Model(&entities.WaitingList{}).
+		Select("COUNT(*) AS tickets, COALESCE(SUM(CASE WHEN estimated_time > 0 THEN estimated_time ELSE ? END), 0) AS minutes", limit.DefaultMinutes).
+		Where("service_date >= ? AND service_date < ? AND id <> ?", startOfDay, endOfDay, waitingList.ID).
+		Where("status IN ?", []entities.WaitingListStatus{
+			entities.WaitingListStatusWaiting,
+			entities.WaitingListStatusCalled,
+			entities.WaitingListStatusInService,
+		}).
+		Scan(&usage).Error
+	if err != nil {
+		return 0, err
+	}
+	if limit.MaxTickets > 0 && usage.Tickets >= limit.MaxTickets {
+		return 0, repositories.ErrDailyLimitReached
+	}
+	if limit.CapacityMinutes > 0 {
+		minutes := waitingList.EstimatedTime
+		if minutes <= 0 {
+			minutes = limit.DefaultMinutes
+		}
+		if remaining := limit.CapacityMinutes - usage.Minutes; minutes > remaining {
+			return 0, fmt.Errorf("%w: %d minutes needed, %d of %d left", repositories.ErrCapacityExceeded, minutes, max(remaining, 0), limit.CapacityMinutes)
+		}
+	}
+	return number, nil
+}
+func isRetryableError(err error) bool {
+	var sqlErr mssqldb.Error
+	if !errors.As(err, &sqlErr) {
+		return false
+	}
+	switch sqlErr.Number {
+	case 1205, 2601, 2627: // deadlock victim, duplicate key
+		return true
+	}
+	return false
+}
+func (r *waitingListRepository) GetAverageServiceMinutes(ctx context.Context, since time.Time) (repositories.ServiceAverages, error) {
+	type ServiceAverage struct {
+		ServiceTypeCode string
+		ServiceType     string
+		Tickets         int
+		Minutes         float64
+	}
+	var results []ServiceAverage
+	averages := repositories.ServiceAverages{ByCode: map[string]float64{}, ByName: map[string]float64{}}
 	err := r.db.WithContext(ctx).
 		Model(&entities.WaitingList{}).
-		Where("service_date >= ? AND service_date < ?", startOfDay, endOfDay).
-		Select("COALESCE(MAX(queue_number), 0)").
-		Scan(&maxQueue).Error
-	return maxQueue + 1, err
+		Select("ISNULL(service_type_code, '') as service_type_code, LOWER(LTRIM(RTRIM(service_type))) as service_type, "+
+			"COUNT(*) as tickets, "+
+			"AVG(CAST(DATEDIFF(SECOND, service_start_at, service_end_at) AS FLOAT)) / 60 as minutes").
+		Where("status = ? AND service_start_at IS NOT NULL AND service_end_at > service_start_at AND service_end_at >= ?",
+			entities.WaitingListStatusCompleted, since).
+		Group("ISNULL(service_type_code, ''), LOWER(LTRIM(RTRIM(service_type)))").
+		Scan(&results).Error
+	if err != nil {
+		return averages, err
+	}
+	// Each row covers one code and name pair; weight by ticket count to
+	// combine them per code and per name.
+	codeTickets := make(map[string]int)
+	nameTickets := make(map[string]int)
+	for _, r := range results {
+		if r.ServiceTypeCode != "" {
+			averages.ByCode[r.ServiceTypeCode] += r.Minutes * float64(r.Tickets)
+			codeTickets[r.ServiceTypeCode] += r.Tickets
+		}
+		averages.ByName[r.ServiceType] += r.Minutes * float64(r.Tickets)
+		nameTickets[r.ServiceType] += r.Tickets
+	}
+	for code, tickets := range codeTickets {
+		averages.ByCode[code] /= float64(tickets)
+	}
+	for name, tickets := range nameTickets {
+		averages.ByName[name] /= float64(tickets)
+	}
+	return averages, nil
+}
+func (r *waitingListRepository) StartServiceInBay(ctx context.Context, waitingList *entities.WaitingList, transition *entities.WaitingListTransition) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Only the ticket's own day counts, so a ticket left in service on an
+		// earlier day does not block the bay.
+		date := waitingList.ServiceDate
+		startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+		var occupied int64
+		err := tx.Raw(`SELECT COUNT(*) FROM waiting_lists WITH (UPDLOCK, HOLDLOCK)
+			WHERE service_bay_id = ? AND status = ? AND id <> ? AND deleted_at IS NULL
+			AND service_date >= ? AND service_date < ?`,
+			waitingList.ServiceBayID, entities.WaitingListStatusInService, waitingList.ID,
+			startOfDay, startOfDay.Add(24*time.Hour)).
+			Scan(&occupied).Error
+		if err != nil {
+			return err
+		}
+		if occupied > 0 {
+			return repositories.ErrBayOccupied
+		}
+		if err := tx.Save(waitingList).Error; err != nil {
+			return err
+		}
+		return createTransition(tx, waitingList, transition)
+	})
 }
 func (r *waitingListRepository) Update(ctx context.Context, waitingList *entities.WaitingList) error {
 	return r.db.WithContext(ctx).Save(waitingList).Error
 }
+func (r *waitingListRepository) UpdateWithTransition(ctx context.Context, waitingList *entities.WaitingList, transition *entities.WaitingListTransition) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(waitingList).Error; err != nil {
+			return err
+		}
+		return createTransition(tx, waitingList, transition)
+	})
+}
 func (r *waitingListRepository) Delete(ctx context.Context, id types.MSSQLUUID) error {
 	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&entities.WaitingList{}).Error
 }
@@ -101,6 +341,8 @@ func (r *waitingListRepository) List(ctx context.Context, limit, offset int) ([]
 	err := r.db.WithContext(ctx).
 		Preload("Vehicle").
 		Preload("Customer").
+		Preload("ServiceBay").
+		Preload("Mechanic").
 		Limit(limit).Offset(offset).
 		Order("service_date DESC, queue_number ASC").
 		Find(&waitingLists).Error
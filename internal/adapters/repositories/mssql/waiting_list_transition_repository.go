@@ -0,0 +1,30 @@
+package mssql
+
+import (
+	"context"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type waitingListTransitionRepository struct {
+	db *gorm.DB
+}
+
+func NewWaitingListTransitionRepository(db *gorm.DB) repositories.WaitingListTransitionRepository {
+	return &waitingListTransitionRepository{db: db}
+}
+func (r *waitingListTransitionRepository) Create(ctx context.Context, transition *entities.WaitingListTransition) error {
+	return r.db.WithContext(ctx).Omit("Actor").Create(transition).Error
+}
+func (r *waitingListTransitionRepository) GetByWaitingListID(ctx context.Context, waitingListID types.MSSQLUUID) ([]*entities.WaitingListTransition, error) {
+	var transitions []*entities.WaitingListTransition
+	err := r.db.WithContext(ctx).
+		Preload("Actor").
+		Where("waiting_list_id = ?", waitingListID).
+		Order("created_at ASC").
+		Find(&transitions).Error
+	return transitions, err
+}
@@ -0,0 +1,77 @@
+package mssql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	mssqldb "github.com/microsoft/go-mssqldb"
+	"gorm.io/gorm"
+)
+
+type warrantyClaimRepository struct {
+	db *gorm.DB
+}
+
+func NewWarrantyClaimRepository(db *gorm.DB) repositories.WarrantyClaimRepository {
+	return &warrantyClaimRepository{db: db}
+}
+func (r *warrantyClaimRepository) Create(ctx context.Context, claim *entities.WarrantyClaim, repair *entities.MaintenanceItem) error {
+	if claim.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		claim.ID = types.NewMSSQLUUID()
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repair.WarrantyClaimID = &claim.ID
+		if err := tx.Create(repair).Error; err != nil {
+			return err
+		}
+		claim.RepairItemID = repair.ID
+		err := tx.Omit("OriginalItem", "RepairItem", "OpenedBy").Create(claim).Error
+		if isDuplicateKeyError(err) {
+			return repositories.ErrWarrantyClaimExists
+		}
+		return err
+	})
+}
+func (r *warrantyClaimRepository) GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.WarrantyClaim, error) {
+	var claim entities.WarrantyClaim
+	err := r.preload(r.db.WithContext(ctx)).Where("id = ?", id).First(&claim).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &claim, nil
+}
+func (r *warrantyClaimRepository) GetByVehicleID(ctx context.Context, vehicleID types.MSSQLUUID) ([]*entities.WarrantyClaim, error) {
+	var claims []*entities.WarrantyClaim
+	err := r.preload(r.db.WithContext(ctx)).
+		Where("vehicle_id = ?", vehicleID).
+		Order("created_at DESC").
+		Find(&claims).Error
+	return claims, err
+}
+func (r *warrantyClaimRepository) GetByOriginalItemIDs(ctx context.Context, itemIDs []types.MSSQLUUID) ([]*entities.WarrantyClaim, error) {
+	var claims []*entities.WarrantyClaim
+	if len(itemIDs) == 0 {
+		return claims, nil
+	}
+	err := r.preload(r.db.WithContext(ctx)).
+		Where("original_item_id IN ?", itemIDs).
+		Order("created_at ASC").
+		Find(&claims).Error
+	return claims, err
+}
+func (r *warrantyClaimRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.Preload("OriginalItem").Preload("RepairItem").Preload("OpenedBy")
+}
+func isDuplicateKeyError(err error) bool {
+	var sqlErr mssqldb.Error
+	if !errors.As(err, &sqlErr) {
+		return false
+	}
+	return sqlErr.Number == 2601 || sqlErr.Number == 2627
+}
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+// ApprovalToken backs a magic link that lets a customer approve or reject
+// discovered items without logging in. Only the newest token of a ticket is
+// kept; UsedAt is set when the customer submits their decisions.
+type ApprovalToken struct {
+	ID            types.MSSQLUUID `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt  `gorm:"index" json:"-"`
+	WaitingListID types.MSSQLUUID `gorm:"type:uniqueidentifier;not null;index" json:"waiting_list_id"`
+	ExpiresAt     time.Time       `gorm:"not null" json:"expires_at"`
+	UsedAt        *time.Time      `json:"used_at,omitempty"`
+}
+
+func (t *ApprovalToken) BeforeCreate(_ *gorm.DB) error {
+	if t.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		t.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type EstimateStatus string
+
+const (
+	EstimateStatusSent       EstimateStatus = "sent" // waiting for the customer
+	EstimateStatusAccepted   EstimateStatus = "accepted"
+	EstimateStatusDeclined   EstimateStatus = "declined"
+	EstimateStatusSuperseded EstimateStatus = "superseded" // replaced by a newer version before the customer answered
+)
+
+// Estimate is a versioned quote for a ticket. Its lines are copied from the
+// ticket's items when the version is issued, so later item changes do not
+// alter what the customer was sent.
+type Estimate struct {
+	ID            types.MSSQLUUID  `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt   `gorm:"index" json:"-"`
+	WaitingListID types.MSSQLUUID  `gorm:"type:uniqueidentifier;not null;uniqueIndex:idx_estimate_version" json:"waiting_list_id"`
+	Version       int              `gorm:"not null;uniqueIndex:idx_estimate_version" json:"version"`
+	Status        EstimateStatus   `gorm:"type:varchar(20);not null;default:'sent'" json:"status"`
+	Subtotal      float64          `gorm:"type:decimal(12,2);default:0" json:"subtotal"`
+	TaxPercent    float64          `gorm:"type:decimal(5,2);default:0" json:"tax_percent"`
+	TaxAmount     float64          `gorm:"type:decimal(12,2);default:0" json:"tax_amount"`
+	Total         float64          `gorm:"type:decimal(12,2);default:0" json:"total"`
+	IssuedByID    *types.MSSQLUUID `gorm:"type:uniqueidentifier" json:"issued_by_id,omitempty"` // empty for versions created by item changes
+	DecidedAt     *time.Time       `json:"decided_at,omitempty"`
+	DecisionNote  string           `gorm:"type:varchar(500)" json:"decision_note,omitempty"` // customer's reason, mostly for declines
+	Lines         []EstimateLine   `gorm:"foreignKey:EstimateID" json:"lines"`
+}
+
+func (e *Estimate) BeforeCreate(_ *gorm.DB) error {
+	if e.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		e.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
+
+// EstimateLine is one maintenance item as it stood when the estimate was
+// issued.
+type EstimateLine struct {
+	ID                types.MSSQLUUID    `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt         time.Time          `json:"created_at"`
+	EstimateID        types.MSSQLUUID    `gorm:"type:uniqueidentifier;not null;index" json:"estimate_id"`
+	MaintenanceItemID types.MSSQLUUID    `gorm:"type:uniqueidentifier;not null" json:"maintenance_item_id"`
+	Position          int                `gorm:"not null;default:0" json:"position"`
+	ItemType          string             `gorm:"type:varchar(20);not null" json:"item_type"`
+	Category          string             `gorm:"type:varchar(100);not null" json:"category"`
+	Name              string             `gorm:"type:varchar(200);not null" json:"name"`
+	PartsCost         float64            `gorm:"type:decimal(10,2);default:0" json:"parts_cost"`
+	LaborHours        float64            `gorm:"type:decimal(5,2);default:0" json:"labor_hours"`
+	LaborRate         float64            `gorm:"type:decimal(10,2);default:0" json:"labor_rate"`
+	LaborCost         float64            `gorm:"type:decimal(10,2);default:0" json:"labor_cost"`
+	Amount            float64            `gorm:"type:decimal(10,2);default:0" json:"amount"` // what the line adds to the subtotal
+	Parts             []EstimateLinePart `gorm:"foreignKey:EstimateLineID" json:"parts,omitempty"`
+}
+
+func (l *EstimateLine) BeforeCreate(_ *gorm.DB) error {
+	if l.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		l.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
+
+type EstimateLinePart struct {
+	ID             types.MSSQLUUID `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt      time.Time       `json:"created_at"`
+	EstimateLineID types.MSSQLUUID `gorm:"type:uniqueidentifier;not null;index" json:"estimate_line_id"`
+	ProductID      types.MSSQLUUID `gorm:"type:uniqueidentifier;not null" json:"product_id"`
+	Name           string          `gorm:"type:varchar(200)" json:"name"`
+	Quantity       int             `gorm:"not null" json:"quantity"`
+	UnitPrice      float64         `gorm:"type:decimal(10,2);not null" json:"unit_price"`
+}
+
+func (p *EstimateLinePart) BeforeCreate(_ *gorm.DB) error {
+	if p.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		p.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+// InspectionResultStatus is the traffic-light outcome of one inspection
+// point.
+type InspectionResultStatus string
+
+const (
+	InspectionResultGreen  InspectionResultStatus = "green"  // fine
+	InspectionResultYellow InspectionResultStatus = "yellow" // needs attention soon
+	InspectionResultRed    InspectionResultStatus = "red"    // needs attention now
+)
+
+func (s InspectionResultStatus) IsValid() bool {
+	return s == InspectionResultGreen || s == InspectionResultYellow || s == InspectionResultRed
+}
+
+// InspectionTemplate is a standard multi-point inspection form, e.g. "Basic
+// 30-point check".
+type InspectionTemplate struct {
+	ID          types.MSSQLUUID           `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt            `gorm:"index" json:"-"`
+	Name        string                    `gorm:"type:varchar(100);not null" json:"name"`
+	Description string                    `gorm:"type:text" json:"description"`
+	IsActive    bool                      `json:"is_active"`
+	Points      []InspectionTemplatePoint `gorm:"foreignKey:TemplateID" json:"points"`
+}
+
+func (t *InspectionTemplate) BeforeCreate(_ *gorm.DB) error {
+	if t.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		t.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
+
+// InspectionTemplatePoint is one thing to check on a template.
+type InspectionTemplatePoint struct {
+	ID         types.MSSQLUUID `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt  time.Time       `json:"created_at"`
+	TemplateID types.MSSQLUUID `gorm:"type:uniqueidentifier;not null;index" json:"template_id"`
+	Position   int             `gorm:"not null;default:0" json:"position"`
+	Category   string          `gorm:"type:varchar(100);not null" json:"category"` // e.g. "Tires", "Brakes"
+	Name       string          `gorm:"type:varchar(200);not null" json:"name"`     // e.g. "Front left tread depth"
+	Unit       string          `gorm:"type:varchar(20)" json:"unit,omitempty"`     // e.g. "mm", empty when nothing is measured
+}
+
+func (p *InspectionTemplatePoint) BeforeCreate(_ *gorm.DB) error {
+	if p.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		p.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
+
+// Inspection is a filled-in checklist for a ticket. Its results are copied
+// from the template so later template edits do not change past reports.
+type Inspection struct {
+	ID            types.MSSQLUUID    `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt     `gorm:"index" json:"-"`
+	WaitingListID types.MSSQLUUID    `gorm:"type:uniqueidentifier;not null;index" json:"waiting_list_id"`
+	TemplateID    types.MSSQLUUID    `gorm:"type:uniqueidentifier;not null" json:"template_id"`
+	TemplateName  string             `gorm:"type:varchar(100);not null" json:"template_name"`
+	MechanicID    types.MSSQLUUID    `gorm:"type:uniqueidentifier;not null" json:"mechanic_id"`
+	Notes         string             `gorm:"type:text" json:"notes"`
+	CompletedAt   *time.Time         `json:"completed_at,omitempty"`
+	FindingsAt    *time.Time         `json:"findings_at,omitempty"` // when red and yellow results were added as maintenance items
+	Results       []InspectionResult `gorm:"foreignKey:InspectionID" json:"results"`
+	Mechanic      *User              `gorm:"foreignKey:MechanicID" json:"mechanic,omitempty"`
+}
+
+func (i *Inspection) BeforeCreate(_ *gorm.DB) error {
+	if i.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		i.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
+
+// InspectionResult is the outcome of one point of an inspection.
+type InspectionResult struct {
+	ID                types.MSSQLUUID        `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt         time.Time              `json:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at"`
+	InspectionID      types.MSSQLUUID        `gorm:"type:uniqueidentifier;not null;index" json:"inspection_id"`
+	Position          int                    `gorm:"not null;default:0" json:"position"`
+	Category          string                 `gorm:"type:varchar(100);not null" json:"category"`
+	Name              string                 `gorm:"type:varchar(200);not null" json:"name"`
+	Unit              string                 `gorm:"type:varchar(20)" json:"unit,omitempty"`
+	Status            InspectionResultStatus `gorm:"type:varchar(10)" json:"status"` // empty until checked
+	Measurement       *float64               `gorm:"type:decimal(8,2)" json:"measurement,omitempty"`
+	Notes             string                 `gorm:"type:text" json:"notes"`
+	PhotoKey          string                 `gorm:"type:varchar(300)" json:"-"` // file storage key
+	PhotoContentType  string                 `gorm:"type:varchar(100)" json:"-"`
+	MaintenanceItemID *types.MSSQLUUID       `gorm:"type:uniqueidentifier" json:"maintenance_item_id,omitempty"` // discovered item made from this finding
+}
+
+func (r *InspectionResult) BeforeCreate(_ *gorm.DB) error {
+	if r.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		r.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
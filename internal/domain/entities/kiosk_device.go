@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type KioskAction string
+
+const (
+	KioskActionLookupVehicle KioskAction = "lookup_vehicle"
+	KioskActionTakeQueue     KioskAction = "take_queue"
+	KioskActionCreateGuest   KioskAction = "create_guest"
+)
+
+// KioskDevice is a self-service tablet allowed to check customers in without a
+// user login. Only the SHA-256 hash of its token is stored.
+type KioskDevice struct {
+	ID         types.MSSQLUUID  `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt   `gorm:"index" json:"-"`
+	Name       string           `gorm:"type:varchar(100);not null" json:"name"`
+	TokenHash  string           `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	CreatedBy  *types.MSSQLUUID `gorm:"type:uniqueidentifier" json:"created_by,omitempty"`
+	RevokedAt  *time.Time       `json:"revoked_at,omitempty"`
+	LastSeenAt *time.Time       `json:"last_seen_at,omitempty"`
+}
+
+func (k *KioskDevice) BeforeCreate(_ *gorm.DB) error {
+	if k.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		k.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
+
+func (k *KioskDevice) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// KioskActivity records an action performed by a kiosk device.
+type KioskActivity struct {
+	ID            types.MSSQLUUID  `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt     time.Time        `gorm:"index" json:"created_at"`
+	KioskDeviceID types.MSSQLUUID  `gorm:"type:uniqueidentifier;not null;index" json:"kiosk_device_id"`
+	Action        KioskAction      `gorm:"type:varchar(30);not null" json:"action"`
+	LicensePlate  string           `gorm:"type:varchar(20)" json:"license_plate,omitempty"`
+	CustomerID    *types.MSSQLUUID `gorm:"type:uniqueidentifier" json:"customer_id,omitempty"`
+	WaitingListID *types.MSSQLUUID `gorm:"type:uniqueidentifier" json:"waiting_list_id,omitempty"`
+	Error         string           `gorm:"type:varchar(500)" json:"error,omitempty"` // why the action failed, empty on success
+}
+
+func (a *KioskActivity) BeforeCreate(_ *gorm.DB) error {
+	if a.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		a.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ const (
 	MaintenanceItemStatusRejected  MaintenanceItemStatus = "rejected"
 	MaintenanceItemStatusCompleted MaintenanceItemStatus = "completed"
 	MaintenanceItemStatusSkipped   MaintenanceItemStatus = "skipped"
+	MaintenanceItemStatusCanceled  MaintenanceItemStatus = "canceled"
 	MaintenanceItemTypeInitial     MaintenanceItemType   = "initial"
 	MaintenanceItemTypeDiscovered  MaintenanceItemType   = "discovered"
 )
@@ -37,12 +38,22 @@ type MaintenanceItem struct {
 	EstimatedCost    float64               `gorm:"type:decimal(10,2);default:0" json:"estimated_cost"`
 	ActualCost       float64               `gorm:"type:decimal(10,2);default:0" json:"actual_cost"`
 	LaborHours       float64               `gorm:"type:decimal(5,2);default:0" json:"labor_hours"`
-	InspectedAt      *time.Time            `json:"inspected_at,omitempty"`                       // When mechanic found it
-	ApprovedAt       *time.Time            `json:"approved_at,omitempty"`                        // When customer approved
-	CompletedAt      *time.Time            `json:"completed_at,omitempty"`                       // When work was completed
-	RequiresApproval bool                  `gorm:"default:false" json:"requires_approval"`       // Does this need customer approval?
-	ImageURL         string                `gorm:"type:varchar(500)" json:"image_url,omitempty"` // Photo of the issue
-	Notes            string                `gorm:"type:text" json:"notes"`                       // Mechanic notes
+	LaborRate        float64               `gorm:"type:decimal(10,2);default:0" json:"labor_rate"`           // Hourly rate the labor was priced at
+	LaborCost        float64               `gorm:"type:decimal(10,2);default:0" json:"labor_cost"`           // LaborHours at LaborRate
+	InspectedAt      *time.Time            `json:"inspected_at,omitempty"`                                   // When mechanic found it
+	ApprovedAt       *time.Time            `json:"approved_at,omitempty"`                                    // When customer approved
+	CompletedAt      *time.Time            `json:"completed_at,omitempty"`                                   // When work was completed
+	RequiresApproval bool                  `gorm:"default:false" json:"requires_approval"`                   // Does this need customer approval?
+	ImageURL         string                `gorm:"type:varchar(500)" json:"image_url,omitempty"`             // Photo of the issue
+	Notes            string                `gorm:"type:text" json:"notes"`                                   // Mechanic notes
+	PackageCode      string                `gorm:"type:varchar(30);index" json:"package_code,omitempty"`     // Set when the item was expanded from a service package
+	DiscountAmount   float64               `gorm:"type:decimal(10,2);default:0" json:"discount_amount"`      // Package discount already taken off EstimatedCost
+	WarrantyMonths   int                   `gorm:"default:0" json:"warranty_months"`                         // Terms granted at completion, 0 for no time limit
+	WarrantyKm       int                   `gorm:"default:0" json:"warranty_km"`                             // Terms granted at completion, 0 for no distance limit
+	WarrantyUntil    *time.Time            `json:"warranty_until,omitempty"`                                 // End of the time limit
+	WarrantyUntilKm  int                   `gorm:"default:0" json:"warranty_until_km,omitempty"`             // Odometer reading that ends the distance limit
+	WarrantyClaimID  *types.MSSQLUUID      `gorm:"type:uniqueidentifier" json:"warranty_claim_id,omitempty"` // Set on repairs done under warranty, which are not billed
+	Parts            []MaintenanceItemPart `gorm:"foreignKey:MaintenanceItemID" json:"parts,omitempty"`
 	WaitingList      *WaitingList          `gorm:"foreignKey:WaitingListID" json:"waiting_list,omitempty"`
 	Mechanic         *User                 `gorm:"foreignKey:MechanicID" json:"mechanic,omitempty"`
 }
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type ApprovalDecision string
+
+const (
+	ApprovalDecisionApproved ApprovalDecision = "approved"
+	ApprovalDecisionRejected ApprovalDecision = "rejected"
+)
+
+// ApprovalChannel is how a customer's decision reached the shop.
+type ApprovalChannel string
+
+const (
+	ApprovalChannelApp       ApprovalChannel = "app"
+	ApprovalChannelMagicLink ApprovalChannel = "magic_link"
+	ApprovalChannelPhone     ApprovalChannel = "phone" // logged by staff on the customer's behalf
+)
+
+// MaintenanceItemApproval records a customer's decision on one maintenance
+// item. Rows are only ever appended so they can settle billing disputes.
+type MaintenanceItemApproval struct {
+	ID                types.MSSQLUUID  `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt         time.Time        `gorm:"index" json:"created_at"`
+	MaintenanceItemID types.MSSQLUUID  `gorm:"type:uniqueidentifier;not null;index" json:"maintenance_item_id"`
+	WaitingListID     types.MSSQLUUID  `gorm:"type:uniqueidentifier;not null;index" json:"waiting_list_id"`
+	CustomerID        types.MSSQLUUID  `gorm:"type:uniqueidentifier;not null" json:"customer_id"`
+	Decision          ApprovalDecision `gorm:"type:varchar(20);not null" json:"decision"`
+	Channel           ApprovalChannel  `gorm:"type:varchar(20);not null" json:"channel"`
+	Reason            string           `gorm:"type:varchar(500)" json:"reason,omitempty"`
+	RecordedByID      *types.MSSQLUUID `gorm:"type:uniqueidentifier" json:"recorded_by_id,omitempty"` // staff member, for phone decisions
+	MaintenanceItem   *MaintenanceItem `gorm:"foreignKey:MaintenanceItemID" json:"maintenance_item,omitempty"`
+	RecordedBy        *User            `gorm:"foreignKey:RecordedByID" json:"recorded_by,omitempty"`
+}
+
+func (a *MaintenanceItemApproval) BeforeCreate(_ *gorm.DB) error {
+	if a.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		a.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
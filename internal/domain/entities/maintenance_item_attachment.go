@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type AttachmentKind string
+
+const (
+	AttachmentKindPhoto    AttachmentKind = "photo"
+	AttachmentKindVideo    AttachmentKind = "video"
+	AttachmentKindDocument AttachmentKind = "document"
+)
+
+// MaintenanceItemAttachment is a file uploaded for a maintenance item. The
+// content lives in file storage under StorageKey; ThumbnailKey is set for
+// photos a thumbnail could be made of.
+type MaintenanceItemAttachment struct {
+	ID                types.MSSQLUUID  `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt   `gorm:"index" json:"-"`
+	MaintenanceItemID types.MSSQLUUID  `gorm:"type:uniqueidentifier;not null;index" json:"maintenance_item_id"`
+	UploadedByID      *types.MSSQLUUID `gorm:"type:uniqueidentifier" json:"uploaded_by_id,omitempty"`
+	Kind              AttachmentKind   `gorm:"type:varchar(20);not null" json:"kind"`
+	FileName          string           `gorm:"type:varchar(255);not null" json:"file_name"`
+	ContentType       string           `gorm:"type:varchar(100);not null" json:"content_type"`
+	Size              int64            `gorm:"not null" json:"size"`
+	StorageKey        string           `gorm:"type:varchar(300);not null" json:"-"`
+	ThumbnailKey      string           `gorm:"type:varchar(300)" json:"-"`
+	UploadedBy        *User            `gorm:"foreignKey:UploadedByID" json:"uploaded_by,omitempty"`
+}
+
+func (a *MaintenanceItemAttachment) BeforeCreate(_ *gorm.DB) error {
+	if a.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		a.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+// MaintenanceItemLogAction is something done to an item by a background job
+// rather than a person.
+type MaintenanceItemLogAction string
+
+const (
+	MaintenanceItemLogReminderSent MaintenanceItemLogAction = "reminder_sent"
+	MaintenanceItemLogEscalated    MaintenanceItemLogAction = "escalated"    // handed to the service advisor to phone the customer
+	MaintenanceItemLogAutoSkipped  MaintenanceItemLogAction = "auto_skipped" // no answer before the rest of the work was done
+)
+
+type MaintenanceItemLog struct {
+	ID                types.MSSQLUUID          `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt         time.Time                `gorm:"index" json:"created_at"`
+	MaintenanceItemID types.MSSQLUUID          `gorm:"type:uniqueidentifier;not null;index" json:"maintenance_item_id"`
+	WaitingListID     types.MSSQLUUID          `gorm:"type:uniqueidentifier;not null;index" json:"waiting_list_id"`
+	Action            MaintenanceItemLogAction `gorm:"type:varchar(30);not null" json:"action"`
+	Message           string                   `gorm:"type:varchar(500)" json:"message,omitempty"`
+}
+
+func (l *MaintenanceItemLog) BeforeCreate(_ *gorm.DB) error {
+	if l.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		l.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+// MaintenanceItemPart is a product used on a maintenance item. UnitPrice is
+// the product price when the part was added so later price changes do not
+// touch the bill. DeductedAt is set while the quantity is out of stock.
+type MaintenanceItemPart struct {
+	ID                types.MSSQLUUID `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt  `gorm:"index" json:"-"`
+	MaintenanceItemID types.MSSQLUUID `gorm:"type:uniqueidentifier;not null;index" json:"maintenance_item_id"`
+	ProductID         types.MSSQLUUID `gorm:"type:uniqueidentifier;not null;index" json:"product_id"`
+	Quantity          int             `gorm:"not null" json:"quantity"`
+	UnitPrice         float64         `gorm:"type:decimal(12,2);not null;default:0" json:"unit_price"`
+	DeductedAt        *time.Time      `json:"deducted_at,omitempty"`
+	Product           *Product        `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+}
+
+func (p *MaintenanceItemPart) BeforeCreate(_ *gorm.DB) error {
+	if p.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		p.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
+
+func (p *MaintenanceItemPart) Total() float64 {
+	return p.UnitPrice * float64(p.Quantity)
+}
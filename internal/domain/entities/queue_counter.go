@@ -0,0 +1,11 @@
+package entities
+
+import "time"
+
+// QueueCounter holds the last queue number handed out for a service date.
+// Incrementing it locks the row, which serializes ticket allocation per day.
+type QueueCounter struct {
+	ServiceDate time.Time `gorm:"type:date;primaryKey" json:"service_date"`
+	LastNumber  int       `gorm:"not null" json:"last_number"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
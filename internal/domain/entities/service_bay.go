@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+// ServiceBay is a physical lane in the workshop; each bay serves one vehicle at a time.
+type ServiceBay struct {
+	ID        types.MSSQLUUID `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	DeletedAt gorm.DeletedAt  `gorm:"index" json:"-"`
+	Code      string          `gorm:"type:varchar(20);not null;uniqueIndex" json:"code"`
+	Name      string          `gorm:"type:varchar(100);not null" json:"name"`
+	IsActive  bool            `json:"is_active"`
+	Notes     string          `gorm:"type:text" json:"notes"`
+}
+
+func (b *ServiceBay) BeforeCreate(_ *gorm.DB) error {
+	if b.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		b.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
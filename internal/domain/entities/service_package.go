@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+// ServicePackage is a fixed set of tasks sold together, e.g. "10,000 km
+// service". Applying it to a ticket expands it into maintenance items.
+type ServicePackage struct {
+	ID              types.MSSQLUUID      `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt       time.Time            `json:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt       `gorm:"index" json:"-"`
+	Code            string               `gorm:"type:varchar(30);not null;uniqueIndex" json:"code"`
+	Name            string               `gorm:"type:varchar(100);not null" json:"name"`
+	Description     string               `gorm:"type:text" json:"description"`
+	DiscountPercent float64              `gorm:"type:decimal(5,2);default:0" json:"discount_percent"` // off the list price of the items
+	FixedPrice      float64              `gorm:"type:decimal(12,2);default:0" json:"fixed_price"`     // package price, overrides DiscountPercent when set
+	IsActive        bool                 `json:"is_active"`
+	Items           []ServicePackageItem `gorm:"foreignKey:PackageID" json:"items"`
+}
+
+func (p *ServicePackage) BeforeCreate(_ *gorm.DB) error {
+	if p.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		p.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
+
+// ServicePackageItem is the template of one maintenance item of a package.
+type ServicePackageItem struct {
+	ID            types.MSSQLUUID      `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+	PackageID     types.MSSQLUUID      `gorm:"type:uniqueidentifier;not null;index" json:"package_id"`
+	Position      int                  `gorm:"not null;default:0" json:"position"`
+	Category      string               `gorm:"type:varchar(100);not null" json:"category"`
+	Name          string               `gorm:"type:varchar(200);not null" json:"name"`
+	Description   string               `gorm:"type:text" json:"description"`
+	EstimatedCost float64              `gorm:"type:decimal(10,2);default:0" json:"estimated_cost"` // without parts
+	LaborHours    float64              `gorm:"type:decimal(5,2);default:0" json:"labor_hours"`
+	Parts         []ServicePackagePart `gorm:"foreignKey:PackageItemID" json:"parts,omitempty"`
+}
+
+func (i *ServicePackageItem) BeforeCreate(_ *gorm.DB) error {
+	if i.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		i.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
+
+// ServicePackagePart is a product a package item normally uses.
+type ServicePackagePart struct {
+	ID            types.MSSQLUUID `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt     time.Time       `json:"created_at"`
+	PackageItemID types.MSSQLUUID `gorm:"type:uniqueidentifier;not null;index" json:"package_item_id"`
+	ProductID     types.MSSQLUUID `gorm:"type:uniqueidentifier;not null" json:"product_id"`
+	Quantity      int             `gorm:"not null;default:1" json:"quantity"`
+}
+
+func (p *ServicePackagePart) BeforeCreate(_ *gorm.DB) error {
+	if p.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		p.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+// ServiceType is an entry of the service catalog. Tickets reference it by Code
+// so reports group the same service together whatever customers typed.
+type ServiceType struct {
+	ID              types.MSSQLUUID `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt  `gorm:"index" json:"-"`
+	Code            string          `gorm:"type:varchar(30);not null;uniqueIndex" json:"code"`
+	Name            string          `gorm:"type:varchar(100);not null" json:"name"`
+	Category        string          `gorm:"type:varchar(100);not null;index" json:"category"` // e.g., "Engine", "Brakes"
+	DefaultDuration int             `gorm:"not null;default:0" json:"default_duration"`       // in minutes
+	BaseLaborPrice  float64         `gorm:"type:decimal(12,2);default:0" json:"base_labor_price"`
+	IsActive        bool            `json:"is_active"`
+}
+
+func (s *ServiceType) BeforeCreate(_ *gorm.DB) error {
+	if s.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		s.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
+
+var DefaultServiceTypes = []ServiceType{
+	{Code: "OIL_CHANGE", Name: "Oil change", Category: "Engine", DefaultDuration: 30, BaseLaborPrice: 50000, IsActive: true},
+	{Code: "TIRE_ROTATION", Name: "Tire rotation", Category: "Tires", DefaultDuration: 20, BaseLaborPrice: 40000, IsActive: true},
+	{Code: "BRAKE_SERVICE", Name: "Brake service", Category: "Brakes", DefaultDuration: 90, BaseLaborPrice: 150000, IsActive: true},
+	{Code: "GENERAL_SERVICE", Name: "General service", Category: "General", DefaultDuration: 120, BaseLaborPrice: 200000, IsActive: true},
+	{Code: "TRANSMISSION_SERVICE", Name: "Transmission service", Category: "Transmission", DefaultDuration: 240, BaseLaborPrice: 400000, IsActive: true},
+}
@@ -83,6 +83,240 @@ var DefaultSettings = []Setting{
 		IsEditable:  true,
 		IsPublic:    true,
 	},
+	{
+		Key:         "waiting_list.slot_interval_minutes",
+		Value:       "30",
+		Type:        SettingTypeInt,
+		Description: "Length of a bookable appointment slot in minutes",
+		Category:    "waiting_list",
+		IsEditable:  true,
+		IsPublic:    true,
+	},
+	{
+		Key:         "waiting_list.limit_mode",
+		Value:       "tickets",
+		Type:        SettingTypeString,
+		Description: "How the daily limit is applied: tickets (max_tickets_per_day) or capacity (bay-minutes from opening hours)",
+		Category:    "waiting_list",
+		IsEditable:  true,
+		IsPublic:    true,
+	},
+	{
+		Key:         "waiting_list.default_service_minutes",
+		Value:       "30",
+		Type:        SettingTypeInt,
+		Description: "Fallback service duration in minutes for wait estimates when there is no history or estimate",
+		Category:    "waiting_list",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "waiting_list.eta_history_days",
+		Value:       "90",
+		Type:        SettingTypeInt,
+		Description: "How many days of completed services are averaged for wait estimates",
+		Category:    "waiting_list",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "waiting_list.auto_assign_mechanic",
+		Value:       "false",
+		Type:        SettingTypeBool,
+		Description: "Assign the least-loaded mechanic when service starts on a ticket without one",
+		Category:    "waiting_list",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "waiting_list.no_show_job_enabled",
+		Value:       "false",
+		Type:        SettingTypeBool,
+		Description: "Enable or disable the job that marks called customers who never arrive as no-show",
+		Category:    "waiting_list",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "waiting_list.no_show_job_schedule",
+		Value:       "*/5 * * * *",
+		Type:        SettingTypeString,
+		Description: "Cron schedule for the no-show job (format: minute hour day month weekday)",
+		Category:    "waiting_list",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "waiting_list.no_show_grace_minutes",
+		Value:       "15",
+		Type:        SettingTypeInt,
+		Description: "Minutes a called customer has to arrive before the ticket is marked no-show",
+		Category:    "waiting_list",
+		IsEditable:  true,
+		IsPublic:    true,
+	},
+	{
+		Key:         "waiting_list.auto_call_next",
+		Value:       "false",
+		Type:        SettingTypeBool,
+		Description: "Call the next waiting customer when a ticket is marked no-show by the job",
+		Category:    "waiting_list",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "labor.default_rate",
+		Value:       "100000",
+		Type:        SettingTypeFloat,
+		Description: "Shop labor rate per hour, used for categories without their own rate",
+		Category:    "labor",
+		IsEditable:  true,
+		IsPublic:    true,
+	},
+	{
+		Key:         "labor.category_rates",
+		Value:       "",
+		Type:        SettingTypeString,
+		Description: "Labor rates per hour by item category, comma-separated Category:rate (e.g. Engine:150000,Electrical:175000)",
+		Category:    "labor",
+		IsEditable:  true,
+		IsPublic:    true,
+	},
+	{
+		Key:         "labor.skill_multipliers",
+		Value:       "junior:0.8,senior:1.25,master:1.5",
+		Type:        SettingTypeString,
+		Description: "Rate multipliers by mechanic skill level, comma-separated level:multiplier; other levels pay the plain rate",
+		Category:    "labor",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "labor.overtime_multiplier",
+		Value:       "1.5",
+		Type:        SettingTypeFloat,
+		Description: "Rate multiplier for work done outside business hours",
+		Category:    "labor",
+		IsEditable:  true,
+		IsPublic:    true,
+	},
+	{
+		Key:         "labor.weekend_multiplier",
+		Value:       "1.5",
+		Type:        SettingTypeFloat,
+		Description: "Rate multiplier for work done on Saturday or Sunday",
+		Category:    "labor",
+		IsEditable:  true,
+		IsPublic:    true,
+	},
+	{
+		Key:         "maintenance.approval_link_hours",
+		Value:       "48",
+		Type:        SettingTypeInt,
+		Description: "How many hours an approval link sent to a customer stays valid",
+		Category:    "maintenance",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "maintenance.approval_job_enabled",
+		Value:       "true",
+		Type:        SettingTypeBool,
+		Description: "Enable the job that follows up on items waiting for customer approval",
+		Category:    "maintenance",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "maintenance.approval_job_schedule",
+		Value:       "*/5 * * * *",
+		Type:        SettingTypeString,
+		Description: "Cron schedule of the approval follow-up job",
+		Category:    "maintenance",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "maintenance.approval_reminder_minutes",
+		Value:       "60",
+		Type:        SettingTypeInt,
+		Description: "Minutes an item waits for approval before the customer is sent a reminder (0 to disable)",
+		Category:    "maintenance",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "maintenance.approval_escalation_minutes",
+		Value:       "180",
+		Type:        SettingTypeInt,
+		Description: "Minutes an item waits for approval before the service advisor is asked to phone the customer (0 to disable)",
+		Category:    "maintenance",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "maintenance.approval_auto_skip",
+		Value:       "false",
+		Type:        SettingTypeBool,
+		Description: "Skip items still waiting for approval once the rest of the ticket's work is done",
+		Category:    "maintenance",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "maintenance.approval_auto_skip_minutes",
+		Value:       "240",
+		Type:        SettingTypeInt,
+		Description: "Minutes an item must have waited for approval before it can be skipped automatically",
+		Category:    "maintenance",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "maintenance.tax_percent",
+		Value:       "11",
+		Type:        SettingTypeFloat,
+		Description: "Tax added to repair estimates, in percent of the subtotal",
+		Category:    "maintenance",
+		IsEditable:  true,
+		IsPublic:    true,
+	},
+	{
+		Key:         "maintenance.estimate_overrun_percent",
+		Value:       "10",
+		Type:        SettingTypeFloat,
+		Description: "How far the final cost may exceed the accepted estimate, in percent, before completing the service warns",
+		Category:    "maintenance",
+		IsEditable:  true,
+		IsPublic:    false,
+	},
+	{
+		Key:         "warranty.default_months",
+		Value:       "3",
+		Type:        SettingTypeInt,
+		Description: "Months of warranty on completed work in categories without their own terms (0 for no time limit)",
+		Category:    "warranty",
+		IsEditable:  true,
+		IsPublic:    true,
+	},
+	{
+		Key:         "warranty.default_km",
+		Value:       "5000",
+		Type:        SettingTypeInt,
+		Description: "Kilometres of warranty on completed work in categories without their own terms (0 for no distance limit)",
+		Category:    "warranty",
+		IsEditable:  true,
+		IsPublic:    true,
+	},
+	{
+		Key:         "warranty.category_terms",
+		Value:       "Brakes:6/10000,Engine:6/10000",
+		Type:        SettingTypeString,
+		Description: "Warranty terms by item category, comma-separated Category:months/km (e.g. Brakes:6/10000,Tires:12/0); 0/0 gives no warranty",
+		Category:    "warranty",
+		IsEditable:  true,
+		IsPublic:    true,
+	},
 	{
 		Key:         "business.shop_name",
 		Value:       "Car Service Center",
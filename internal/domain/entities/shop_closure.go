@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+// ShopClosure marks a day or date range when the shop is closed, either all day
+// or between StartTime and EndTime. Recurring closures repeat every year on the
+// same month and day (public holidays).
+type ShopClosure struct {
+	ID          types.MSSQLUUID `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt  `gorm:"index" json:"-"`
+	Name        string          `gorm:"type:varchar(100);not null" json:"name"`
+	StartDate   time.Time       `gorm:"type:date;not null;index" json:"start_date"`
+	EndDate     time.Time       `gorm:"type:date;not null" json:"end_date"`          // same as StartDate for a single day
+	StartTime   string          `gorm:"type:varchar(5)" json:"start_time,omitempty"` // HH:MM, empty for a full-day closure
+	EndTime     string          `gorm:"type:varchar(5)" json:"end_time,omitempty"`   // HH:MM, empty for a full-day closure
+	IsRecurring bool            `gorm:"default:0" json:"is_recurring"`
+}
+
+func (c *ShopClosure) BeforeCreate(_ *gorm.DB) error {
+	if c.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		c.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
+
+func (c *ShopClosure) IsFullDay() bool {
+	return c.StartTime == "" && c.EndTime == ""
+}
+
+// Covers reports whether the closure applies to the calendar day of date.
+func (c *ShopClosure) Covers(date time.Time) bool {
+	day := date.Format("2006-01-02")
+	if !c.IsRecurring {
+		return day >= c.StartDate.Format("2006-01-02") && day <= c.EndDate.Format("2006-01-02")
+	}
+	monthDay := date.Format("01-02")
+	from, to := c.StartDate.Format("01-02"), c.EndDate.Format("01-02")
+	if from <= to {
+		return monthDay >= from && monthDay <= to
+	}
+	return monthDay >= from || monthDay <= to // wraps over New Year
+}
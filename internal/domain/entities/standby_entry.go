@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+type StandbyStatus string
+
+const (
+	StandbyStatusWaiting  StandbyStatus = "waiting"
+	StandbyStatusPromoted StandbyStatus = "promoted"
+	StandbyStatusExpired  StandbyStatus = "expired"
+	StandbyStatusCanceled StandbyStatus = "canceled"
+)
+
+// StandbyEntry is a customer waiting for a ticket on a fully booked day. The
+// oldest waiting entry is promoted to a real ticket when a place frees up.
+type StandbyEntry struct {
+	ID              types.MSSQLUUID  `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt   `gorm:"index" json:"-"`
+	VehicleID       types.MSSQLUUID  `gorm:"type:uniqueidentifier;not null" json:"vehicle_id"`
+	CustomerID      types.MSSQLUUID  `gorm:"type:uniqueidentifier;not null;index" json:"customer_id"`
+	ServiceDate     time.Time        `gorm:"not null;index" json:"service_date"`
+	ServiceType     string           `gorm:"type:varchar(100);not null" json:"service_type"`
+	ServiceTypeCode string           `gorm:"type:varchar(30)" json:"service_type_code,omitempty"`
+	EstimatedTime   int              `json:"estimated_time"` // in minutes
+	Notes           string           `gorm:"type:text" json:"notes"`
+	Status          StandbyStatus    `gorm:"type:varchar(30);default:'waiting';index" json:"status"`
+	WaitingListID   *types.MSSQLUUID `gorm:"type:uniqueidentifier" json:"waiting_list_id,omitempty"` // ticket created on promotion
+	PromotedAt      *time.Time       `json:"promoted_at,omitempty"`
+	Position        int              `gorm:"-" json:"position,omitempty"` // 1-based place in the day's standby list
+	Vehicle         Vehicle          `gorm:"foreignKey:VehicleID" json:"vehicle,omitempty"`
+	Customer        User             `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+}
+
+func (s *StandbyEntry) BeforeCreate(_ *gorm.DB) error {
+	if s.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		s.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
@@ -8,15 +8,17 @@ import (
 )
 
 type User struct {
-	ID        types.MSSQLUUID `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
-	CreatedAt time.Time       `json:"created_at"`
-	UpdatedAt time.Time       `json:"updated_at"`
-	DeletedAt gorm.DeletedAt  `gorm:"index" json:"-"`
-	Email     string          `gorm:"not null;unique" json:"email"`
-	Password  string          `gorm:"not null" json:"-"`
-	Name      string          `json:"name"`
-	Phone     string          `json:"phone"`
-	Address   string          `json:"address"`
+	ID         types.MSSQLUUID `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt  `gorm:"index" json:"-"`
+	Email      string          `gorm:"not null;unique" json:"email"`
+	Password   string          `gorm:"not null" json:"-"`
+	Name       string          `json:"name"`
+	Phone      string          `json:"phone"`
+	Address    string          `json:"address"`
+	IsGuest    bool            `gorm:"default:0" json:"is_guest"`                     // created at a kiosk, cannot log in
+	SkillLevel string          `gorm:"type:varchar(20)" json:"skill_level,omitempty"` // mechanics only, see labor.skill_multipliers
 
 	// Many-to-many relationship with roles table (RBAC system)
 	Roles []Role `gorm:"many2many:user_roles;" json:"roles,omitempty"`
@@ -18,24 +18,52 @@ const (
 	WaitingListStatusNoShow    WaitingListStatus = "no_show"
 )
 
+// waitingListTransitions is the ticket state machine. Completed, canceled and
+// no-show tickets are final.
+var waitingListTransitions = map[WaitingListStatus][]WaitingListStatus{
+	WaitingListStatusWaiting:   {WaitingListStatusCalled, WaitingListStatusCanceled},
+	WaitingListStatusCalled:    {WaitingListStatusInService, WaitingListStatusNoShow, WaitingListStatusCanceled},
+	WaitingListStatusInService: {WaitingListStatusCompleted, WaitingListStatusCanceled},
+}
+
+// CanTransitionTo reports whether a ticket in status s may move to next.
+func (s WaitingListStatus) CanTransitionTo(next WaitingListStatus) bool {
+	for _, allowed := range waitingListTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 type WaitingList struct {
-	ID             types.MSSQLUUID   `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
-	CreatedAt      time.Time         `json:"created_at"`
-	UpdatedAt      time.Time         `json:"updated_at"`
-	DeletedAt      gorm.DeletedAt    `gorm:"index" json:"-"`
-	QueueNumber    int               `gorm:"uniqueIndex:idx_queue_date;not null" json:"queue_number"`
-	VehicleID      types.MSSQLUUID   `gorm:"type:uniqueidentifier;not null" json:"vehicle_id"`
-	CustomerID     types.MSSQLUUID   `gorm:"type:uniqueidentifier;not null" json:"customer_id"`
-	ServiceDate    time.Time         `gorm:"uniqueIndex:idx_queue_date;not null" json:"service_date"`
-	ServiceType    string            `gorm:"type:varchar(100);not null" json:"service_type"`
-	EstimatedTime  int               `json:"estimated_time"` // in minutes
-	Status         WaitingListStatus `gorm:"type:varchar(30);default:'waiting'" json:"status"`
-	CalledAt       *time.Time        `json:"called_at,omitempty"`
-	ServiceStartAt *time.Time        `json:"service_start_at,omitempty"`
-	ServiceEndAt   *time.Time        `json:"service_end_at,omitempty"`
-	Notes          string            `gorm:"type:text" json:"notes"`
-	Vehicle        Vehicle           `gorm:"foreignKey:VehicleID" json:"vehicle,omitempty"`
-	Customer       User              `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	ID                   types.MSSQLUUID   `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt            time.Time         `json:"created_at"`
+	UpdatedAt            time.Time         `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt    `gorm:"index" json:"-"`
+	QueueNumber          int               `gorm:"uniqueIndex:idx_queue_date;not null" json:"queue_number"`
+	VehicleID            types.MSSQLUUID   `gorm:"type:uniqueidentifier;not null" json:"vehicle_id"`
+	CustomerID           types.MSSQLUUID   `gorm:"type:uniqueidentifier;not null" json:"customer_id"`
+	ServiceDate          time.Time         `gorm:"uniqueIndex:idx_queue_date;not null" json:"service_date"`
+	ServiceType          string            `gorm:"type:varchar(100);not null" json:"service_type"`
+	ServiceTypeCode      string            `gorm:"type:varchar(30);index" json:"service_type_code,omitempty"` // catalog code, empty on tickets taken before the catalog existed
+	EstimatedTime        int               `json:"estimated_time"`                                            // in minutes
+	AppointmentAt        *time.Time        `gorm:"index" json:"appointment_at,omitempty"`                     // Booked slot start, nil for walk-ins
+	Status               WaitingListStatus `gorm:"type:varchar(30);default:'waiting'" json:"status"`
+	ArrivedAt            *time.Time        `json:"arrived_at,omitempty"` // set when staff scan the ticket's QR code
+	CalledAt             *time.Time        `json:"called_at,omitempty"`
+	ServiceStartAt       *time.Time        `json:"service_start_at,omitempty"`
+	ServiceEndAt         *time.Time        `json:"service_end_at,omitempty"`
+	ServiceBayID         *types.MSSQLUUID  `gorm:"type:uniqueidentifier;index" json:"service_bay_id,omitempty"`
+	MechanicID           *types.MSSQLUUID  `gorm:"type:uniqueidentifier;index" json:"mechanic_id,omitempty"`
+	Notes                string            `gorm:"type:text" json:"notes"`
+	RescheduledFromDate  *time.Time        `json:"rescheduled_from_date,omitempty"`  // service date before the last reschedule
+	RescheduledFromQueue *int              `json:"rescheduled_from_queue,omitempty"` // queue number before the last reschedule
+	RescheduleCount      int               `gorm:"default:0" json:"reschedule_count"`
+	Vehicle              Vehicle           `gorm:"foreignKey:VehicleID" json:"vehicle,omitempty"`
+	Customer             User              `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	ServiceBay           *ServiceBay       `gorm:"foreignKey:ServiceBayID" json:"service_bay,omitempty"`
+	Mechanic             *User             `gorm:"foreignKey:MechanicID" json:"mechanic,omitempty"`
 }
 
 func (w *WaitingList) BeforeCreate(_ *gorm.DB) error {
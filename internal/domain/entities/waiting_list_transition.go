@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+// WaitingListTransition is one status change of a ticket. Rows are only ever
+// appended, so together they form the ticket's history.
+type WaitingListTransition struct {
+	ID            types.MSSQLUUID   `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt     time.Time         `gorm:"index" json:"created_at"`
+	WaitingListID types.MSSQLUUID   `gorm:"type:uniqueidentifier;not null;index" json:"waiting_list_id"`
+	FromStatus    WaitingListStatus `gorm:"type:varchar(30)" json:"from_status,omitempty"` // empty when the ticket was created
+	ToStatus      WaitingListStatus `gorm:"type:varchar(30);not null" json:"to_status"`
+	ActorID       *types.MSSQLUUID  `gorm:"type:uniqueidentifier" json:"actor_id,omitempty"` // nil for background jobs
+	Reason        string            `gorm:"type:varchar(255)" json:"reason,omitempty"`
+	Actor         *User             `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+}
+
+func (t *WaitingListTransition) BeforeCreate(_ *gorm.DB) error {
+	if t.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		t.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+// WarrantyClaim links a comeback repair to the completed work it is covered
+// by. The repair is a maintenance item on the new ticket that the customer
+// is not billed for.
+type WarrantyClaim struct {
+	ID             types.MSSQLUUID  `gorm:"type:uniqueidentifier;primary_key;default:newid()" json:"id"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt   `gorm:"index" json:"-"`
+	OriginalItemID types.MSSQLUUID  `gorm:"type:uniqueidentifier;not null;uniqueIndex:idx_warranty_claim_ticket" json:"original_item_id"`
+	RepairItemID   types.MSSQLUUID  `gorm:"type:uniqueidentifier;not null" json:"repair_item_id"`
+	VehicleID      types.MSSQLUUID  `gorm:"type:uniqueidentifier;not null;index" json:"vehicle_id"`
+	WaitingListID  types.MSSQLUUID  `gorm:"type:uniqueidentifier;not null;index;uniqueIndex:idx_warranty_claim_ticket" json:"waiting_list_id"` // ticket the repair is done on, at most one claim per original item
+	OpenedByID     types.MSSQLUUID  `gorm:"type:uniqueidentifier;not null" json:"opened_by_id"`
+	Reason         string           `gorm:"type:varchar(500);not null" json:"reason"`
+	Mileage        int              `gorm:"default:0" json:"mileage"` // odometer when the claim was opened
+	OriginalItem   *MaintenanceItem `gorm:"foreignKey:OriginalItemID" json:"original_item,omitempty"`
+	RepairItem     *MaintenanceItem `gorm:"foreignKey:RepairItemID" json:"repair_item,omitempty"`
+	OpenedBy       *User            `gorm:"foreignKey:OpenedByID" json:"opened_by,omitempty"`
+}
+
+func (c *WarrantyClaim) BeforeCreate(_ *gorm.DB) error {
+	if c.ID.String() == "00000000-0000-0000-0000-000000000000" {
+		c.ID = types.NewMSSQLUUID()
+	}
+	return nil
+}
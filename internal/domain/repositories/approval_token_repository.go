@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+type ApprovalTokenRepository interface {
+	Create(ctx context.Context, token *entities.ApprovalToken) error
+	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.ApprovalToken, error)
+	// RevokeByWaitingList removes the ticket's tokens so older links stop working.
+	RevokeByWaitingList(ctx context.Context, waitingListID types.MSSQLUUID) error
+}
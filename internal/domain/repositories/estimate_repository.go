@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+// ErrEstimateNotOpen is returned when a decision arrives for an estimate
+// that is no longer waiting for one.
+var ErrEstimateNotOpen = errors.New("estimate is not waiting for a decision")
+
+// EstimateRepository loads estimates together with their lines and parts.
+type EstimateRepository interface {
+	// Create stores estimate as the ticket's next version and supersedes
+	// the earlier versions still waiting for the customer, in one
+	// transaction. It sets estimate.Version.
+	Create(ctx context.Context, estimate *entities.Estimate) error
+	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.Estimate, error)
+	// GetByWaitingListID returns every version of the ticket's estimate,
+	// newest first.
+	GetByWaitingListID(ctx context.Context, waitingListID types.MSSQLUUID) ([]*entities.Estimate, error)
+	GetLatest(ctx context.Context, waitingListID types.MSSQLUUID) (*entities.Estimate, error)
+	GetLatestAccepted(ctx context.Context, waitingListID types.MSSQLUUID) (*entities.Estimate, error)
+	// Decide saves the customer's answer. It fails with ErrEstimateNotOpen
+	// if the estimate is no longer sent.
+	Decide(ctx context.Context, estimate *entities.Estimate) error
+}
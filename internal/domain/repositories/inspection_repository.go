@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+// InspectionTemplateRepository loads templates together with their points.
+type InspectionTemplateRepository interface {
+	Create(ctx context.Context, template *entities.InspectionTemplate) error
+	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.InspectionTemplate, error)
+	GetAll(ctx context.Context) ([]*entities.InspectionTemplate, error)
+	GetActive(ctx context.Context) ([]*entities.InspectionTemplate, error)
+	// Update saves the template and replaces its points with template.Points.
+	Update(ctx context.Context, template *entities.InspectionTemplate) error
+	Delete(ctx context.Context, id types.MSSQLUUID) error
+}
+
+// InspectionRepository loads inspections together with their results.
+// ErrFindingsAlreadyAdded is returned when an inspection's findings were
+// already added as maintenance items.
+var ErrFindingsAlreadyAdded = errors.New("inspection findings were already added")
+
+type InspectionRepository interface {
+	Create(ctx context.Context, inspection *entities.Inspection) error
+	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.Inspection, error)
+	GetByWaitingListID(ctx context.Context, waitingListID types.MSSQLUUID) (*entities.Inspection, error)
+	// Update saves the inspection and the given results in one transaction.
+	Update(ctx context.Context, inspection *entities.Inspection, results []*entities.InspectionResult) error
+	// AddFindings creates items and links results[i] to items[i] in one
+	// transaction, setting the inspection's FindingsAt. It fails with
+	// ErrFindingsAlreadyAdded, creating nothing, when FindingsAt is set.
+	AddFindings(ctx context.Context, inspection *entities.Inspection, results []*entities.InspectionResult, items []*entities.MaintenanceItem) error
+}
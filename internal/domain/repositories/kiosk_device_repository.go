@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+type KioskDeviceRepository interface {
+	Create(ctx context.Context, device *entities.KioskDevice) error
+	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.KioskDevice, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entities.KioskDevice, error)
+	GetAll(ctx context.Context) ([]*entities.KioskDevice, error)
+	Update(ctx context.Context, device *entities.KioskDevice) error
+	CreateActivity(ctx context.Context, activity *entities.KioskActivity) error
+	GetActivity(ctx context.Context, deviceID types.MSSQLUUID, limit int) ([]*entities.KioskActivity, error)
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+// ErrItemNotAwaitingApproval is returned when a decision arrives for an item
+// that is no longer waiting for one, e.g. because it was decided meanwhile.
+var ErrItemNotAwaitingApproval = errors.New("item is not waiting for approval")
+
+// ErrApprovalTokenUsed is returned when decisions arrive through an approval
+// link that was already used.
+var ErrApprovalTokenUsed = errors.New("approval token was already used")
+
+type MaintenanceItemApprovalRepository interface {
+	// RecordDecisions applies each decision to its item and stores it in one
+	// transaction. It fails with ErrItemNotAwaitingApproval, recording
+	// nothing, if any item is no longer inspected.
+	RecordDecisions(ctx context.Context, approvals []*entities.MaintenanceItemApproval) error
+	// RecordLinkDecisions marks the approval token used and records the
+	// decisions in one transaction, so a failed recording leaves the link
+	// usable. It fails with ErrApprovalTokenUsed if the token was used.
+	RecordLinkDecisions(ctx context.Context, tokenID types.MSSQLUUID, approvals []*entities.MaintenanceItemApproval) error
+	GetByWaitingListID(ctx context.Context, waitingListID types.MSSQLUUID) ([]*entities.MaintenanceItemApproval, error)
+}
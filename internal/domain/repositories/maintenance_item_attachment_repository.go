@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+type MaintenanceItemAttachmentRepository interface {
+	Create(ctx context.Context, attachment *entities.MaintenanceItemAttachment) error
+	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.MaintenanceItemAttachment, error)
+	GetByItemID(ctx context.Context, itemID types.MSSQLUUID) ([]*entities.MaintenanceItemAttachment, error)
+	Delete(ctx context.Context, id types.MSSQLUUID) error
+}
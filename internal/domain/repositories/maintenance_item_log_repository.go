@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+type MaintenanceItemLogRepository interface {
+	Create(ctx context.Context, log *entities.MaintenanceItemLog) error
+	GetByItemID(ctx context.Context, itemID types.MSSQLUUID) ([]*entities.MaintenanceItemLog, error)
+	GetByItemIDs(ctx context.Context, itemIDs []types.MSSQLUUID) ([]*entities.MaintenanceItemLog, error)
+	// Skip marks the logged item as skipped and stores the log in one
+	// transaction. It fails with ErrItemNotAwaitingApproval if the item is
+	// no longer inspected.
+	Skip(ctx context.Context, log *entities.MaintenanceItemLog) error
+}
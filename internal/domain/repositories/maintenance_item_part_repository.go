@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+// ErrInsufficientStock is returned when completing an item would take more
+// of a product than is in stock.
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+type MaintenanceItemPartRepository interface {
+	Create(ctx context.Context, part *entities.MaintenanceItemPart) error
+	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.MaintenanceItemPart, error)
+	GetByItemID(ctx context.Context, itemID types.MSSQLUUID) ([]*entities.MaintenanceItemPart, error)
+	Delete(ctx context.Context, id types.MSSQLUUID) error
+	// CompleteItem saves item and takes the quantities of its parts out of
+	// stock in one transaction. Unless allowShortage is set, a part with too
+	// little stock fails the whole completion with ErrInsufficientStock.
+	// Each part is taken out of stock at most once, also under concurrent
+	// calls.
+	CompleteItem(ctx context.Context, item *entities.MaintenanceItem, allowShortage bool) error
+	// RestockItem saves item and puts the quantities taken by CompleteItem
+	// back into stock in one transaction, at most once per part.
+	RestockItem(ctx context.Context, item *entities.MaintenanceItem) error
+}
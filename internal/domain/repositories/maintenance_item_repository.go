@@ -7,6 +7,18 @@ import (
 	"github.com/kuahbanyak/go-crud/internal/shared/types"
 )
 
+// MaintenanceCostTotals sums the items of a ticket that are still billable.
+// Parts and Labor break the work down; Estimated and Actual are the quoted
+// and final prices. Repairs done under warranty are not billable; Warranty
+// is their actual cost, which the shop carries.
+type MaintenanceCostTotals struct {
+	Estimated float64
+	Actual    float64
+	Parts     float64
+	Labor     float64
+	Warranty  float64
+}
+
 type MaintenanceItemRepository interface {
 	Create(ctx context.Context, item *entities.MaintenanceItem) error
 	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.MaintenanceItem, error)
@@ -16,12 +28,18 @@ type MaintenanceItemRepository interface {
 	GetByStatus(ctx context.Context, waitingListID types.MSSQLUUID, status entities.MaintenanceItemStatus) ([]*entities.MaintenanceItem, error)
 	GetByType(ctx context.Context, waitingListID types.MSSQLUUID, itemType entities.MaintenanceItemType) ([]*entities.MaintenanceItem, error)
 	GetPendingApproval(ctx context.Context, waitingListID types.MSSQLUUID) ([]*entities.MaintenanceItem, error)
+	// GetAllPendingApproval returns the items of every ticket that are waiting
+	// for the customer's approval, with their ticket, customer and vehicle.
+	GetAllPendingApproval(ctx context.Context) ([]*entities.MaintenanceItem, error)
+	// GetWarrantiesByVehicle returns the completed items of the vehicle's
+	// tickets that were given warranty terms, newest first.
+	GetWarrantiesByVehicle(ctx context.Context, vehicleID types.MSSQLUUID) ([]*entities.MaintenanceItem, error)
 	GetInitialItems(ctx context.Context, waitingListID types.MSSQLUUID) ([]*entities.MaintenanceItem, error)
 	GetDiscoveredItems(ctx context.Context, waitingListID types.MSSQLUUID) ([]*entities.MaintenanceItem, error)
 	CreateMany(ctx context.Context, items []*entities.MaintenanceItem) error
 	UpdateStatus(ctx context.Context, id types.MSSQLUUID, status entities.MaintenanceItemStatus) error
 	ApproveItems(ctx context.Context, ids []types.MSSQLUUID) error
 	RejectItems(ctx context.Context, ids []types.MSSQLUUID) error
-	GetTotalCost(ctx context.Context, waitingListID types.MSSQLUUID) (estimated float64, actual float64, err error)
+	GetTotalCost(ctx context.Context, waitingListID types.MSSQLUUID) (*MaintenanceCostTotals, error)
 	CountByStatus(ctx context.Context, waitingListID types.MSSQLUUID) (map[string]int, error)
 }
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+type ServiceBayRepository interface {
+	Create(ctx context.Context, bay *entities.ServiceBay) error
+	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.ServiceBay, error)
+	GetByCode(ctx context.Context, code string) (*entities.ServiceBay, error)
+	GetAll(ctx context.Context) ([]*entities.ServiceBay, error)
+	GetActive(ctx context.Context) ([]*entities.ServiceBay, error)
+	Update(ctx context.Context, bay *entities.ServiceBay) error
+	Delete(ctx context.Context, id types.MSSQLUUID) error
+}
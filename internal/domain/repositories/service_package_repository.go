@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+// ServicePackageRepository loads packages together with their items and the
+// items' parts.
+type ServicePackageRepository interface {
+	Create(ctx context.Context, pkg *entities.ServicePackage) error
+	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.ServicePackage, error)
+	GetByCode(ctx context.Context, code string) (*entities.ServicePackage, error)
+	GetAll(ctx context.Context) ([]*entities.ServicePackage, error)
+	GetActive(ctx context.Context) ([]*entities.ServicePackage, error)
+	// Update saves the package and replaces its items with pkg.Items.
+	Update(ctx context.Context, pkg *entities.ServicePackage) error
+	Delete(ctx context.Context, id types.MSSQLUUID) error
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+type ServiceTypeRepository interface {
+	Create(ctx context.Context, serviceType *entities.ServiceType) error
+	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.ServiceType, error)
+	GetByCode(ctx context.Context, code string) (*entities.ServiceType, error)
+	// GetByName matches the name case-insensitively.
+	GetByName(ctx context.Context, name string) (*entities.ServiceType, error)
+	GetAll(ctx context.Context) ([]*entities.ServiceType, error)
+	GetActive(ctx context.Context) ([]*entities.ServiceType, error)
+	Update(ctx context.Context, serviceType *entities.ServiceType) error
+	Delete(ctx context.Context, id types.MSSQLUUID) error
+	// SeedDefaults creates the default catalog entries whose code has never
+	// been used, so entries an admin deleted are not brought back.
+	SeedDefaults(ctx context.Context) error
+}
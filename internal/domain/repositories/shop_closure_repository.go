@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+type ShopClosureRepository interface {
+	Create(ctx context.Context, closure *entities.ShopClosure) error
+	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.ShopClosure, error)
+	GetAll(ctx context.Context) ([]*entities.ShopClosure, error)
+	Update(ctx context.Context, closure *entities.ShopClosure) error
+	Delete(ctx context.Context, id types.MSSQLUUID) error
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+// ErrStandbyNotWaiting is returned when a standby entry is promoted after
+// another promotion already took it off the list.
+var ErrStandbyNotWaiting = errors.New("standby entry is no longer waiting")
+
+type StandbyRepository interface {
+	Create(ctx context.Context, entry *entities.StandbyEntry) error
+	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.StandbyEntry, error)
+	// GetWaitingByDate returns the day's waiting entries, oldest first.
+	GetWaitingByDate(ctx context.Context, serviceDate time.Time) ([]*entities.StandbyEntry, error)
+	// GetWaitingByCustomer returns the customer's waiting entries from the
+	// start of from's day on, in from's location.
+	GetWaitingByCustomer(ctx context.Context, customerID types.MSSQLUUID, from time.Time) ([]*entities.StandbyEntry, error)
+	// Promote marks a waiting entry promoted and saves waitingList, with the
+	// next queue number as in SaveWithQueueNumber, and transition in one
+	// transaction. The entry is claimed with a conditional update, so when
+	// promotions race only one succeeds; the others get ErrStandbyNotWaiting.
+	Promote(ctx context.Context, entry *entities.StandbyEntry, waitingList *entities.WaitingList, transition *entities.WaitingListTransition, limit DailyLimit) error
+	// ExpireBefore marks waiting entries for service dates before the given
+	// day as expired and returns how many were changed.
+	ExpireBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -10,8 +10,13 @@ import (
 
 type VehicleRepository interface {
 	Create(ctx context.Context, vehicle *entities.Vehicle) error
+	// CreateWithOwner creates owner and then vehicle for them in one
+	// transaction, so a failed vehicle insert leaves no user behind.
+	CreateWithOwner(ctx context.Context, owner *entities.User, vehicle *entities.Vehicle) error
 	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.Vehicle, error)
 	GetByOwnerID(ctx context.Context, ownerID types.MSSQLUUID) ([]*entities.Vehicle, error)
+	// GetByLicensePlate matches plates ignoring case, spaces and dashes.
+	GetByLicensePlate(ctx context.Context, plate string) (*entities.Vehicle, error)
 	Update(ctx context.Context, vehicle *entities.Vehicle) error
 	Delete(ctx context.Context, id types.MSSQLUUID) error
 	List(ctx context.Context, limit, offset int) ([]*entities.Vehicle, error)
@@ -2,12 +2,48 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/kuahbanyak/go-crud/internal/domain/entities"
 	"github.com/kuahbanyak/go-crud/internal/shared/types"
 )
 
+// ErrSlotUnavailable is returned when an appointment overlaps a slot that was
+// booked after availability was checked.
+var ErrSlotUnavailable = errors.New("selected time slot is no longer available")
+
+// ErrBayOccupied is returned when a ticket is started in a bay that is already
+// serving another vehicle.
+var ErrBayOccupied = errors.New("service bay is already serving another vehicle")
+
+// ErrDailyLimitReached is returned when a service date already has the
+// maximum number of active tickets.
+var ErrDailyLimitReached = errors.New("daily ticket limit reached")
+
+// ErrCapacityExceeded is returned in capacity mode when a ticket's estimated
+// minutes do not fit in what is left of the day. It wraps ErrDailyLimitReached.
+var ErrCapacityExceeded = fmt.Errorf("%w: not enough service capacity left", ErrDailyLimitReached)
+
+// DailyLimit bounds how many tickets a service date accepts. MaxTickets caps
+// the number of active tickets when positive. CapacityMinutes, when positive,
+// caps the sum of their estimated minutes, counting DefaultMinutes for
+// tickets without an estimate.
+type DailyLimit struct {
+	MaxTickets      int
+	CapacityMinutes int
+	DefaultMinutes  int
+}
+
+// ServiceAverages holds historical service minutes keyed by catalog code
+// and, for tickets taken before the catalog existed, by lower-cased service
+// type name.
+type ServiceAverages struct {
+	ByCode map[string]float64
+	ByName map[string]float64
+}
+
 type WaitingListRepository interface {
 	Create(ctx context.Context, waitingList *entities.WaitingList) error
 	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.WaitingList, error)
@@ -15,8 +51,19 @@ type WaitingListRepository interface {
 	GetByCustomerID(ctx context.Context, customerID types.MSSQLUUID) ([]*entities.WaitingList, error)
 	GetByServiceDate(ctx context.Context, serviceDate time.Time) ([]*entities.WaitingList, error)
 	GetByStatus(ctx context.Context, status entities.WaitingListStatus, serviceDate time.Time) ([]*entities.WaitingList, error)
-	GetNextQueueNumber(ctx context.Context, serviceDate time.Time) (int, error)
+	GetByMechanic(ctx context.Context, mechanicID types.MSSQLUUID, serviceDate time.Time) ([]*entities.WaitingList, error)
+	// SaveWithQueueNumber assigns the next queue number for the ticket's
+	// service date and saves it, failing with ErrDailyLimitReached once the
+	// ticket would exceed limit. Allocation is atomic per day.
+	//
+	// The methods taking a transition store it in the same transaction as
+	// the ticket, with its WaitingListID set, unless it is nil.
+	SaveWithQueueNumber(ctx context.Context, waitingList *entities.WaitingList, limit DailyLimit, transition *entities.WaitingListTransition) error
+	CreateAppointment(ctx context.Context, waitingList *entities.WaitingList, defaultMinutes int, limit DailyLimit, transition *entities.WaitingListTransition) error
+	GetAverageServiceMinutes(ctx context.Context, since time.Time) (ServiceAverages, error)
+	StartServiceInBay(ctx context.Context, waitingList *entities.WaitingList, transition *entities.WaitingListTransition) error
 	Update(ctx context.Context, waitingList *entities.WaitingList) error
+	UpdateWithTransition(ctx context.Context, waitingList *entities.WaitingList, transition *entities.WaitingListTransition) error
 	Delete(ctx context.Context, id types.MSSQLUUID) error
 	List(ctx context.Context, limit, offset int) ([]*entities.WaitingList, error)
 }
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+type WaitingListTransitionRepository interface {
+	Create(ctx context.Context, transition *entities.WaitingListTransition) error
+	GetByWaitingListID(ctx context.Context, waitingListID types.MSSQLUUID) ([]*entities.WaitingListTransition, error)
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+// ErrWarrantyClaimExists is returned when an item already has a warranty
+// claim on the ticket.
+var ErrWarrantyClaimExists = errors.New("warranty claim already exists on the ticket")
+
+// WarrantyClaimRepository loads claims with their original and repair items.
+type WarrantyClaimRepository interface {
+	// Create stores the repair item and the claim in one transaction.
+	// repair.WarrantyClaimID and claim.RepairItemID are set. It fails with
+	// ErrWarrantyClaimExists, storing nothing, when the original item already
+	// has a claim on the same ticket.
+	Create(ctx context.Context, claim *entities.WarrantyClaim, repair *entities.MaintenanceItem) error
+	GetByID(ctx context.Context, id types.MSSQLUUID) (*entities.WarrantyClaim, error)
+	GetByVehicleID(ctx context.Context, vehicleID types.MSSQLUUID) ([]*entities.WarrantyClaim, error)
+	GetByOriginalItemIDs(ctx context.Context, itemIDs []types.MSSQLUUID) ([]*entities.WarrantyClaim, error)
+}
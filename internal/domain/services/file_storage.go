@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrFileNotFound = errors.New("file not found")
+
+// FileStorage keeps uploaded files under slash-separated keys such as
+// "maintenance/<item id>/<file>". Implementations must reject keys that
+// would escape their root.
+type FileStorage interface {
+	Save(ctx context.Context, key string, content io.Reader) error
+	// Open returns ErrFileNotFound when nothing is stored under key.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+)
+
+// NotificationService tells customers about changes to their tickets.
+// Delivery is best effort; callers should not fail an operation because a
+// notification could not be sent.
+type NotificationService interface {
+	NotifyQueueRescheduled(ctx context.Context, waitingList *entities.WaitingList, previousDate time.Time, previousQueueNumber int) error
+	NotifyCustomerCalled(ctx context.Context, waitingList *entities.WaitingList) error
+	NotifyNoShow(ctx context.Context, waitingList *entities.WaitingList) error
+	NotifyStandbyPromoted(ctx context.Context, waitingList *entities.WaitingList) error
+	// NotifyApprovalNeeded sends the customer a link to approve or reject
+	// items found during service.
+	NotifyApprovalNeeded(ctx context.Context, waitingList *entities.WaitingList, items []*entities.MaintenanceItem, approvalURL string, expiresAt time.Time) error
+	// NotifyEstimateSent tells the customer a new version of the ticket's
+	// estimate is waiting for their answer.
+	NotifyEstimateSent(ctx context.Context, waitingList *entities.WaitingList, estimate *entities.Estimate) error
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 type Config struct {
@@ -10,12 +11,15 @@ type Config struct {
 	Database DatabaseConfig
 	JWT      JWTConfig
 	Redis    RedisConfig
+	RabbitMQ RabbitMQConfig
+	Storage  StorageConfig
 }
 
 type ServerConfig struct {
-	Port string
-	Host string
-	Mode string
+	Port      string
+	Host      string
+	Mode      string
+	PublicURL string // base URL customers reach the API at, used in links sent to them
 }
 
 type DatabaseConfig struct {
@@ -38,14 +42,32 @@ type RedisConfig struct {
 	DB       int
 }
 
+// RabbitMQConfig is optional for the API; notifications are only logged when
+// Host is empty.
+type RabbitMQConfig struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Vhost    string
+}
+
+// StorageConfig selects where uploaded files are kept. Only the "local"
+// driver exists so far; it writes below LocalPath.
+type StorageConfig struct {
+	Driver    string
+	LocalPath string
+}
+
 func Load() *Config {
 	port := getEnv("PORT", getEnv("SERVER_PORT", "8080"))
 
 	return &Config{
 		Server: ServerConfig{
-			Port: port,
-			Host: getEnv("SERVER_HOST", "0.0.0.0"),
-			Mode: getEnv("GIN_MODE", "debug"),
+			Port:      port,
+			Host:      getEnv("SERVER_HOST", "0.0.0.0"),
+			Mode:      getEnv("GIN_MODE", "debug"),
+			PublicURL: strings.TrimRight(getEnv("PUBLIC_URL", "http://localhost:"+port), "/"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -64,6 +86,17 @@ func Load() *Config {
 			Password: getEnv("REDIS_PASSWORD", ""),
 			DB:       getEnvAsInt("REDIS_DB", 0),
 		},
+		RabbitMQ: RabbitMQConfig{
+			Host:     getEnv("RABBITMQ_HOST", ""),
+			Port:     getEnv("RABBITMQ_PORT", "5672"),
+			User:     getEnv("RABBITMQ_USER", "admin"),
+			Password: getEnv("RABBITMQ_PASS", "password"),
+			Vhost:    getEnv("RABBITMQ_VHOST", "/"),
+		},
+		Storage: StorageConfig{
+			Driver:    getEnv("STORAGE_DRIVER", "local"),
+			LocalPath: getEnv("STORAGE_LOCAL_PATH", "./uploads"),
+		},
 	}
 }
 
@@ -69,6 +69,30 @@ func autoMigrate(db *gorm.DB) error {
 		&entities.Part{},
 		&entities.Setting{},
 		&entities.MaintenanceItem{},
+		&entities.MaintenanceItemPart{},
+		&entities.ApprovalToken{},
+		&entities.MaintenanceItemApproval{},
+		&entities.MaintenanceItemAttachment{},
+		&entities.MaintenanceItemLog{},
+		&entities.InspectionTemplate{},
+		&entities.InspectionTemplatePoint{},
+		&entities.Inspection{},
+		&entities.InspectionResult{},
+		&entities.Estimate{},
+		&entities.EstimateLine{},
+		&entities.EstimateLinePart{},
+		&entities.WarrantyClaim{},
+		&entities.ServiceBay{},
+		&entities.ServiceType{},
+		&entities.ServicePackage{},
+		&entities.ServicePackageItem{},
+		&entities.ServicePackagePart{},
+		&entities.ShopClosure{},
+		&entities.QueueCounter{},
+		&entities.WaitingListTransition{},
+		&entities.StandbyEntry{},
+		&entities.KioskDevice{},
+		&entities.KioskActivity{},
 	)
 }
 func Close(db *gorm.DB) error {
@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/infrastructure/logger"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+)
+
+// ApprovalFollowUpJob chases discovered items that are still waiting for the
+// customer's approval: it sends reminders, escalates to the service advisor
+// and, when enabled, skips items holding up a finished ticket.
+type ApprovalFollowUpJob struct {
+	maintenanceItemUsecase *usecases.MaintenanceItemUsecase
+	settingUsecase         *usecases.SettingUsecase
+}
+
+func NewApprovalFollowUpJob(maintenanceItemUsecase *usecases.MaintenanceItemUsecase, settingUsecase *usecases.SettingUsecase) *ApprovalFollowUpJob {
+	return &ApprovalFollowUpJob{
+		maintenanceItemUsecase: maintenanceItemUsecase,
+		settingUsecase:         settingUsecase,
+	}
+}
+func (j *ApprovalFollowUpJob) Name() string {
+	return "ApprovalFollowUp"
+}
+func (j *ApprovalFollowUpJob) Schedule() string {
+	if j.settingUsecase != nil {
+		schedule := j.settingUsecase.GetApprovalJobSchedule(context.Background())
+		if schedule != "" {
+			return schedule
+		}
+	}
+	return "*/5 * * * *"
+}
+func (j *ApprovalFollowUpJob) Run(ctx context.Context) error {
+	if !j.settingUsecase.IsApprovalJobEnabled(ctx) {
+		return nil
+	}
+	taken, err := j.maintenanceItemUsecase.FollowUpApprovals(ctx, time.Now())
+	if n := taken[entities.MaintenanceItemLogReminderSent] + taken[entities.MaintenanceItemLogEscalated] + taken[entities.MaintenanceItemLogAutoSkipped]; n > 0 {
+		logger.Info(fmt.Sprintf("Approval follow-up: %d reminded, %d escalated, %d skipped",
+			taken[entities.MaintenanceItemLogReminderSent], taken[entities.MaintenanceItemLogEscalated], taken[entities.MaintenanceItemLogAutoSkipped]))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to follow up approvals: %w", err)
+	}
+	return nil
+}
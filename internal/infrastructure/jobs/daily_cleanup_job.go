@@ -12,14 +12,18 @@ import (
 )
 
 type DailyCleanupJob struct {
-	waitingListRepo repositories.WaitingListRepository
-	settingUsecase  *usecases.SettingUsecase
+	waitingListRepo    repositories.WaitingListRepository
+	waitingListUsecase *usecases.WaitingListUsecase
+	standbyRepo        repositories.StandbyRepository
+	settingUsecase     *usecases.SettingUsecase
 }
 
-func NewDailyCleanupJob(waitingListRepo repositories.WaitingListRepository, settingUsecase *usecases.SettingUsecase) *DailyCleanupJob {
+func NewDailyCleanupJob(waitingListRepo repositories.WaitingListRepository, waitingListUsecase *usecases.WaitingListUsecase, standbyRepo repositories.StandbyRepository, settingUsecase *usecases.SettingUsecase) *DailyCleanupJob {
 	return &DailyCleanupJob{
-		waitingListRepo: waitingListRepo,
-		settingUsecase:  settingUsecase,
+		waitingListRepo:    waitingListRepo,
+		waitingListUsecase: waitingListUsecase,
+		standbyRepo:        standbyRepo,
+		settingUsecase:     settingUsecase,
 	}
 }
 func (j *DailyCleanupJob) Name() string {
@@ -47,6 +51,10 @@ func (j *DailyCleanupJob) Run(ctx context.Context) error {
 		logger.Error(fmt.Sprintf("Failed to cleanup old entries: %v", err))
 		return err
 	}
+	if err := j.expireStandby(ctx, startOfDay); err != nil {
+		logger.Error(fmt.Sprintf("Failed to expire standby entries: %v", err))
+		return err
+	}
 	if err := j.enforceTicketLimit(ctx, today); err != nil {
 		logger.Error(fmt.Sprintf("Failed to enforce ticket limit: %v", err))
 		return err
@@ -86,9 +94,23 @@ func (j *DailyCleanupJob) cleanupOldEntries(ctx context.Context, today time.Time
 	logger.Info(fmt.Sprintf("Cleaned up %d old entries", totalCleaned))
 	return nil
 }
+func (j *DailyCleanupJob) expireStandby(ctx context.Context, today time.Time) error {
+	if j.standbyRepo == nil {
+		return nil
+	}
+	expired, err := j.standbyRepo.ExpireBefore(ctx, today)
+	if err != nil {
+		return err
+	}
+	logger.Info(fmt.Sprintf("Expired %d standby entries", expired))
+	return nil
+}
 func (j *DailyCleanupJob) enforceTicketLimit(ctx context.Context, today time.Time) error {
 	maxTickets := 10
 	if j.settingUsecase != nil {
+		if j.settingUsecase.GetLimitMode(ctx) == usecases.LimitModeCapacity {
+			return nil // capacity is enforced when tickets are taken
+		}
 		maxTickets = j.settingUsecase.GetMaxTicketsPerDay(ctx)
 	}
 	todayEntries, err := j.waitingListRepo.GetByServiceDate(ctx, today)
@@ -108,9 +130,10 @@ func (j *DailyCleanupJob) enforceTicketLimit(ctx context.Context, today time.Tim
 		logger.Info(fmt.Sprintf("Found %d waiting tickets, canceling %d excess tickets", waitingCount, excessCount))
 		for i := 0; i < excessCount && i < len(waitingEntries); i++ {
 			entry := waitingEntries[i]
-			entry.Status = entities.WaitingListStatusCanceled
-			entry.Notes = fmt.Sprintf("%s [Auto-canceled: Daily limit of %d tickets exceeded]", entry.Notes, maxTickets)
-			if err := j.waitingListRepo.Update(ctx, entry); err != nil {
+			// Cancel through the usecase so the change is recorded in the
+			// ticket history, shown on the queue board and frees standby.
+			reason := fmt.Sprintf("auto-canceled: daily limit of %d tickets exceeded", maxTickets)
+			if err := j.waitingListUsecase.CancelQueue(usecases.WithTransitionReason(ctx, reason), entry.ID); err != nil {
 				logger.Error(fmt.Sprintf("Failed to cancel excess entry %s: %v", entry.ID, err))
 			} else {
 				logger.Info(fmt.Sprintf("Canceled excess ticket #%d for customer %s", entry.QueueNumber, entry.CustomerID))
@@ -0,0 +1,73 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/infrastructure/logger"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+)
+
+// NoShowSweeperJob marks called customers who did not arrive within the grace
+// period as no-show and, when enabled, calls the next waiting customer.
+type NoShowSweeperJob struct {
+	waitingListRepo    repositories.WaitingListRepository
+	waitingListUsecase *usecases.WaitingListUsecase
+	settingUsecase     *usecases.SettingUsecase
+}
+
+func NewNoShowSweeperJob(waitingListRepo repositories.WaitingListRepository, waitingListUsecase *usecases.WaitingListUsecase, settingUsecase *usecases.SettingUsecase) *NoShowSweeperJob {
+	return &NoShowSweeperJob{
+		waitingListRepo:    waitingListRepo,
+		waitingListUsecase: waitingListUsecase,
+		settingUsecase:     settingUsecase,
+	}
+}
+func (j *NoShowSweeperJob) Name() string {
+	return "NoShowSweeper"
+}
+func (j *NoShowSweeperJob) Schedule() string {
+	if j.settingUsecase != nil {
+		schedule := j.settingUsecase.GetNoShowJobSchedule(context.Background())
+		if schedule != "" {
+			return schedule
+		}
+	}
+	return "*/5 * * * *"
+}
+func (j *NoShowSweeperJob) Run(ctx context.Context) error {
+	if !j.settingUsecase.IsNoShowJobEnabled(ctx) {
+		return nil
+	}
+	now := time.Now()
+	grace := time.Duration(j.settingUsecase.GetNoShowGraceMinutes(ctx)) * time.Minute
+	called, err := j.waitingListRepo.GetByStatus(ctx, entities.WaitingListStatusCalled, now)
+	if err != nil {
+		return fmt.Errorf("failed to get called tickets: %w", err)
+	}
+	autoCall := j.settingUsecase.IsAutoCallNextEnabled(ctx)
+	for _, ticket := range usecases.OverdueCalledTickets(called, grace, now) {
+		reason := fmt.Sprintf("not arrived within %s of being called", grace)
+		if err := j.waitingListUsecase.MarkNoShow(usecases.WithTransitionReason(ctx, reason), ticket.ID); err != nil {
+			logger.Error(fmt.Sprintf("Failed to mark ticket #%d as no-show: %v", ticket.QueueNumber, err))
+			continue
+		}
+		logger.Info(fmt.Sprintf("Marked ticket #%d as no-show after %s without arrival", ticket.QueueNumber, grace))
+		if !autoCall {
+			continue
+		}
+		reason = fmt.Sprintf("called automatically after no-show of #%d", ticket.QueueNumber)
+		next, err := j.waitingListUsecase.CallNextCustomer(usecases.WithTransitionReason(ctx, reason), now)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to call next customer: %v", err))
+			continue
+		}
+		if next != nil {
+			logger.Info(fmt.Sprintf("Called ticket #%d after no-show of #%d", next.QueueNumber, ticket.QueueNumber))
+		}
+	}
+	return nil
+}
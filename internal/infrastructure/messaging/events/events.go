@@ -9,11 +9,15 @@ import (
 const (
 	EventQueueNumberAssigned  = "event.queue.assigned"
 	EventQueuePositionChanged = "event.queue.position_changed"
+	EventQueueRescheduled     = "event.queue.rescheduled"
+	EventQueueNoShow          = "event.queue.no_show"
+	EventQueuePromoted        = "event.queue.promoted"
 	EventServiceCalled        = "event.service.called"
 	EventServiceStarted       = "event.service.started"
 	EventServiceCompleted     = "event.service.completed"
 	EventIssueDiscovered      = "event.approval.issue_discovered"
 	EventApprovalNeeded       = "event.approval.needed"
+	EventEstimateSent         = "event.approval.estimate_sent"
 	EventNotificationEmail    = "notification.email"
 	EventNotificationSMS      = "notification.sms"
 	EventNotificationPush     = "notification.push"
@@ -43,6 +47,36 @@ type QueueNumberAssignedEvent struct {
 	LicensePlate  string          `json:"license_plate"`
 }
 
+type QueueRescheduledEvent struct {
+	BaseEvent
+	WaitingListID       types.MSSQLUUID `json:"waiting_list_id"`
+	CustomerID          types.MSSQLUUID `json:"customer_id"`
+	CustomerEmail       string          `json:"customer_email"`
+	CustomerName        string          `json:"customer_name"`
+	CustomerPhone       string          `json:"customer_phone"`
+	PreviousQueueNumber int             `json:"previous_queue_number"`
+	PreviousServiceDate time.Time       `json:"previous_service_date"`
+	QueueNumber         int             `json:"queue_number"`
+	ServiceDate         time.Time       `json:"service_date"`
+	ServiceType         string          `json:"service_type"`
+	LicensePlate        string          `json:"license_plate"`
+}
+
+// QueueStatusEvent reports a ticket being called, marked no-show or issued
+// to a customer on the standby list.
+type QueueStatusEvent struct {
+	BaseEvent
+	WaitingListID types.MSSQLUUID `json:"waiting_list_id"`
+	CustomerID    types.MSSQLUUID `json:"customer_id"`
+	CustomerEmail string          `json:"customer_email"`
+	CustomerName  string          `json:"customer_name"`
+	CustomerPhone string          `json:"customer_phone"`
+	QueueNumber   int             `json:"queue_number"`
+	ServiceDate   time.Time       `json:"service_date"`
+	Status        string          `json:"status"`
+	LicensePlate  string          `json:"license_plate"`
+}
+
 type ServiceStartedEvent struct {
 	BaseEvent
 	WaitingListID types.MSSQLUUID `json:"waiting_list_id"`
@@ -86,6 +120,21 @@ type ApprovalNeededEvent struct {
 	ItemCount     int             `json:"item_count"`
 	TotalCost     float64         `json:"total_cost"`
 	ApprovalURL   string          `json:"approval_url"`
+	ExpiresAt     time.Time       `json:"expires_at"`
+}
+
+type EstimateSentEvent struct {
+	BaseEvent
+	WaitingListID types.MSSQLUUID `json:"waiting_list_id"`
+	EstimateID    types.MSSQLUUID `json:"estimate_id"`
+	CustomerID    types.MSSQLUUID `json:"customer_id"`
+	CustomerEmail string          `json:"customer_email"`
+	CustomerName  string          `json:"customer_name"`
+	CustomerPhone string          `json:"customer_phone"`
+	Version       int             `json:"version"`
+	LineCount     int             `json:"line_count"`
+	Total         float64         `json:"total"`
+	LicensePlate  string          `json:"license_plate"`
 }
 
 type ServiceCompletedEvent struct {
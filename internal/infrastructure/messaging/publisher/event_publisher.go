@@ -56,6 +56,168 @@ func (p *EventPublisher) PublishQueueNumberAssigned(ctx context.Context, event *
 	return p.PublishEmailNotification(ctx, emailEvent)
 }
 
+func (p *EventPublisher) PublishQueueRescheduled(ctx context.Context, event *events.QueueRescheduledEvent) error {
+	event.BaseEvent = events.BaseEvent{
+		ID: uuid.New().String(), Type: events.EventQueueRescheduled,
+		Timestamp: time.Now(), Source: "api",
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := p.conn.PublishWithRetry(ctx, "car-maintenance", "event.queue.rescheduled", body, 3); err != nil {
+		logger.Error("Failed to publish queue rescheduled event", err)
+		return err
+	}
+
+	emailEvent := &events.EmailNotificationEvent{
+		BaseEvent: events.BaseEvent{
+			ID: uuid.New().String(), Type: events.EventNotificationEmail,
+			Timestamp: time.Now(), Source: "api",
+		},
+		To:       event.CustomerEmail,
+		Subject:  fmt.Sprintf("Booking Rescheduled - Queue #%d", event.QueueNumber),
+		Template: "queue_rescheduled",
+		TemplateData: map[string]interface{}{
+			"customer_name": event.CustomerName, "queue_number": event.QueueNumber,
+			"service_date":          event.ServiceDate.Format("Monday, January 2, 2006"),
+			"previous_queue_number": event.PreviousQueueNumber,
+			"previous_service_date": event.PreviousServiceDate.Format("Monday, January 2, 2006"),
+			"service_type":          event.ServiceType, "license_plate": event.LicensePlate,
+		},
+		Priority: "high",
+	}
+
+	return p.PublishEmailNotification(ctx, emailEvent)
+}
+
+func (p *EventPublisher) PublishCustomerCalled(ctx context.Context, event *events.QueueStatusEvent) error {
+	event.BaseEvent = events.BaseEvent{
+		ID: uuid.New().String(), Type: events.EventServiceCalled,
+		Timestamp: time.Now(), Source: "api",
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := p.conn.PublishWithRetry(ctx, "car-maintenance", "event.service.called", body, 3); err != nil {
+		logger.Error("Failed to publish customer called event", err)
+		return err
+	}
+
+	if event.CustomerPhone != "" {
+		smsEvent := &events.SMSNotificationEvent{
+			To:       event.CustomerPhone,
+			Message:  fmt.Sprintf("Queue #%d: it's your turn. Please bring your vehicle (%s) to the service desk.", event.QueueNumber, event.LicensePlate),
+			Priority: "high",
+		}
+		if err := p.PublishSMSNotification(ctx, smsEvent); err != nil {
+			logger.Error("Failed to publish customer called SMS", err)
+		}
+	}
+
+	emailEvent := &events.EmailNotificationEvent{
+		BaseEvent: events.BaseEvent{
+			ID: uuid.New().String(), Type: events.EventNotificationEmail,
+			Timestamp: time.Now(), Source: "api",
+		},
+		To:       event.CustomerEmail,
+		Subject:  fmt.Sprintf("It's Your Turn - Queue #%d", event.QueueNumber),
+		Template: "customer_called",
+		TemplateData: map[string]interface{}{
+			"customer_name": event.CustomerName, "queue_number": event.QueueNumber,
+			"license_plate": event.LicensePlate,
+		},
+		Priority: "high",
+	}
+
+	return p.PublishEmailNotification(ctx, emailEvent)
+}
+
+func (p *EventPublisher) PublishNoShow(ctx context.Context, event *events.QueueStatusEvent) error {
+	event.BaseEvent = events.BaseEvent{
+		ID: uuid.New().String(), Type: events.EventQueueNoShow,
+		Timestamp: time.Now(), Source: "api",
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := p.conn.PublishWithRetry(ctx, "car-maintenance", "event.queue.no_show", body, 3); err != nil {
+		logger.Error("Failed to publish no-show event", err)
+		return err
+	}
+
+	emailEvent := &events.EmailNotificationEvent{
+		BaseEvent: events.BaseEvent{
+			ID: uuid.New().String(), Type: events.EventNotificationEmail,
+			Timestamp: time.Now(), Source: "api",
+		},
+		To:       event.CustomerEmail,
+		Subject:  fmt.Sprintf("Missed Turn - Queue #%d", event.QueueNumber),
+		Template: "queue_no_show",
+		TemplateData: map[string]interface{}{
+			"customer_name": event.CustomerName, "queue_number": event.QueueNumber,
+			"service_date": event.ServiceDate.Format("Monday, January 2, 2006"),
+		},
+		Priority: "normal",
+	}
+
+	return p.PublishEmailNotification(ctx, emailEvent)
+}
+
+func (p *EventPublisher) PublishStandbyPromoted(ctx context.Context, event *events.QueueStatusEvent) error {
+	event.BaseEvent = events.BaseEvent{
+		ID: uuid.New().String(), Type: events.EventQueuePromoted,
+		Timestamp: time.Now(), Source: "api",
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := p.conn.PublishWithRetry(ctx, "car-maintenance", "event.queue.promoted", body, 3); err != nil {
+		logger.Error("Failed to publish standby promoted event", err)
+		return err
+	}
+
+	if event.CustomerPhone != "" {
+		smsEvent := &events.SMSNotificationEvent{
+			To:       event.CustomerPhone,
+			Message:  fmt.Sprintf("A place opened up on %s: you now have queue #%d.", event.ServiceDate.Format("2006-01-02"), event.QueueNumber),
+			Priority: "high",
+		}
+		if err := p.PublishSMSNotification(ctx, smsEvent); err != nil {
+			logger.Error("Failed to publish standby promoted SMS", err)
+		}
+	}
+
+	emailEvent := &events.EmailNotificationEvent{
+		BaseEvent: events.BaseEvent{
+			ID: uuid.New().String(), Type: events.EventNotificationEmail,
+			Timestamp: time.Now(), Source: "api",
+		},
+		To:       event.CustomerEmail,
+		Subject:  fmt.Sprintf("You're In - Queue #%d", event.QueueNumber),
+		Template: "standby_promoted",
+		TemplateData: map[string]interface{}{
+			"customer_name": event.CustomerName, "queue_number": event.QueueNumber,
+			"service_date":  event.ServiceDate.Format("Monday, January 2, 2006"),
+			"license_plate": event.LicensePlate,
+		},
+		Priority: "high",
+	}
+
+	return p.PublishEmailNotification(ctx, emailEvent)
+}
+
 func (p *EventPublisher) PublishServiceStarted(ctx context.Context, event *events.ServiceStartedEvent) error {
 	event.BaseEvent = events.BaseEvent{
 		ID: uuid.New().String(), Type: events.EventServiceStarted,
@@ -130,6 +292,87 @@ func (p *EventPublisher) PublishIssueDiscovered(ctx context.Context, event *even
 	return nil
 }
 
+func (p *EventPublisher) PublishApprovalNeeded(ctx context.Context, event *events.ApprovalNeededEvent) error {
+	event.BaseEvent = events.BaseEvent{
+		ID: uuid.New().String(), Type: events.EventApprovalNeeded,
+		Timestamp: time.Now(), Source: "api",
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := p.conn.PublishWithRetry(ctx, "car-maintenance", "event.approval.needed", body, 3); err != nil {
+		logger.Error("Failed to publish approval needed event", err)
+		return err
+	}
+
+	if event.CustomerPhone != "" {
+		smsEvent := &events.SMSNotificationEvent{
+			To:       event.CustomerPhone,
+			Message:  fmt.Sprintf("Your mechanic found %d item(s) that need your approval: %s", event.ItemCount, event.ApprovalURL),
+			Priority: "high",
+		}
+		if err := p.PublishSMSNotification(ctx, smsEvent); err != nil {
+			logger.Error("Failed to publish approval needed SMS", err)
+		}
+	}
+
+	emailEvent := &events.EmailNotificationEvent{
+		BaseEvent: events.BaseEvent{
+			ID: uuid.New().String(), Type: events.EventNotificationEmail,
+			Timestamp: time.Now(), Source: "api",
+		},
+		To:       event.CustomerEmail,
+		Subject:  "Approval Needed for Additional Work",
+		Template: "approval_needed",
+		TemplateData: map[string]interface{}{
+			"customer_name": event.CustomerName, "item_count": event.ItemCount,
+			"total_cost": event.TotalCost, "approval_url": event.ApprovalURL,
+			"expires_at": event.ExpiresAt.Format("Monday, January 2, 2006 15:04"),
+		},
+		Priority: "high",
+	}
+
+	return p.PublishEmailNotification(ctx, emailEvent)
+}
+
+func (p *EventPublisher) PublishEstimateSent(ctx context.Context, event *events.EstimateSentEvent) error {
+	event.BaseEvent = events.BaseEvent{
+		ID: uuid.New().String(), Type: events.EventEstimateSent,
+		Timestamp: time.Now(), Source: "api",
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := p.conn.PublishWithRetry(ctx, "car-maintenance", "event.approval.estimate_sent", body, 3); err != nil {
+		logger.Error("Failed to publish estimate sent event", err)
+		return err
+	}
+
+	emailEvent := &events.EmailNotificationEvent{
+		BaseEvent: events.BaseEvent{
+			ID: uuid.New().String(), Type: events.EventNotificationEmail,
+			Timestamp: time.Now(), Source: "api",
+		},
+		To:       event.CustomerEmail,
+		Subject:  fmt.Sprintf("Repair Estimate v%d for %s", event.Version, event.LicensePlate),
+		Template: "estimate_sent",
+		TemplateData: map[string]interface{}{
+			"customer_name": event.CustomerName, "version": event.Version,
+			"line_count": event.LineCount, "total": event.Total,
+			"license_plate": event.LicensePlate,
+		},
+		Priority: "high",
+	}
+
+	return p.PublishEmailNotification(ctx, emailEvent)
+}
+
 func (p *EventPublisher) PublishServiceCompleted(ctx context.Context, event *events.ServiceCompletedEvent) error {
 	event.BaseEvent = events.BaseEvent{
 		ID: uuid.New().String(), Type: events.EventServiceCompleted,
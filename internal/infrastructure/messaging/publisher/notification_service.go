@@ -0,0 +1,125 @@
+package publisher
+
+import (
+	"context"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/services"
+	"github.com/kuahbanyak/go-crud/internal/infrastructure/logger"
+	"github.com/kuahbanyak/go-crud/internal/infrastructure/messaging/events"
+)
+
+type notificationService struct {
+	publisher *EventPublisher
+}
+
+// NewNotificationService publishes customer notifications to RabbitMQ for the
+// notification worker to deliver.
+func NewNotificationService(publisher *EventPublisher) services.NotificationService {
+	return &notificationService{publisher: publisher}
+}
+func (s *notificationService) NotifyQueueRescheduled(ctx context.Context, waitingList *entities.WaitingList, previousDate time.Time, previousQueueNumber int) error {
+	return s.publisher.PublishQueueRescheduled(ctx, &events.QueueRescheduledEvent{
+		WaitingListID:       waitingList.ID,
+		CustomerID:          waitingList.CustomerID,
+		CustomerEmail:       waitingList.Customer.Email,
+		CustomerName:        waitingList.Customer.Name,
+		CustomerPhone:       waitingList.Customer.Phone,
+		PreviousQueueNumber: previousQueueNumber,
+		PreviousServiceDate: previousDate,
+		QueueNumber:         waitingList.QueueNumber,
+		ServiceDate:         waitingList.ServiceDate,
+		ServiceType:         waitingList.ServiceType,
+		LicensePlate:        waitingList.Vehicle.LicensePlate,
+	})
+}
+
+func (s *notificationService) NotifyCustomerCalled(ctx context.Context, waitingList *entities.WaitingList) error {
+	return s.publisher.PublishCustomerCalled(ctx, queueStatusEvent(waitingList))
+}
+func (s *notificationService) NotifyNoShow(ctx context.Context, waitingList *entities.WaitingList) error {
+	return s.publisher.PublishNoShow(ctx, queueStatusEvent(waitingList))
+}
+func (s *notificationService) NotifyStandbyPromoted(ctx context.Context, waitingList *entities.WaitingList) error {
+	return s.publisher.PublishStandbyPromoted(ctx, queueStatusEvent(waitingList))
+}
+func (s *notificationService) NotifyApprovalNeeded(ctx context.Context, waitingList *entities.WaitingList, items []*entities.MaintenanceItem, approvalURL string, expiresAt time.Time) error {
+	total := 0.0
+	for _, item := range items {
+		total += item.EstimatedCost
+	}
+	return s.publisher.PublishApprovalNeeded(ctx, &events.ApprovalNeededEvent{
+		WaitingListID: waitingList.ID,
+		CustomerID:    waitingList.CustomerID,
+		CustomerEmail: waitingList.Customer.Email,
+		CustomerName:  waitingList.Customer.Name,
+		CustomerPhone: waitingList.Customer.Phone,
+		ItemCount:     len(items),
+		TotalCost:     total,
+		ApprovalURL:   approvalURL,
+		ExpiresAt:     expiresAt,
+	})
+}
+func (s *notificationService) NotifyEstimateSent(ctx context.Context, waitingList *entities.WaitingList, estimate *entities.Estimate) error {
+	return s.publisher.PublishEstimateSent(ctx, &events.EstimateSentEvent{
+		WaitingListID: waitingList.ID,
+		EstimateID:    estimate.ID,
+		CustomerID:    waitingList.CustomerID,
+		CustomerEmail: waitingList.Customer.Email,
+		CustomerName:  waitingList.Customer.Name,
+		CustomerPhone: waitingList.Customer.Phone,
+		Version:       estimate.Version,
+		LineCount:     len(estimate.Lines),
+		Total:         estimate.Total,
+		LicensePlate:  waitingList.Vehicle.LicensePlate,
+	})
+}
+func queueStatusEvent(waitingList *entities.WaitingList) *events.QueueStatusEvent {
+	return &events.QueueStatusEvent{
+		WaitingListID: waitingList.ID,
+		CustomerID:    waitingList.CustomerID,
+		CustomerEmail: waitingList.Customer.Email,
+		CustomerName:  waitingList.Customer.Name,
+		CustomerPhone: waitingList.Customer.Phone,
+		QueueNumber:   waitingList.QueueNumber,
+		ServiceDate:   waitingList.ServiceDate,
+		Status:        string(waitingList.Status),
+		LicensePlate:  waitingList.Vehicle.LicensePlate,
+	}
+}
+
+type logNotificationService struct{}
+
+// NewLogNotificationService only logs notifications. It is used when the API
+// runs without a message broker.
+func NewLogNotificationService() services.NotificationService {
+	return &logNotificationService{}
+}
+func (s *logNotificationService) NotifyQueueRescheduled(_ context.Context, waitingList *entities.WaitingList, previousDate time.Time, previousQueueNumber int) error {
+	logger.Info("Queue rescheduled",
+		"ticket:", waitingList.ID.String(),
+		"from:", previousDate.Format("2006-01-02"), "#", previousQueueNumber,
+		"to:", waitingList.ServiceDate.Format("2006-01-02"), "#", waitingList.QueueNumber)
+	return nil
+}
+func (s *logNotificationService) NotifyCustomerCalled(_ context.Context, waitingList *entities.WaitingList) error {
+	logger.Info("Customer called", "ticket:", waitingList.ID.String(), "queue:", waitingList.QueueNumber)
+	return nil
+}
+func (s *logNotificationService) NotifyNoShow(_ context.Context, waitingList *entities.WaitingList) error {
+	logger.Info("Ticket marked no-show", "ticket:", waitingList.ID.String(), "queue:", waitingList.QueueNumber)
+	return nil
+}
+func (s *logNotificationService) NotifyStandbyPromoted(_ context.Context, waitingList *entities.WaitingList) error {
+	logger.Info("Standby entry promoted", "ticket:", waitingList.ID.String(), "queue:", waitingList.QueueNumber)
+	return nil
+}
+func (s *logNotificationService) NotifyApprovalNeeded(_ context.Context, waitingList *entities.WaitingList, items []*entities.MaintenanceItem, approvalURL string, _ time.Time) error {
+	logger.Info("Approval needed", "ticket:", waitingList.ID.String(), "items:", len(items), "link:", approvalURL)
+	return nil
+}
+func (s *logNotificationService) NotifyEstimateSent(_ context.Context, waitingList *entities.WaitingList, estimate *entities.Estimate) error {
+	logger.Info("Estimate sent", "ticket:", waitingList.ID.String(), "version:", estimate.Version, "total:", estimate.Total)
+	return nil
+}
@@ -27,6 +27,14 @@ type HTTPServer struct {
 	invoiceHandler         *handlers.InvoiceHandler
 	analyticsHandler       *handlers.AnalyticsHandler
 	roleHandler            *handlers.RoleHandler
+	serviceBayHandler      *handlers.ServiceBayHandler
+	shopClosureHandler     *handlers.ShopClosureHandler
+	kioskHandler           *handlers.KioskHandler
+	serviceTypeHandler     *handlers.ServiceTypeHandler
+	servicePackageHandler  *handlers.ServicePackageHandler
+	attachmentHandler      *handlers.MaintenanceAttachmentHandler
+	inspectionHandler      *handlers.InspectionHandler
+	warrantyHandler        *handlers.WarrantyHandler
 }
 
 func NewHTTPServer(
@@ -42,6 +50,14 @@ func NewHTTPServer(
 	invoiceHandler *handlers.InvoiceHandler,
 	analyticsHandler *handlers.AnalyticsHandler,
 	roleHandler *handlers.RoleHandler,
+	serviceBayHandler *handlers.ServiceBayHandler,
+	shopClosureHandler *handlers.ShopClosureHandler,
+	kioskHandler *handlers.KioskHandler,
+	serviceTypeHandler *handlers.ServiceTypeHandler,
+	servicePackageHandler *handlers.ServicePackageHandler,
+	attachmentHandler *handlers.MaintenanceAttachmentHandler,
+	inspectionHandler *handlers.InspectionHandler,
+	warrantyHandler *handlers.WarrantyHandler,
 ) *HTTPServer {
 	router := mux.NewRouter()
 
@@ -81,6 +97,14 @@ func NewHTTPServer(
 		invoiceHandler:         invoiceHandler,
 		analyticsHandler:       analyticsHandler,
 		roleHandler:            roleHandler,
+		serviceBayHandler:      serviceBayHandler,
+		shopClosureHandler:     shopClosureHandler,
+		kioskHandler:           kioskHandler,
+		serviceTypeHandler:     serviceTypeHandler,
+		servicePackageHandler:  servicePackageHandler,
+		attachmentHandler:      attachmentHandler,
+		inspectionHandler:      inspectionHandler,
+		warrantyHandler:        warrantyHandler,
 	}
 
 	httpServer.setupRoutes()
@@ -135,25 +159,115 @@ func (s *HTTPServer) setupRoutes() {
 	adminProductRoutes.HandleFunc("/{id}/stock", s.productHandler.UpdateProductStock).Methods("PATCH")
 	adminProductRoutes.HandleFunc("/{id}", s.productHandler.DeleteProduct).Methods("DELETE")
 
+	// Live queue board stream (Public - lobby display, anonymized payload)
+	api.HandleFunc("/waiting-list/today/stream", s.waitingListHandler.StreamTodayQueue).Methods("GET")
+
 	// Waiting List Routes (Customer)
 	waitingListRoutes := api.PathPrefix("/waiting-list").Subrouter()
 	waitingListRoutes.Use(middleware.Auth)
 	waitingListRoutes.HandleFunc("/take", s.waitingListHandler.TakeQueueNumber).Methods("POST")
+	waitingListRoutes.HandleFunc("/book", s.waitingListHandler.BookSlot).Methods("POST")
+	waitingListRoutes.HandleFunc("/standby", s.waitingListHandler.JoinStandby).Methods("POST")
 	waitingListRoutes.HandleFunc("/my-queue", s.waitingListHandler.GetMyQueue).Methods("GET")
 	waitingListRoutes.HandleFunc("/today", s.waitingListHandler.GetTodayQueue).Methods("GET")
 	waitingListRoutes.HandleFunc("/date", s.waitingListHandler.GetQueueByDate).Methods("GET")
 	waitingListRoutes.HandleFunc("/number/{number}", s.waitingListHandler.GetQueueByNumber).Methods("GET")
 	waitingListRoutes.HandleFunc("/availability", s.waitingListHandler.CheckAvailability).Methods("GET")
+	waitingListRoutes.HandleFunc("/availability/slots", s.waitingListHandler.GetAvailableSlots).Methods("GET")
 	waitingListRoutes.HandleFunc("/{id}/cancel", s.waitingListHandler.CancelQueue).Methods("PUT")
+	waitingListRoutes.HandleFunc("/{id}/reschedule", s.waitingListHandler.RescheduleQueue).Methods("PUT")
 	waitingListRoutes.HandleFunc("/{id}/progress", s.waitingListHandler.GetServiceProgress).Methods("GET")
+	waitingListRoutes.HandleFunc("/{id}/history", s.waitingListHandler.GetHistory).Methods("GET")
+	waitingListRoutes.HandleFunc("/{id}/qr", s.waitingListHandler.GetQRCode).Methods("GET")
 
 	// Waiting List Routes (Admin only - manage queue operations)
 	adminWaitingListRoutes := adminRoutes.PathPrefix("/waiting-list").Subrouter()
+	adminWaitingListRoutes.HandleFunc("/reschedule", s.waitingListHandler.BulkReschedule).Methods("POST")
+	adminWaitingListRoutes.HandleFunc("/check-in", s.waitingListHandler.CheckIn).Methods("POST")
 	adminWaitingListRoutes.HandleFunc("/{id}/call", s.waitingListHandler.CallCustomer).Methods("PUT")
 	adminWaitingListRoutes.HandleFunc("/{id}/start", s.waitingListHandler.StartService).Methods("PUT")
+	adminWaitingListRoutes.HandleFunc("/{id}/assign-mechanic", s.waitingListHandler.AssignMechanic).Methods("PUT")
 	adminWaitingListRoutes.HandleFunc("/{id}/complete", s.waitingListHandler.CompleteService).Methods("PUT")
 	adminWaitingListRoutes.HandleFunc("/{id}/no-show", s.waitingListHandler.MarkNoShow).Methods("PUT")
 
+	// Kiosk Routes (kiosk device token, tighter per-device rate limit)
+	kioskRoutes := api.PathPrefix("/kiosk").Subrouter()
+	kioskRoutes.Use(middleware.KioskAuth)
+	kioskRoutes.Use(middleware.KioskRateLimit(middleware.NewRateLimiter(constants.KioskRequestsPerMinute, time.Minute)))
+	kioskRoutes.HandleFunc("/vehicles", s.kioskHandler.LookupVehicle).Methods("GET")
+	kioskRoutes.HandleFunc("/queue", s.kioskHandler.TakeQueue).Methods("POST")
+	kioskRoutes.HandleFunc("/guests", s.kioskHandler.CreateGuest).Methods("POST")
+
+	// Kiosk Device Routes (Admin only)
+	adminKioskRoutes := adminRoutes.PathPrefix("/kiosks").Subrouter()
+	adminKioskRoutes.HandleFunc("", s.kioskHandler.RegisterDevice).Methods("POST")
+	adminKioskRoutes.HandleFunc("", s.kioskHandler.GetDevices).Methods("GET")
+	adminKioskRoutes.HandleFunc("/{id}/revoke", s.kioskHandler.RevokeDevice).Methods("PUT")
+	adminKioskRoutes.HandleFunc("/{id}/activity", s.kioskHandler.GetActivity).Methods("GET")
+
+	// Mechanic Routes (Mechanic/Admin)
+	mechanicRoutes := api.PathPrefix("/mechanic").Subrouter()
+	mechanicRoutes.Use(middleware.Auth)
+	mechanicRoutes.Use(middleware.RequireRole(constants.RoleMechanic, constants.RoleAdmin))
+	mechanicRoutes.HandleFunc("/jobs/today", s.waitingListHandler.GetMyJobsToday).Methods("GET")
+	mechanicRoutes.HandleFunc("/maintenance/items/{id}/attachments", s.attachmentHandler.Upload).Methods("POST")
+	mechanicRoutes.HandleFunc("/maintenance/attachments/{id}", s.attachmentHandler.Delete).Methods("DELETE")
+	mechanicRoutes.HandleFunc("/inspection-templates", s.inspectionHandler.GetActiveTemplates).Methods("GET")
+	mechanicRoutes.HandleFunc("/inspections", s.inspectionHandler.StartInspection).Methods("POST")
+	mechanicRoutes.HandleFunc("/inspections/{id}", s.inspectionHandler.GetInspection).Methods("GET")
+	mechanicRoutes.HandleFunc("/inspections/{id}/results", s.inspectionHandler.RecordResults).Methods("PUT")
+	mechanicRoutes.HandleFunc("/inspections/{id}/results/{result_id}/photo", s.inspectionHandler.UploadPhoto).Methods("POST")
+	mechanicRoutes.HandleFunc("/inspections/{id}/complete", s.inspectionHandler.CompleteInspection).Methods("PUT")
+	mechanicRoutes.HandleFunc("/inspections/{id}/findings", s.inspectionHandler.CreateFindings).Methods("POST")
+	mechanicRoutes.HandleFunc("/waiting-list/{waiting_list_id}/inspection", s.inspectionHandler.GetInspectionByWaitingList).Methods("GET")
+
+	// Service Bay Routes (Admin only)
+	serviceBayRoutes := adminRoutes.PathPrefix("/service-bays").Subrouter()
+	serviceBayRoutes.HandleFunc("", s.serviceBayHandler.CreateServiceBay).Methods("POST")
+	serviceBayRoutes.HandleFunc("", s.serviceBayHandler.GetAllServiceBays).Methods("GET")
+	serviceBayRoutes.HandleFunc("/status", s.serviceBayHandler.GetBayStatus).Methods("GET")
+	serviceBayRoutes.HandleFunc("/{id}", s.serviceBayHandler.GetServiceBay).Methods("GET")
+	serviceBayRoutes.HandleFunc("/{id}", s.serviceBayHandler.UpdateServiceBay).Methods("PUT")
+	serviceBayRoutes.HandleFunc("/{id}", s.serviceBayHandler.DeleteServiceBay).Methods("DELETE")
+
+	// Service Type Routes (Customer - catalog to pick from when taking a ticket)
+	serviceTypeRoutes := api.PathPrefix("/service-types").Subrouter()
+	serviceTypeRoutes.Use(middleware.Auth)
+	serviceTypeRoutes.HandleFunc("", s.serviceTypeHandler.GetActiveServiceTypes).Methods("GET")
+
+	// Service Type Routes (Admin only)
+	adminServiceTypeRoutes := adminRoutes.PathPrefix("/service-types").Subrouter()
+	adminServiceTypeRoutes.HandleFunc("", s.serviceTypeHandler.CreateServiceType).Methods("POST")
+	adminServiceTypeRoutes.HandleFunc("", s.serviceTypeHandler.GetAllServiceTypes).Methods("GET")
+	adminServiceTypeRoutes.HandleFunc("/{id}", s.serviceTypeHandler.GetServiceType).Methods("GET")
+	adminServiceTypeRoutes.HandleFunc("/{id}", s.serviceTypeHandler.UpdateServiceType).Methods("PUT")
+	adminServiceTypeRoutes.HandleFunc("/{id}", s.serviceTypeHandler.DeleteServiceType).Methods("DELETE")
+
+	// Service Package Routes (Customer - packages to pick when adding maintenance items)
+	servicePackageRoutes := api.PathPrefix("/service-packages").Subrouter()
+	servicePackageRoutes.Use(middleware.Auth)
+	servicePackageRoutes.HandleFunc("", s.servicePackageHandler.GetActivePackages).Methods("GET")
+
+	// Service Package Routes (Admin only)
+	adminServicePackageRoutes := adminRoutes.PathPrefix("/service-packages").Subrouter()
+	adminServicePackageRoutes.HandleFunc("", s.servicePackageHandler.CreatePackage).Methods("POST")
+	adminServicePackageRoutes.HandleFunc("", s.servicePackageHandler.GetAllPackages).Methods("GET")
+	adminServicePackageRoutes.HandleFunc("/{id}", s.servicePackageHandler.GetPackage).Methods("GET")
+	adminServicePackageRoutes.HandleFunc("/{id}", s.servicePackageHandler.UpdatePackage).Methods("PUT")
+	adminServicePackageRoutes.HandleFunc("/{id}", s.servicePackageHandler.DeletePackage).Methods("DELETE")
+
+	// Inspection Template Routes (Admin only)
+	adminInspectionTemplateRoutes := adminRoutes.PathPrefix("/inspection-templates").Subrouter()
+	adminInspectionTemplateRoutes.HandleFunc("", s.inspectionHandler.CreateTemplate).Methods("POST")
+	adminInspectionTemplateRoutes.HandleFunc("", s.inspectionHandler.GetAllTemplates).Methods("GET")
+	adminInspectionTemplateRoutes.HandleFunc("/{id}", s.inspectionHandler.GetTemplate).Methods("GET")
+	adminInspectionTemplateRoutes.HandleFunc("/{id}", s.inspectionHandler.UpdateTemplate).Methods("PUT")
+	adminInspectionTemplateRoutes.HandleFunc("/{id}", s.inspectionHandler.DeleteTemplate).Methods("DELETE")
+
+	// Approval links (Public - the signed token in the link stands in for login)
+	api.HandleFunc("/maintenance/approvals/{token}", s.maintenanceItemHandler.GetPendingApprovalByToken).Methods("GET")
+	api.HandleFunc("/maintenance/approvals/{token}", s.maintenanceItemHandler.DecideByToken).Methods("POST")
+
 	// Maintenance Items Routes (Customer)
 	maintenanceRoutes := api.PathPrefix("/maintenance").Subrouter()
 	maintenanceRoutes.Use(middleware.Auth)
@@ -161,13 +275,31 @@ func (s *HTTPServer) setupRoutes() {
 	maintenanceRoutes.HandleFunc("/waiting-list/{waiting_list_id}/items", s.maintenanceItemHandler.GetItemsByWaitingList).Methods("GET")
 	maintenanceRoutes.HandleFunc("/waiting-list/{waiting_list_id}/inspection-summary", s.maintenanceItemHandler.GetInspectionSummary).Methods("GET")
 	maintenanceRoutes.HandleFunc("/items/approve", s.maintenanceItemHandler.ApproveItems).Methods("POST")
+	maintenanceRoutes.HandleFunc("/items/{id}", s.maintenanceItemHandler.RemoveItem).Methods("DELETE")
+	maintenanceRoutes.HandleFunc("/items/{id}/attachments", s.attachmentHandler.List).Methods("GET")
+	maintenanceRoutes.HandleFunc("/attachments/{id}", s.attachmentHandler.Download).Methods("GET")
+	maintenanceRoutes.HandleFunc("/attachments/{id}/thumbnail", s.attachmentHandler.Thumbnail).Methods("GET")
+	maintenanceRoutes.HandleFunc("/waiting-list/{waiting_list_id}/inspection-report", s.inspectionHandler.GetReport).Methods("GET")
+	maintenanceRoutes.HandleFunc("/inspections/{id}/results/{result_id}/photo", s.inspectionHandler.GetPhoto).Methods("GET")
+	maintenanceRoutes.HandleFunc("/waiting-list/{waiting_list_id}/estimates", s.maintenanceItemHandler.GetEstimates).Methods("GET")
+	maintenanceRoutes.HandleFunc("/waiting-list/{waiting_list_id}/estimate", s.maintenanceItemHandler.GetLatestEstimate).Methods("GET")
+	maintenanceRoutes.HandleFunc("/estimates/{id}/decision", s.maintenanceItemHandler.DecideEstimate).Methods("POST")
 
 	// Maintenance Items Routes (Admin/Mechanic)
 	adminMaintenanceRoutes := adminRoutes.PathPrefix("/maintenance").Subrouter()
 	adminMaintenanceRoutes.HandleFunc("/items/discovered", s.maintenanceItemHandler.AddDiscoveredItem).Methods("POST")
+	adminMaintenanceRoutes.HandleFunc("/waiting-list/{waiting_list_id}/approval-link", s.maintenanceItemHandler.SendApprovalLink).Methods("POST")
+	adminMaintenanceRoutes.HandleFunc("/waiting-list/{waiting_list_id}/phone-approval", s.maintenanceItemHandler.RecordPhoneDecisions).Methods("POST")
+	adminMaintenanceRoutes.HandleFunc("/waiting-list/{waiting_list_id}/estimates", s.maintenanceItemHandler.IssueEstimate).Methods("POST")
+	adminMaintenanceRoutes.HandleFunc("/approval-calls", s.maintenanceItemHandler.GetApprovalCalls).Methods("GET")
 	adminMaintenanceRoutes.HandleFunc("/items/{id}", s.maintenanceItemHandler.UpdateItem).Methods("PUT")
 	adminMaintenanceRoutes.HandleFunc("/items/{id}/complete", s.maintenanceItemHandler.CompleteItem).Methods("PUT")
+	adminMaintenanceRoutes.HandleFunc("/items/{id}/cancel", s.maintenanceItemHandler.CancelItem).Methods("PUT")
+	adminMaintenanceRoutes.HandleFunc("/items/{id}/logs", s.maintenanceItemHandler.GetItemLogs).Methods("GET")
+	adminMaintenanceRoutes.HandleFunc("/items/{id}/parts", s.maintenanceItemHandler.AddPart).Methods("POST")
+	adminMaintenanceRoutes.HandleFunc("/items/{id}/parts/{part_id}", s.maintenanceItemHandler.RemovePart).Methods("DELETE")
 	adminMaintenanceRoutes.HandleFunc("/items/{id}", s.maintenanceItemHandler.DeleteItem).Methods("DELETE")
+	adminMaintenanceRoutes.HandleFunc("/warranty-claims", s.warrantyHandler.OpenClaim).Methods("POST")
 
 	// Vehicle Routes (User can manage their own vehicles)
 	vehicleRoutes := api.PathPrefix("/vehicles").Subrouter()
@@ -177,15 +309,18 @@ func (s *HTTPServer) setupRoutes() {
 	vehicleRoutes.HandleFunc("/{id}", s.vehicleHandler.GetVehicle).Methods("GET")
 	vehicleRoutes.HandleFunc("/{id}", s.vehicleHandler.UpdateVehicle).Methods("PUT")
 	vehicleRoutes.HandleFunc("/{id}", s.vehicleHandler.DeleteVehicle).Methods("DELETE")
+	vehicleRoutes.HandleFunc("/{id}/warranties", s.warrantyHandler.GetActiveWarranties).Methods("GET")
 
 	// Vehicle Routes (Admin - Get all vehicles)
 	adminVehicleRoutes := adminRoutes.PathPrefix("/vehicles").Subrouter()
 	adminVehicleRoutes.HandleFunc("", s.vehicleHandler.GetAllVehicles).Methods("GET")
+	adminVehicleRoutes.HandleFunc("/{id}/warranty-claims", s.warrantyHandler.GetClaimsByVehicle).Methods("GET")
 
 	// Settings Routes (Public - for customers to see shop info)
 	settingsPublicRoutes := api.PathPrefix("/settings").Subrouter()
 	settingsPublicRoutes.Use(middleware.Auth)
 	settingsPublicRoutes.HandleFunc("/public", s.settingHandler.GetPublicSettings).Methods("GET")
+	settingsPublicRoutes.HandleFunc("/closures", s.shopClosureHandler.GetCalendar).Methods("GET")
 
 	// Settings Routes (Admin only)
 	settingsAdminRoutes := adminRoutes.PathPrefix("/settings").Subrouter()
@@ -194,6 +329,11 @@ func (s *HTTPServer) setupRoutes() {
 	settingsAdminRoutes.HandleFunc("/category/{category}", s.settingHandler.GetSettingsByCategory).Methods("GET")
 	settingsAdminRoutes.HandleFunc("/key/{key}", s.settingHandler.GetSetting).Methods("GET")
 	settingsAdminRoutes.HandleFunc("/key/{key}", s.settingHandler.UpdateSetting).Methods("PUT")
+	settingsAdminRoutes.HandleFunc("/closures", s.shopClosureHandler.CreateClosure).Methods("POST")
+	settingsAdminRoutes.HandleFunc("/closures", s.shopClosureHandler.GetAllClosures).Methods("GET")
+	settingsAdminRoutes.HandleFunc("/closures/{id}", s.shopClosureHandler.GetClosure).Methods("GET")
+	settingsAdminRoutes.HandleFunc("/closures/{id}", s.shopClosureHandler.UpdateClosure).Methods("PUT")
+	settingsAdminRoutes.HandleFunc("/closures/{id}", s.shopClosureHandler.DeleteClosure).Methods("DELETE")
 	settingsAdminRoutes.HandleFunc("/{id}", s.settingHandler.DeleteSetting).Methods("DELETE")
 
 	// Invoice Routes (Admin)
@@ -221,6 +361,7 @@ func (s *HTTPServer) setupRoutes() {
 	analyticsRoutes.HandleFunc("/service-stats", s.analyticsHandler.GetServiceStats).Methods("GET")
 	analyticsRoutes.HandleFunc("/queue-stats", s.analyticsHandler.GetQueueStats).Methods("GET")
 	analyticsRoutes.HandleFunc("/mechanic-performance", s.analyticsHandler.GetMechanicPerformance).Methods("GET")
+	analyticsRoutes.HandleFunc("/warranty", s.analyticsHandler.GetWarrantyStats).Methods("GET")
 
 	// Role Routes (Admin only)
 	roleRoutes := adminRoutes.PathPrefix("/roles").Subrouter()
@@ -16,6 +16,7 @@ const (
 	MaxDescriptionLength = 1000
 	DefaultPageSize = 10
 	MaxPageSize     = 100
+	KioskRequestsPerMinute = 20
 	RoleAdmin    = "admin"
 	RoleUser     = "user"
 	RoleMechanic = "mechanic"
@@ -37,7 +37,8 @@ type ServiceStatsResponse struct {
 }
 
 type ServiceTypeCount struct {
-	Type  string `json:"type"`
+	Type  string `json:"type"` // service catalog code
+	Name  string `json:"name"`
 	Count int    `json:"count"`
 }
 
@@ -61,5 +62,26 @@ type MechanicPerformanceResponse struct {
 	CompletedServices int     `json:"completed_services"`
 	AverageCompletion float64 `json:"average_completion_hours"`
 	CustomerRating    float64 `json:"customer_rating"`
-	Efficiency        float64 `json:"efficiency_percentage"`
+	CompletionRate    float64 `json:"completion_rate_percentage"` // completed / assigned
+	Efficiency        float64 `json:"efficiency_percentage"`      // estimated / actual minutes on completed jobs
+}
+
+// WarrantyStatsResponse represents warranty comebacks, reported apart from
+// billed work
+type WarrantyStatsResponse struct {
+	WarrantedItems  int                     `json:"warranted_items"` // completed work given warranty terms
+	TotalClaims     int                     `json:"total_claims"`
+	CompletedClaims int                     `json:"completed_claims"`
+	AbsorbedCost    float64                 `json:"absorbed_cost"`         // actual cost of completed warranty repairs
+	ClaimRate       float64                 `json:"claim_rate_percentage"` // claims / warranted items
+	ByCategory      []WarrantyCategoryStats `json:"by_category"`
+}
+
+type WarrantyCategoryStats struct {
+	Category        string  `json:"category"`
+	WarrantedItems  int     `json:"warranted_items"`
+	TotalClaims     int     `json:"total_claims"`
+	CompletedClaims int     `json:"completed_claims"`
+	AbsorbedCost    float64 `json:"absorbed_cost"`
+	ClaimRate       float64 `json:"claim_rate_percentage"`
 }
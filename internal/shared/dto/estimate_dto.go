@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+type DecideEstimateRequest struct {
+	Accept bool   `json:"accept"`
+	Reason string `json:"reason,omitempty" validate:"max=500"`
+}
+
+type EstimateLinePartResponse struct {
+	ProductID types.MSSQLUUID `json:"product_id"`
+	Name      string          `json:"name"`
+	Quantity  int             `json:"quantity"`
+	UnitPrice float64         `json:"unit_price"`
+	Total     float64         `json:"total"`
+}
+
+type EstimateLineResponse struct {
+	MaintenanceItemID types.MSSQLUUID            `json:"maintenance_item_id"`
+	ItemType          string                     `json:"item_type"`
+	Category          string                     `json:"category"`
+	Name              string                     `json:"name"`
+	PartsCost         float64                    `json:"parts_cost"`
+	LaborHours        float64                    `json:"labor_hours"`
+	LaborRate         float64                    `json:"labor_rate"`
+	LaborCost         float64                    `json:"labor_cost"`
+	Amount            float64                    `json:"amount"`
+	Parts             []EstimateLinePartResponse `json:"parts,omitempty"`
+}
+
+// EstimateLineChange is how one item differs between two estimate versions.
+type EstimateLineChange struct {
+	Change            string          `json:"change"` // added, removed or changed
+	MaintenanceItemID types.MSSQLUUID `json:"maintenance_item_id"`
+	Name              string          `json:"name"`
+	PreviousAmount    float64         `json:"previous_amount"`
+	Amount            float64         `json:"amount"`
+}
+
+type EstimateDiff struct {
+	AgainstVersion int                  `json:"against_version"`
+	AgainstStatus  string               `json:"against_status"`
+	Lines          []EstimateLineChange `json:"lines"`
+	TotalChange    float64              `json:"total_change"`
+}
+
+type EstimateResponse struct {
+	ID            types.MSSQLUUID        `json:"id"`
+	WaitingListID types.MSSQLUUID        `json:"waiting_list_id"`
+	Version       int                    `json:"version"`
+	Status        string                 `json:"status"`
+	Lines         []EstimateLineResponse `json:"lines"`
+	Subtotal      float64                `json:"subtotal"`
+	TaxPercent    float64                `json:"tax_percent"`
+	TaxAmount     float64                `json:"tax_amount"`
+	Total         float64                `json:"total"`
+	Diff          *EstimateDiff          `json:"diff,omitempty"` // empty for the first version
+	DecidedAt     *time.Time             `json:"decided_at,omitempty"`
+	DecisionNote  string                 `json:"decision_note,omitempty"`
+	CreatedAt     time.Time              `json:"created_at"`
+}
+
+// CostOverrunWarning is returned when a ticket's final cost is more than the
+// allowed threshold above the estimate the customer accepted.
+type CostOverrunWarning struct {
+	EstimateID       types.MSSQLUUID `json:"estimate_id"`
+	EstimateVersion  int             `json:"estimate_version"`
+	AcceptedTotal    float64         `json:"accepted_total"`
+	FinalTotal       float64         `json:"final_total"`
+	Overrun          float64         `json:"overrun"`
+	OverrunPercent   float64         `json:"overrun_percent"`
+	ThresholdPercent float64         `json:"threshold_percent"`
+}
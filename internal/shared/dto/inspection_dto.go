@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+type InspectionTemplateRequest struct {
+	Name        string                           `json:"name" validate:"required,max=100"`
+	Description string                           `json:"description,omitempty"`
+	IsActive    *bool                            `json:"is_active"` // defaults to true
+	Points      []InspectionTemplatePointRequest `json:"points" validate:"required,min=1"`
+}
+
+type InspectionTemplatePointRequest struct {
+	Category string `json:"category" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+	Unit     string `json:"unit,omitempty"` // e.g. "mm" for a measured point
+}
+
+type StartInspectionRequest struct {
+	WaitingListID types.MSSQLUUID `json:"waiting_list_id" validate:"required"`
+	TemplateID    types.MSSQLUUID `json:"template_id" validate:"required"`
+}
+
+type RecordInspectionResultsRequest struct {
+	Notes   *string                         `json:"notes,omitempty"` // overall notes, left alone when omitted
+	Results []RecordInspectionResultRequest `json:"results"`
+}
+
+type RecordInspectionResultRequest struct {
+	ResultID    types.MSSQLUUID `json:"result_id" validate:"required"`
+	Status      string          `json:"status" validate:"required"` // green, yellow or red
+	Measurement *float64        `json:"measurement,omitempty"`
+	Notes       string          `json:"notes,omitempty"`
+}
+
+type InspectionResultResponse struct {
+	ID                types.MSSQLUUID  `json:"id"`
+	Category          string           `json:"category"`
+	Name              string           `json:"name"`
+	Status            string           `json:"status"` // empty until checked
+	Measurement       *float64         `json:"measurement,omitempty"`
+	Unit              string           `json:"unit,omitempty"`
+	Notes             string           `json:"notes,omitempty"`
+	PhotoURL          string           `json:"photo_url,omitempty"`
+	MaintenanceItemID *types.MSSQLUUID `json:"maintenance_item_id,omitempty"`
+}
+
+type InspectionResponse struct {
+	ID            types.MSSQLUUID            `json:"id"`
+	WaitingListID types.MSSQLUUID            `json:"waiting_list_id"`
+	TemplateID    types.MSSQLUUID            `json:"template_id"`
+	TemplateName  string                     `json:"template_name"`
+	MechanicID    types.MSSQLUUID            `json:"mechanic_id"`
+	MechanicName  string                     `json:"mechanic_name,omitempty"`
+	Notes         string                     `json:"notes"`
+	Green         int                        `json:"green"`
+	Yellow        int                        `json:"yellow"`
+	Red           int                        `json:"red"`
+	Unchecked     int                        `json:"unchecked"`
+	Results       []InspectionResultResponse `json:"results"`
+	CompletedAt   *time.Time                 `json:"completed_at,omitempty"`
+	CreatedAt     time.Time                  `json:"created_at"`
+}
+
+// InspectionReportResponse is the customer's view of a completed
+// inspection, grouped by category.
+type InspectionReportResponse struct {
+	WaitingListID types.MSSQLUUID              `json:"waiting_list_id"`
+	QueueNumber   int                          `json:"queue_number"`
+	VehicleBrand  string                       `json:"vehicle_brand"`
+	VehicleModel  string                       `json:"vehicle_model"`
+	LicensePlate  string                       `json:"license_plate"`
+	MechanicName  string                       `json:"mechanic_name,omitempty"`
+	Notes         string                       `json:"notes,omitempty"`
+	Green         int                          `json:"green"`
+	Yellow        int                          `json:"yellow"`
+	Red           int                          `json:"red"`
+	Categories    []InspectionCategoryResponse `json:"categories"`
+	InspectedAt   time.Time                    `json:"inspected_at"`
+}
+
+type InspectionCategoryResponse struct {
+	Category string                     `json:"category"`
+	Status   string                     `json:"status"` // worst result in the category
+	Results  []InspectionResultResponse `json:"results"`
+}
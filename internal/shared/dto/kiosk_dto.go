@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+type RegisterKioskRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type KioskDeviceResponse struct {
+	ID         types.MSSQLUUID `json:"id"`
+	Name       string          `json:"name"`
+	Token      string          `json:"token,omitempty"` // only returned when the device is registered
+	RevokedAt  *time.Time      `json:"revoked_at,omitempty"`
+	LastSeenAt *time.Time      `json:"last_seen_at,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type KioskTakeQueueRequest struct {
+	LicensePlate  string `json:"license_plate" validate:"required,max=20"`
+	ServiceType   string `json:"service_type" validate:"required"`
+	EstimatedTime int    `json:"estimated_time"` // in minutes
+	Notes         string `json:"notes,omitempty"`
+}
+
+type KioskGuestRequest struct {
+	Name         string `json:"name" validate:"required"`
+	Phone        string `json:"phone,omitempty"`
+	LicensePlate string `json:"license_plate" validate:"required,max=20"`
+	Brand        string `json:"brand,omitempty"`
+	Model        string `json:"model,omitempty"`
+	Year         int    `json:"year,omitempty"`
+}
+
+// KioskVehicleResponse is what a kiosk may show about a vehicle; the owner's
+// name is masked.
+type KioskVehicleResponse struct {
+	VehicleID    types.MSSQLUUID `json:"vehicle_id"`
+	LicensePlate string          `json:"license_plate"`
+	Brand        string          `json:"brand"`
+	Model        string          `json:"model"`
+	OwnerName    string          `json:"owner_name"`
+}
+
+type KioskTicketResponse struct {
+	QueueNumber  int       `json:"queue_number"`
+	ServiceDate  time.Time `json:"service_date"`
+	ServiceType  string    `json:"service_type"`
+	Status       string    `json:"status"`
+	LicensePlate string    `json:"license_plate"`
+}
@@ -9,6 +9,13 @@ type CreateMaintenanceItemRequest struct {
 	Description   string  `json:"description"`
 	EstimatedCost float64 `json:"estimated_cost"`
 }
+// CreateInitialItemsRequest is the object form of the initial items body. A
+// plain array of items is still accepted.
+type CreateInitialItemsRequest struct {
+	PackageCode string                         `json:"package_code,omitempty"`
+	Exclude     []string                       `json:"exclude,omitempty"` // names of package items to leave out
+	Items       []CreateMaintenanceItemRequest `json:"items,omitempty"`   // added on top of the package
+}
 type AddDiscoveredItemRequest struct {
 	WaitingListID    types.MSSQLUUID `json:"waiting_list_id" validate:"required"`
 	Category         string          `json:"category" validate:"required"`
@@ -30,10 +37,23 @@ type UpdateMaintenanceItemRequest struct {
 	Priority      string   `json:"priority" validate:"omitempty,oneof=urgent high normal low"`
 	Notes         string   `json:"notes"`
 }
+type AddItemPartRequest struct {
+	ProductID types.MSSQLUUID `json:"product_id" validate:"required"`
+	Quantity  int             `json:"quantity" validate:"min=1"`
+	UnitPrice *float64        `json:"unit_price"` // defaults to the product's current price
+}
+type CompleteMaintenanceItemRequest struct {
+	ActualCost    float64 `json:"actual_cost"`
+	OverrideStock bool    `json:"override_stock"` // admin only: complete even when parts are out of stock
+}
 type ApproveMaintenanceItemRequest struct {
-	ItemIDs []types.MSSQLUUID `json:"item_ids" validate:"required,min=1"`
-	Approve bool              `json:"approve"` // true = approve, false = reject
-	Notes   string            `json:"notes"`   // Customer feedback
+	ItemIDs   []types.MSSQLUUID  `json:"item_ids"`
+	Approve   bool               `json:"approve"`             // true = approve, false = reject
+	Notes     string             `json:"notes"`               // Customer feedback, stored as the reason
+	Decisions []ApprovalDecision `json:"decisions,omitempty"` // per-item decisions, in addition to item_ids
+}
+type RecordPhoneApprovalRequest struct {
+	Decisions []ApprovalDecision `json:"decisions" validate:"required,min=1"`
 }
 type MaintenanceItemResponse struct {
 	ID               types.MSSQLUUID  `json:"id"`
@@ -49,20 +69,107 @@ type MaintenanceItemResponse struct {
 	EstimatedCost    float64          `json:"estimated_cost"`
 	ActualCost       float64          `json:"actual_cost"`
 	LaborHours       float64          `json:"labor_hours"`
+	LaborRate        float64          `json:"labor_rate"`
+	LaborCost        float64          `json:"labor_cost"`
 	RequiresApproval bool             `json:"requires_approval"`
 	ImageURL         string           `json:"image_url,omitempty"`
 	Notes            string           `json:"notes"`
+	PackageCode      string           `json:"package_code,omitempty"`
+	DiscountAmount   float64          `json:"discount_amount"`
+	WarrantyMonths   int              `json:"warranty_months,omitempty"`
+	WarrantyKm       int              `json:"warranty_km,omitempty"`
+	WarrantyUntil    *time.Time       `json:"warranty_until,omitempty"`
+	WarrantyUntilKm  int              `json:"warranty_until_km,omitempty"`
+	WarrantyClaimID  *types.MSSQLUUID `json:"warranty_claim_id,omitempty"` // set on repairs done under warranty
+	Parts            []MaintenanceItemPartResponse `json:"parts,omitempty"`
+	PartsCost        float64          `json:"parts_cost"`
 	InspectedAt      *time.Time       `json:"inspected_at,omitempty"`
 	ApprovedAt       *time.Time       `json:"approved_at,omitempty"`
 	CompletedAt      *time.Time       `json:"completed_at,omitempty"`
 	CreatedAt        time.Time        `json:"created_at"`
 	UpdatedAt        time.Time        `json:"updated_at"`
 }
+type MaintenanceItemPartResponse struct {
+	ID          types.MSSQLUUID `json:"id"`
+	ProductID   types.MSSQLUUID `json:"product_id"`
+	ProductName string          `json:"product_name,omitempty"`
+	SKU         string          `json:"sku,omitempty"`
+	Quantity    int             `json:"quantity"`
+	UnitPrice   float64         `json:"unit_price"`
+	Total       float64         `json:"total"`
+	DeductedAt  *time.Time      `json:"deducted_at,omitempty"`
+}
+type ApprovalLinkResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+// ApprovalDecision is one answer given through an approval link.
+type ApprovalDecision struct {
+	ItemID  types.MSSQLUUID `json:"item_id" validate:"required"`
+	Approve bool            `json:"approve"`
+	Reason  string          `json:"reason,omitempty"`
+}
+// ApprovalRecordResponse is one entry of a ticket's decision trail.
+type ApprovalRecordResponse struct {
+	ItemID         types.MSSQLUUID  `json:"item_id"`
+	ItemName       string           `json:"item_name,omitempty"`
+	CustomerID     types.MSSQLUUID  `json:"customer_id"`
+	Decision       string           `json:"decision"`
+	Channel        string           `json:"channel"`
+	Reason         string           `json:"reason,omitempty"`
+	RecordedByID   *types.MSSQLUUID `json:"recorded_by_id,omitempty"`
+	RecordedByName string           `json:"recorded_by_name,omitempty"`
+	DecidedAt      time.Time        `json:"decided_at"`
+}
+type ApprovalLinkDecisionRequest struct {
+	Decisions []ApprovalDecision `json:"decisions" validate:"required,min=1"`
+}
+// PendingApprovalResponse is what an approval link shows: the discovered
+// items still waiting for the customer's answer.
+type PendingApprovalResponse struct {
+	WaitingListID      types.MSSQLUUID           `json:"waiting_list_id"`
+	QueueNumber        int                       `json:"queue_number"`
+	VehicleBrand       string                    `json:"vehicle_brand"`
+	VehicleModel       string                    `json:"vehicle_model"`
+	LicensePlate       string                    `json:"license_plate"`
+	Items              []MaintenanceItemResponse `json:"items"`
+	TotalEstimatedCost float64                   `json:"total_estimated_cost"`
+	ExpiresAt          time.Time                 `json:"expires_at"`
+}
+// ApprovalCallResponse is a phone-call task for the service advisor: a
+// ticket whose approvals were escalated and are still unanswered.
+type ApprovalCallResponse struct {
+	WaitingListID      types.MSSQLUUID           `json:"waiting_list_id"`
+	QueueNumber        int                       `json:"queue_number"`
+	CustomerName       string                    `json:"customer_name"`
+	CustomerPhone      string                    `json:"customer_phone"`
+	LicensePlate       string                    `json:"license_plate"`
+	Items              []MaintenanceItemResponse `json:"items"`
+	TotalEstimatedCost float64                   `json:"total_estimated_cost"`
+	EscalatedAt        time.Time                 `json:"escalated_at"`
+}
+type AttachmentResponse struct {
+	ID                types.MSSQLUUID  `json:"id"`
+	MaintenanceItemID types.MSSQLUUID  `json:"maintenance_item_id"`
+	Kind              string           `json:"kind"` // photo, video or document
+	FileName          string           `json:"file_name"`
+	ContentType       string           `json:"content_type"`
+	Size              int64            `json:"size"`
+	URL               string           `json:"url"`
+	ThumbnailURL      string           `json:"thumbnail_url,omitempty"`
+	UploadedByID      *types.MSSQLUUID `json:"uploaded_by_id,omitempty"`
+	UploadedByName    string           `json:"uploaded_by_name,omitempty"`
+	CreatedAt         time.Time        `json:"created_at"`
+}
 type MaintenanceItemListResponse struct {
 	Items                []MaintenanceItemResponse `json:"items"`
 	Total                int                       `json:"total"`
 	TotalEstimatedCost   float64                   `json:"total_estimated_cost"`
+	TotalDiscount        float64                   `json:"total_discount"`
 	TotalActualCost      float64                   `json:"total_actual_cost"`
+	TotalPartsCost       float64                   `json:"total_parts_cost"`
+	TotalLaborCost       float64                   `json:"total_labor_cost"`
+	TotalWarrantyCost    float64                   `json:"total_warranty_cost"` // warranty repairs, carried by the shop
 	PendingApprovalCount int                       `json:"pending_approval_count"`
 	CompletedCount       int                       `json:"completed_count"`
 }
@@ -75,7 +182,10 @@ type MaintenanceInspectionSummary struct {
 	InitialItems       []MaintenanceItemResponse `json:"initial_items"`
 	DiscoveredItems    []MaintenanceItemResponse `json:"discovered_items"`
 	TotalEstimatedCost float64                   `json:"total_estimated_cost"`
+	TotalPartsCost     float64                   `json:"total_parts_cost"`
+	TotalLaborCost     float64                   `json:"total_labor_cost"`
 	RequiresApproval   bool                      `json:"requires_approval"`
+	Decisions          []ApprovalRecordResponse  `json:"decisions"`
 	InspectedAt        time.Time                 `json:"inspected_at"`
 }
 
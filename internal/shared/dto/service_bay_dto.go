@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+type CreateServiceBayRequest struct {
+	Code     string `json:"code" validate:"required,max=20"`
+	Name     string `json:"name" validate:"required,max=100"`
+	IsActive *bool  `json:"is_active"` // defaults to true
+	Notes    string `json:"notes,omitempty"`
+}
+
+type UpdateServiceBayRequest struct {
+	Name     string `json:"name,omitempty"`
+	IsActive *bool  `json:"is_active,omitempty"`
+	Notes    string `json:"notes,omitempty"`
+}
+
+// ServiceBayStatus describes what a bay is working on right now.
+type ServiceBayStatus struct {
+	ID             types.MSSQLUUID `json:"id"`
+	Code           string          `json:"code"`
+	Name           string          `json:"name"`
+	Busy           bool            `json:"busy"`
+	QueueNumber    int             `json:"queue_number,omitempty"`
+	ServiceType    string          `json:"service_type,omitempty"`
+	LicensePlate   string          `json:"license_plate,omitempty"` // masked
+	ServiceStartAt *time.Time      `json:"service_start_at,omitempty"`
+}
+
+type ServiceBayOverview struct {
+	Bays      []ServiceBayStatus `json:"bays"`
+	TotalBays int                `json:"total_bays"`
+	FreeBays  int                `json:"free_bays"`
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+type ServicePackageRequest struct {
+	Code            string                      `json:"code" validate:"required,max=30"`
+	Name            string                      `json:"name" validate:"required,max=100"`
+	Description     string                      `json:"description,omitempty"`
+	DiscountPercent float64                     `json:"discount_percent"`
+	FixedPrice      float64                     `json:"fixed_price"` // overrides discount_percent when set
+	IsActive        *bool                       `json:"is_active"`   // defaults to true
+	Items           []ServicePackageItemRequest `json:"items" validate:"required,min=1"`
+}
+
+type ServicePackageItemRequest struct {
+	Category      string                      `json:"category" validate:"required"`
+	Name          string                      `json:"name" validate:"required"`
+	Description   string                      `json:"description,omitempty"`
+	EstimatedCost float64                     `json:"estimated_cost"` // without parts
+	LaborHours    float64                     `json:"labor_hours"`
+	Parts         []ServicePackagePartRequest `json:"parts,omitempty"`
+}
+
+type ServicePackagePartRequest struct {
+	ProductID types.MSSQLUUID `json:"product_id" validate:"required"`
+	Quantity  int             `json:"quantity" validate:"min=1"`
+}
+
+type ServicePackageResponse struct {
+	ID              types.MSSQLUUID              `json:"id"`
+	Code            string                       `json:"code"`
+	Name            string                       `json:"name"`
+	Description     string                       `json:"description"`
+	DiscountPercent float64                      `json:"discount_percent"`
+	FixedPrice      float64                      `json:"fixed_price"`
+	IsActive        bool                         `json:"is_active"`
+	ListPrice       float64                      `json:"list_price"` // items and parts at full price
+	Price           float64                      `json:"price"`      // what the customer pays for the whole package
+	Items           []ServicePackageItemResponse `json:"items"`
+	CreatedAt       time.Time                    `json:"created_at"`
+	UpdatedAt       time.Time                    `json:"updated_at"`
+}
+
+type ServicePackageItemResponse struct {
+	Category      string                       `json:"category"`
+	Name          string                       `json:"name"`
+	Description   string                       `json:"description,omitempty"`
+	EstimatedCost float64                      `json:"estimated_cost"`
+	LaborHours    float64                      `json:"labor_hours"`
+	Parts         []ServicePackagePartResponse `json:"parts,omitempty"`
+}
+
+type ServicePackagePartResponse struct {
+	ProductID types.MSSQLUUID `json:"product_id"`
+	Name      string          `json:"name,omitempty"`
+	Quantity  int             `json:"quantity"`
+	UnitPrice float64         `json:"unit_price"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+type CreateServiceTypeRequest struct {
+	Code            string  `json:"code" validate:"required,max=30"`
+	Name            string  `json:"name" validate:"required,max=100"`
+	Category        string  `json:"category" validate:"required,max=100"`
+	DefaultDuration int     `json:"default_duration"` // in minutes
+	BaseLaborPrice  float64 `json:"base_labor_price"`
+	IsActive        *bool   `json:"is_active"` // defaults to true
+}
+
+type UpdateServiceTypeRequest struct {
+	Name            string   `json:"name,omitempty"`
+	Category        string   `json:"category,omitempty"`
+	DefaultDuration *int     `json:"default_duration,omitempty"`
+	BaseLaborPrice  *float64 `json:"base_labor_price,omitempty"`
+	IsActive        *bool    `json:"is_active,omitempty"`
+}
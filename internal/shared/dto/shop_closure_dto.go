@@ -0,0 +1,33 @@
+package dto
+
+type ShopClosureRequest struct {
+	Name        string `json:"name" validate:"required"`
+	StartDate   string `json:"start_date" validate:"required"` // YYYY-MM-DD
+	EndDate     string `json:"end_date,omitempty"`             // YYYY-MM-DD, defaults to start_date
+	StartTime   string `json:"start_time,omitempty"`           // HH:MM, omit both times for a full-day closure
+	EndTime     string `json:"end_time,omitempty"`             // HH:MM
+	IsRecurring bool   `json:"is_recurring"`                   // repeat every year
+}
+
+type ClosureWindowResponse struct {
+	Name      string `json:"name"`
+	StartTime string `json:"start_time"` // HH:MM
+	EndTime   string `json:"end_time"`   // HH:MM
+}
+
+type CalendarDayResponse struct {
+	Date         string                  `json:"date"`
+	IsOpen       bool                    `json:"is_open"`
+	IsWorkingDay bool                    `json:"is_working_day"`
+	Reason       string                  `json:"reason,omitempty"`
+	OpeningTime  string                  `json:"opening_time,omitempty"`
+	ClosingTime  string                  `json:"closing_time,omitempty"`
+	Closures     []ClosureWindowResponse `json:"partial_closures,omitempty"`
+}
+
+type ShopCalendarResponse struct {
+	From        string                `json:"from"`
+	To          string                `json:"to"`
+	WorkingDays []string              `json:"working_days"`
+	Days        []CalendarDayResponse `json:"days"`
+}
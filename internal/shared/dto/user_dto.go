@@ -18,16 +18,18 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 type UpdateUserRequest struct {
-	FirstName string `json:"first_name,omitempty" validate:"omitempty,min=1,max=100"`
-	LastName  string `json:"last_name,omitempty" validate:"omitempty,min=1,max=100"`
-	Phone     string `json:"phone,omitempty" validate:"omitempty,min=10,max=20"`
+	FirstName  string `json:"first_name,omitempty" validate:"omitempty,min=1,max=100"`
+	LastName   string `json:"last_name,omitempty" validate:"omitempty,min=1,max=100"`
+	Phone      string `json:"phone,omitempty" validate:"omitempty,min=10,max=20"`
+	SkillLevel string `json:"skill_level,omitempty" validate:"omitempty,max=20"` // admin only, ignored on profile updates
 }
 type UserResponse struct {
-	ID    types.MSSQLUUID `json:"id"`
-	Email string          `json:"email"`
-	Name  string          `json:"name"`
-	Phone string          `json:"phone"`
-	Roles []RoleResponse  `json:"roles,omitempty"` // RBAC roles from roles table
+	ID         types.MSSQLUUID `json:"id"`
+	Email      string          `json:"email"`
+	Name       string          `json:"name"`
+	Phone      string          `json:"phone"`
+	SkillLevel string          `json:"skill_level,omitempty"`
+	Roles      []RoleResponse  `json:"roles,omitempty"` // RBAC roles from roles table
 }
 type LoginResponse struct {
 	User        UserResponse `json:"user"`
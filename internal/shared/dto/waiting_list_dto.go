@@ -14,6 +14,85 @@ type TakeQueueRequest struct {
 	Notes         string          `json:"notes,omitempty"`
 }
 
+type BookSlotRequest struct {
+	VehicleID     types.MSSQLUUID `json:"vehicle_id" validate:"required"`
+	ServiceType   string          `json:"service_type" validate:"required"`
+	ServiceDate   string          `json:"service_date" validate:"required"` // YYYY-MM-DD
+	SlotTime      string          `json:"slot_time" validate:"required"`    // HH:MM, start of the booked slot
+	EstimatedTime int             `json:"estimated_time"`                   // in minutes, defaults to one slot
+	Notes         string          `json:"notes,omitempty"`
+}
+
+type StandbyResponse struct {
+	ID            types.MSSQLUUID `json:"id"`
+	VehicleID     types.MSSQLUUID `json:"vehicle_id"`
+	CustomerID    types.MSSQLUUID `json:"customer_id"`
+	ServiceDate   time.Time       `json:"service_date"`
+	ServiceType   string          `json:"service_type"`
+	EstimatedTime int             `json:"estimated_time"`
+	Status        string          `json:"status"`
+	Position      int             `json:"position"`
+	Notes         string          `json:"notes"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type StartServiceRequest struct {
+	ServiceBayID *types.MSSQLUUID `json:"service_bay_id,omitempty"` // first free bay when omitted
+}
+
+type AssignMechanicRequest struct {
+	MechanicID *types.MSSQLUUID `json:"mechanic_id,omitempty"` // least-loaded mechanic when omitted
+}
+
+type CancelQueueRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+type CheckInRequest struct {
+	Token string `json:"token" validate:"required"` // content of the ticket's QR code
+}
+
+type CheckInResponse struct {
+	ID           types.MSSQLUUID `json:"id"`
+	QueueNumber  int             `json:"queue_number"`
+	Status       string          `json:"status"`
+	LicensePlate string          `json:"license_plate"`
+	CustomerName string          `json:"customer_name"`
+	ArrivedAt    *time.Time      `json:"arrived_at"`
+}
+
+type StatusTransitionResponse struct {
+	FromStatus string           `json:"from_status,omitempty"` // empty for the ticket's creation
+	ToStatus   string           `json:"to_status"`
+	ActorID    *types.MSSQLUUID `json:"actor_id,omitempty"` // empty for background jobs
+	ActorName  string           `json:"actor_name,omitempty"`
+	Reason     string           `json:"reason,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+type RescheduleRequest struct {
+	ServiceDate string `json:"service_date" validate:"required"` // YYYY-MM-DD
+}
+
+type BulkRescheduleRequest struct {
+	FromDate string `json:"from_date" validate:"required"` // YYYY-MM-DD
+	ToDate   string `json:"to_date" validate:"required"`   // YYYY-MM-DD
+}
+
+type RescheduledTicket struct {
+	ID                  types.MSSQLUUID `json:"id"`
+	PreviousQueueNumber int             `json:"previous_queue_number"`
+	QueueNumber         int             `json:"queue_number,omitempty"`
+	Error               string          `json:"error,omitempty"`
+}
+
+type BulkRescheduleResponse struct {
+	FromDate string              `json:"from_date"`
+	ToDate   string              `json:"to_date"`
+	Moved    []RescheduledTicket `json:"moved"`
+	Failed   []RescheduledTicket `json:"failed"`
+}
+
 type UpdateWaitingListRequest struct {
 	ServiceType   string `json:"service_type,omitempty"`
 	EstimatedTime int    `json:"estimated_time,omitempty"`
@@ -22,48 +101,76 @@ type UpdateWaitingListRequest struct {
 }
 
 type WaitingListResponse struct {
-	ID             types.MSSQLUUID `json:"id"`
-	QueueNumber    int             `json:"queue_number"`
-	VehicleID      types.MSSQLUUID `json:"vehicle_id"`
-	CustomerID     types.MSSQLUUID `json:"customer_id"`
-	ServiceDate    time.Time       `json:"service_date"`
-	ServiceType    string          `json:"service_type"`
-	EstimatedTime  int             `json:"estimated_time"`
-	Status         string          `json:"status"`
-	CalledAt       *time.Time      `json:"called_at,omitempty"`
-	ServiceStartAt *time.Time      `json:"service_start_at,omitempty"`
-	ServiceEndAt   *time.Time      `json:"service_end_at,omitempty"`
-	Notes          string          `json:"notes"`
-	CreatedAt      time.Time       `json:"created_at"`
-	UpdatedAt      time.Time       `json:"updated_at"`
+	ID              types.MSSQLUUID `json:"id"`
+	QueueNumber     int             `json:"queue_number"`
+	VehicleID       types.MSSQLUUID `json:"vehicle_id"`
+	CustomerID      types.MSSQLUUID `json:"customer_id"`
+	ServiceDate     time.Time       `json:"service_date"`
+	ServiceType     string          `json:"service_type"`
+	ServiceTypeCode string          `json:"service_type_code,omitempty"`
+	EstimatedTime   int             `json:"estimated_time"`
+	AppointmentAt   *time.Time      `json:"appointment_at,omitempty"`
+	Status          string          `json:"status"`
+	CalledAt        *time.Time      `json:"called_at,omitempty"`
+	ServiceStartAt  *time.Time      `json:"service_start_at,omitempty"`
+	ServiceEndAt    *time.Time      `json:"service_end_at,omitempty"`
+	Notes           string          `json:"notes"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
 }
 
 type WaitingListWithDetailsResponse struct {
-	ID             types.MSSQLUUID `json:"id"`
-	QueueNumber    int             `json:"queue_number"`
-	VehicleID      types.MSSQLUUID `json:"vehicle_id"`
-	VehicleBrand   string          `json:"vehicle_brand,omitempty"`
-	VehicleModel   string          `json:"vehicle_model,omitempty"`
-	LicensePlate   string          `json:"license_plate,omitempty"`
-	CustomerID     types.MSSQLUUID `json:"customer_id"`
-	CustomerName   string          `json:"customer_name,omitempty"`
-	CustomerPhone  string          `json:"customer_phone,omitempty"`
-	ServiceDate    time.Time       `json:"service_date"`
-	ServiceType    string          `json:"service_type"`
-	EstimatedTime  int             `json:"estimated_time"`
-	Status         string          `json:"status"`
-	CalledAt       *time.Time      `json:"called_at,omitempty"`
-	ServiceStartAt *time.Time      `json:"service_start_at,omitempty"`
-	ServiceEndAt   *time.Time      `json:"service_end_at,omitempty"`
-	Notes          string          `json:"notes"`
-	CreatedAt      time.Time       `json:"created_at"`
-	UpdatedAt      time.Time       `json:"updated_at"`
+	ID                   types.MSSQLUUID  `json:"id"`
+	QueueNumber          int              `json:"queue_number"`
+	VehicleID            types.MSSQLUUID  `json:"vehicle_id"`
+	VehicleBrand         string           `json:"vehicle_brand,omitempty"`
+	VehicleModel         string           `json:"vehicle_model,omitempty"`
+	LicensePlate         string           `json:"license_plate,omitempty"`
+	CustomerID           types.MSSQLUUID  `json:"customer_id"`
+	CustomerName         string           `json:"customer_name,omitempty"`
+	CustomerPhone        string           `json:"customer_phone,omitempty"`
+	ServiceDate          time.Time        `json:"service_date"`
+	ServiceType          string           `json:"service_type"`
+	ServiceTypeCode      string           `json:"service_type_code,omitempty"`
+	EstimatedTime        int              `json:"estimated_time"`
+	AppointmentAt        *time.Time       `json:"appointment_at,omitempty"`
+	Status               string           `json:"status"`
+	RescheduledFromDate  *time.Time       `json:"rescheduled_from_date,omitempty"`
+	RescheduledFromQueue *int             `json:"rescheduled_from_queue,omitempty"`
+	StandbyPosition      int              `json:"standby_position,omitempty"` // set for standby entries, which have no queue number yet
+	ServiceBayID         *types.MSSQLUUID `json:"service_bay_id,omitempty"`
+	ServiceBayName       string           `json:"service_bay_name,omitempty"`
+	MechanicID           *types.MSSQLUUID `json:"mechanic_id,omitempty"`
+	MechanicName         string           `json:"mechanic_name,omitempty"`
+	ArrivedAt            *time.Time       `json:"arrived_at,omitempty"`
+	CalledAt             *time.Time       `json:"called_at,omitempty"`
+	ServiceStartAt       *time.Time       `json:"service_start_at,omitempty"`
+	ServiceEndAt         *time.Time       `json:"service_end_at,omitempty"`
+	Notes                string           `json:"notes"`
+	CreatedAt            time.Time        `json:"created_at"`
+	UpdatedAt            time.Time        `json:"updated_at"`
 }
 
 type WaitingListListResponse struct {
 	WaitingLists []WaitingListWithDetailsResponse `json:"waiting_lists"`
 	Total        int                              `json:"total"`
 	Date         string                           `json:"date"`
+	Bays         *ServiceBayOverview              `json:"bays,omitempty"`
+}
+
+type TimeSlotResponse struct {
+	StartTime string `json:"start_time"` // HH:MM
+	EndTime   string `json:"end_time"`   // HH:MM
+}
+
+type DaySlotsResponse struct {
+	Date             string             `json:"date"`
+	IsWorkingDay     bool               `json:"is_working_day"`
+	ClosedReason     string             `json:"closed_reason,omitempty"`
+	SlotMinutes      int                `json:"slot_minutes"`
+	RemainingTickets int                `json:"remaining_tickets"`
+	RemainingMinutes int                `json:"remaining_minutes"` // bay-minutes left
+	Slots            []TimeSlotResponse `json:"slots"`
 }
 
 type QueueStatusResponse struct {
@@ -84,26 +191,41 @@ type QueueAvailabilityResponse struct {
 }
 
 type ServiceProgressResponse struct {
-	ID            types.MSSQLUUID `json:"id"`
-	QueueNumber   int             `json:"queue_number"`
-	Status        string          `json:"status"`
-	StatusMessage string          `json:"status_message"`
-	VehicleBrand  string          `json:"vehicle_brand"`
-	VehicleModel  string          `json:"vehicle_model"`
-	LicensePlate  string          `json:"license_plate"`
-	ServiceType   string          `json:"service_type"`
-	ServiceDate   time.Time       `json:"service_date"`
-	EstimatedTime int             `json:"estimated_time_minutes"`
-	QueuePosition int             `json:"queue_position"`
-	PeopleAhead   int             `json:"people_ahead"`
-	EstimatedWait int             `json:"estimated_wait_minutes"`
-	Timeline      Timeline        `json:"timeline"`
-	Notes         string          `json:"notes,omitempty"`
+	ID                types.MSSQLUUID     `json:"id"`
+	QueueNumber       int                 `json:"queue_number"`
+	Status            string              `json:"status"`
+	StatusMessage     string              `json:"status_message"`
+	VehicleBrand      string              `json:"vehicle_brand"`
+	VehicleModel      string              `json:"vehicle_model"`
+	LicensePlate      string              `json:"license_plate"`
+	ServiceType       string              `json:"service_type"`
+	ServiceDate       time.Time           `json:"service_date"`
+	EstimatedTime     int                 `json:"estimated_time_minutes"`
+	QueuePosition     int                 `json:"queue_position"`
+	PeopleAhead       int                 `json:"people_ahead"`
+	EstimatedWait     int                 `json:"estimated_wait_minutes"`
+	Timeline          Timeline            `json:"timeline"`
+	EstimatedCalledAt *time.Time          `json:"estimated_called_at,omitempty"`
+	EstimatedDoneAt   *time.Time          `json:"estimated_done_at,omitempty"`
+	ServiceBayName    string              `json:"service_bay_name,omitempty"`
+	Bays              *ServiceBayOverview `json:"bays,omitempty"`
+	Notes             string              `json:"notes,omitempty"`
 }
 
 type Timeline struct {
 	QueueTakenAt   time.Time  `json:"queue_taken_at"`
+	ArrivedAt      *time.Time `json:"arrived_at,omitempty"`
 	CalledAt       *time.Time `json:"called_at,omitempty"`
 	ServiceStartAt *time.Time `json:"service_start_at,omitempty"`
 	ServiceEndAt   *time.Time `json:"service_end_at,omitempty"`
 }
+
+// QueueBoardEntry is the anonymized view of a ticket shown on public queue displays.
+type QueueBoardEntry struct {
+	QueueNumber  int       `json:"queue_number"`
+	Status       string    `json:"status"`
+	LicensePlate string    `json:"license_plate"` // masked
+	ServiceBay   string    `json:"service_bay,omitempty"`
+	ServiceDate  string    `json:"service_date"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
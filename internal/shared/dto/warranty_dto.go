@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+// WarrantyResponse is completed work that is still under warranty.
+type WarrantyResponse struct {
+	MaintenanceItemID types.MSSQLUUID `json:"maintenance_item_id"`
+	WaitingListID     types.MSSQLUUID `json:"waiting_list_id"`
+	Category          string          `json:"category"`
+	Name              string          `json:"name"`
+	CompletedAt       *time.Time      `json:"completed_at,omitempty"`
+	WarrantyMonths    int             `json:"warranty_months,omitempty"`
+	WarrantyKm        int             `json:"warranty_km,omitempty"`
+	WarrantyUntil     *time.Time      `json:"warranty_until,omitempty"`
+	WarrantyUntilKm   int             `json:"warranty_until_km,omitempty"`
+	ClaimCount        int             `json:"claim_count"`
+}
+
+type OpenWarrantyClaimRequest struct {
+	OriginalItemID types.MSSQLUUID `json:"original_item_id" validate:"required"`
+	WaitingListID  types.MSSQLUUID `json:"waiting_list_id" validate:"required"` // ticket the repair is done on
+	Reason         string          `json:"reason" validate:"required,max=500"`
+	Description    string          `json:"description,omitempty"`
+	Mileage        int             `json:"mileage,omitempty" validate:"min=0"` // defaults to the vehicle's recorded mileage
+}
+
+type WarrantyClaimResponse struct {
+	ID            types.MSSQLUUID          `json:"id"`
+	VehicleID     types.MSSQLUUID          `json:"vehicle_id"`
+	WaitingListID types.MSSQLUUID          `json:"waiting_list_id"`
+	Reason        string                   `json:"reason"`
+	Mileage       int                      `json:"mileage"`
+	OpenedByID    types.MSSQLUUID          `json:"opened_by_id"`
+	OpenedByName  string                   `json:"opened_by_name,omitempty"`
+	OriginalItem  *MaintenanceItemResponse `json:"original_item,omitempty"`
+	RepairItem    *MaintenanceItemResponse `json:"repair_item,omitempty"`
+	CreatedAt     time.Time                `json:"created_at"`
+}
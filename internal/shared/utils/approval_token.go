@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+var (
+	ErrApprovalTokenInvalid = errors.New("invalid approval link")
+	ErrApprovalTokenExpired = errors.New("approval link has expired")
+)
+
+// ApprovalTokenService signs the tokens in the approval links sent to
+// customers. A token names a stored approval record, which is what makes it
+// single use.
+type ApprovalTokenService struct {
+	tokens *SignedTokenService
+}
+
+func NewApprovalTokenService(secret string) *ApprovalTokenService {
+	return &ApprovalTokenService{tokens: NewSignedTokenService(secret, "maintenance-approval")}
+}
+
+// Sign returns a token for the approval record that is valid until expiresAt.
+func (s *ApprovalTokenService) Sign(tokenID types.MSSQLUUID, expiresAt time.Time) string {
+	return s.tokens.Sign(tokenID, expiresAt)
+}
+
+// Verify checks the signature and expiry of a token and returns the ID of
+// its approval record.
+func (s *ApprovalTokenService) Verify(token string, now time.Time) (types.MSSQLUUID, error) {
+	tokenID, err := s.tokens.Verify(token, now)
+	return tokenID, purposeError(err, ErrApprovalTokenInvalid, ErrApprovalTokenExpired)
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+var (
+	ErrCheckInTokenInvalid = errors.New("invalid check-in token")
+	ErrCheckInTokenExpired = errors.New("check-in token has expired")
+)
+
+// CheckInTokenService signs the tokens encoded in ticket QR codes.
+type CheckInTokenService struct {
+	tokens *SignedTokenService
+}
+
+func NewCheckInTokenService(secret string) *CheckInTokenService {
+	return &CheckInTokenService{tokens: NewSignedTokenService(secret, "ticket-check-in")}
+}
+
+// Sign returns a token for the ticket that is valid until expiresAt.
+func (s *CheckInTokenService) Sign(ticketID types.MSSQLUUID, expiresAt time.Time) string {
+	return s.tokens.Sign(ticketID, expiresAt)
+}
+
+// Verify checks the signature and expiry of a token and returns its ticket ID.
+func (s *CheckInTokenService) Verify(token string, now time.Time) (types.MSSQLUUID, error) {
+	ticketID, err := s.tokens.Verify(token, now)
+	return ticketID, purposeError(err, ErrCheckInTokenInvalid, ErrCheckInTokenExpired)
+}
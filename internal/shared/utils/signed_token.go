@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+
+var (
+	ErrSignedTokenInvalid = errors.New("invalid token")
+	ErrSignedTokenExpired = errors.New("token has expired")
+)
+
+// SignedTokenService signs short-lived tokens that carry a single ID. The
+// signing key is derived from the application secret and the purpose, so a
+// token made for one purpose never verifies for another or as a login token.
+type SignedTokenService struct {
+	key []byte
+}
+
+func NewSignedTokenService(secret, purpose string) *SignedTokenService {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return &SignedTokenService{key: mac.Sum(nil)}
+}
+
+// Sign returns "<id>.<expiry unix>.<signature>".
+func (s *SignedTokenService) Sign(id types.MSSQLUUID, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%s.%d", id.String(), expiresAt.Unix())
+	return payload + "." + s.signature(payload)
+}
+
+// Verify checks the signature and expiry of a token and returns its ID.
+func (s *SignedTokenService) Verify(token string, now time.Time) (types.MSSQLUUID, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return types.MSSQLUUID{}, ErrSignedTokenInvalid
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.signature(payload))) {
+		return types.MSSQLUUID{}, ErrSignedTokenInvalid
+	}
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return types.MSSQLUUID{}, ErrSignedTokenInvalid
+	}
+	if now.Unix() > expiresAt {
+		return types.MSSQLUUID{}, ErrSignedTokenExpired
+	}
+	id, err := types.ParseMSSQLUUID(parts[0])
+	if err != nil {
+		return types.MSSQLUUID{}, ErrSignedTokenInvalid
+	}
+	return id, nil
+}
+func (s *SignedTokenService) signature(payload string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// purposeError replaces the errors of SignedTokenService.Verify with the
+// caller's own, so handlers can report which kind of token was rejected.
+func purposeError(err, invalid, expired error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrSignedTokenExpired):
+		return expired
+	default:
+		return invalid
+	}
+}
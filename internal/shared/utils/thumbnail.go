@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	// Register the decoders for the image formats thumbnails are made from.
+	_ "image/gif"
+	_ "image/png"
+)
+
+// maxThumbnailSourcePixels caps the size of the images thumbnails are made
+// from. A small, highly compressed file can declare huge dimensions, and
+// decoding it would allocate all of them.
+const maxThumbnailSourcePixels = 50_000_000
+
+var ErrImageTooLarge = errors.New("image dimensions are too large")
+
+// JPEGThumbnail decodes a JPEG, PNG or GIF image and returns a JPEG copy
+// whose longer side is at most maxSize pixels. Smaller images keep their size.
+func JPEGThumbnail(data []byte, maxSize int) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if int64(config.Width)*int64(config.Height) > maxThumbnailSourcePixels {
+		return nil, ErrImageTooLarge
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, Downscale(src, maxSize), &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Downscale shrinks src so its longer side is at most maxSize pixels,
+// averaging the source pixels behind each target pixel.
+func Downscale(src image.Image, maxSize int) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= maxSize && h <= maxSize {
+		return src
+	}
+	tw, th := maxSize, h*maxSize/w
+	if h > w {
+		tw, th = w*maxSize/h, maxSize
+	}
+	tw, th = max(tw, 1), max(th, 1)
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0, y1 := bounds.Min.Y+y*h/th, bounds.Min.Y+(y+1)*h/th
+		for x := 0; x < tw; x++ {
+			x0, x1 := bounds.Min.X+x*w/tw, bounds.Min.X+(x+1)*w/tw
+			var r, g, b, a, n uint64
+			for sy := y0; sy < max(y1, y0+1); sy++ {
+				for sx := x0; sx < max(x1, x0+1); sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a, n = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
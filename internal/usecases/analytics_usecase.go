@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/constants"
 	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
 )
 
 type AnalyticsUsecase struct {
@@ -120,6 +122,29 @@ func (u *AnalyticsUsecase) GetServiceStats(ctx context.Context) (*dto.ServiceSta
 		}
 	}
 
+	// Tickets taken before the catalog existed have no code yet, so they are
+	// matched to the catalog by name; anything still unmatched is "OTHER".
+	query = `
+		SELECT COALESCE(st.code, 'OTHER') as code, COALESCE(MAX(st.name), 'Other') as name, COUNT(*) as count
+		FROM waiting_lists w
+		LEFT JOIN service_types st ON st.deleted_at IS NULL AND (
+			st.code = w.service_type_code OR
+			(COALESCE(w.service_type_code, '') = '' AND LOWER(st.name) = LOWER(LTRIM(RTRIM(w.service_type)))))
+		WHERE w.deleted_at IS NULL
+		GROUP BY COALESCE(st.code, 'OTHER')
+		ORDER BY count DESC
+	`
+	rows, err = u.db.QueryContext(ctx, query)
+	if err == nil {
+		defer rows.Close()
+		for rows.Next() {
+			var tc dto.ServiceTypeCount
+			if err := rows.Scan(&tc.Type, &tc.Name, &tc.Count); err == nil {
+				stats.ServicesByType = append(stats.ServicesByType, tc)
+			}
+		}
+	}
+
 	query = `SELECT AVG(DATEDIFF(HOUR, service_start_at, service_end_at)) FROM waiting_lists WHERE service_end_at IS NOT NULL AND service_start_at IS NOT NULL AND deleted_at IS NULL`
 	var avgCompletion sql.NullFloat64
 	_ = u.db.QueryRowContext(ctx, query).Scan(&avgCompletion)
@@ -172,19 +197,28 @@ func (u *AnalyticsUsecase) GetQueueStats(ctx context.Context) (*dto.QueueStatsRe
 func (u *AnalyticsUsecase) GetMechanicPerformance(ctx context.Context) ([]dto.MechanicPerformanceResponse, error) {
 	performances := []dto.MechanicPerformanceResponse{}
 
-	// Note: Current schema doesn't have mechanic_id in waiting_lists
-	// This query returns mechanics with zero services until the schema is updated
+	// Efficiency compares estimated minutes with actual minutes on completed
+	// jobs that have both, so 100% means the mechanic works exactly to estimate.
 	query := `
 		SELECT u.id, u.name,
-		       0 as total_services,
-		       0 as completed_services,
-		       0.0 as avg_completion
+		       COUNT(w.id) as total_services,
+		       SUM(CASE WHEN w.status = 'completed' THEN 1 ELSE 0 END) as completed_services,
+		       AVG(CASE WHEN w.status = 'completed' AND w.service_start_at IS NOT NULL AND w.service_end_at IS NOT NULL
+		                THEN CAST(DATEDIFF(MINUTE, w.service_start_at, w.service_end_at) AS FLOAT) / 60 END) as avg_completion,
+		       SUM(CASE WHEN w.status = 'completed' AND w.estimated_time > 0 AND w.service_start_at IS NOT NULL AND w.service_end_at IS NOT NULL
+		                THEN w.estimated_time ELSE 0 END) as estimated_minutes,
+		       SUM(CASE WHEN w.status = 'completed' AND w.estimated_time > 0 AND w.service_start_at IS NOT NULL AND w.service_end_at IS NOT NULL
+		                THEN DATEDIFF(MINUTE, w.service_start_at, w.service_end_at) ELSE 0 END) as actual_minutes
 		FROM users u
-		WHERE u.role = 'mechanic' AND u.deleted_at IS NULL
+		INNER JOIN user_roles ur ON ur.user_id = u.id
+		INNER JOIN roles r ON r.id = ur.role_id AND r.name = @p1
+		LEFT JOIN waiting_lists w ON w.mechanic_id = u.id AND w.status <> 'canceled' AND w.deleted_at IS NULL
+		WHERE u.deleted_at IS NULL
 		GROUP BY u.id, u.name
+		ORDER BY u.name
 	`
 
-	rows, err := u.db.QueryContext(ctx, query)
+	rows, err := u.db.QueryContext(ctx, query, sql.Named("p1", constants.RoleMechanic))
 	if err != nil {
 		return performances, err
 	}
@@ -192,18 +226,25 @@ func (u *AnalyticsUsecase) GetMechanicPerformance(ctx context.Context) ([]dto.Me
 
 	for rows.Next() {
 		var perf dto.MechanicPerformanceResponse
+		var mechanicID types.MSSQLUUID
 		var avgCompletion sql.NullFloat64
+		var estimatedMinutes, actualMinutes int
 
-		if err := rows.Scan(&perf.MechanicID, &perf.MechanicName, &perf.TotalServices, &perf.CompletedServices, &avgCompletion); err != nil {
+		if err := rows.Scan(&mechanicID, &perf.MechanicName, &perf.TotalServices, &perf.CompletedServices, &avgCompletion, &estimatedMinutes, &actualMinutes); err != nil {
 			continue
 		}
+		perf.MechanicID = mechanicID.String()
 
 		if avgCompletion.Valid {
 			perf.AverageCompletion = avgCompletion.Float64
 		}
 
 		if perf.TotalServices > 0 {
-			perf.Efficiency = float64(perf.CompletedServices) / float64(perf.TotalServices) * 100
+			perf.CompletionRate = float64(perf.CompletedServices) / float64(perf.TotalServices) * 100
+		}
+
+		if actualMinutes > 0 {
+			perf.Efficiency = float64(estimatedMinutes) / float64(actualMinutes) * 100
 		}
 
 		perf.CustomerRating = 0.0
@@ -212,3 +253,86 @@ func (u *AnalyticsUsecase) GetMechanicPerformance(ctx context.Context) ([]dto.Me
 
 	return performances, nil
 }
+
+// GetWarrantyStats reports warranty claims per category of the original work.
+// Warranty repairs are not billed, so their cost is what the shop absorbed.
+func (u *AnalyticsUsecase) GetWarrantyStats(ctx context.Context) (*dto.WarrantyStatsResponse, error) {
+	stats := &dto.WarrantyStatsResponse{ByCategory: []dto.WarrantyCategoryStats{}}
+	byCategory := make(map[string]*dto.WarrantyCategoryStats)
+	var categories []string
+	category := func(name string) *dto.WarrantyCategoryStats {
+		if c, ok := byCategory[name]; ok {
+			return c
+		}
+		c := &dto.WarrantyCategoryStats{Category: name}
+		byCategory[name] = c
+		categories = append(categories, name)
+		return c
+	}
+
+	query := `
+		SELECT category, COUNT(*) as warranted
+		FROM maintenance_items
+		WHERE status = 'completed' AND warranty_claim_id IS NULL AND deleted_at IS NULL
+		  AND (warranty_until IS NOT NULL OR warranty_until_km > 0)
+		GROUP BY category
+		ORDER BY category
+	`
+	rows, err := u.db.QueryContext(ctx, query)
+	if err != nil {
+		return stats, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		var warranted int
+		if err := rows.Scan(&name, &warranted); err != nil {
+			continue
+		}
+		category(name).WarrantedItems = warranted
+	}
+
+	query = `
+		SELECT o.category,
+		       COUNT(c.id) as total_claims,
+		       SUM(CASE WHEN r.status = 'completed' THEN 1 ELSE 0 END) as completed_claims,
+		       SUM(CASE WHEN r.status = 'completed' THEN r.actual_cost ELSE 0 END) as absorbed_cost
+		FROM warranty_claims c
+		INNER JOIN maintenance_items o ON o.id = c.original_item_id
+		INNER JOIN maintenance_items r ON r.id = c.repair_item_id AND r.deleted_at IS NULL
+		WHERE c.deleted_at IS NULL
+		GROUP BY o.category
+	`
+	claimRows, err := u.db.QueryContext(ctx, query)
+	if err != nil {
+		return stats, err
+	}
+	defer claimRows.Close()
+	for claimRows.Next() {
+		var name string
+		var total, completed int
+		var absorbed float64
+		if err := claimRows.Scan(&name, &total, &completed, &absorbed); err != nil {
+			continue
+		}
+		c := category(name)
+		c.TotalClaims, c.CompletedClaims, c.AbsorbedCost = total, completed, roundMoney(absorbed)
+	}
+
+	for _, name := range categories {
+		c := byCategory[name]
+		if c.WarrantedItems > 0 {
+			c.ClaimRate = float64(c.TotalClaims) / float64(c.WarrantedItems) * 100
+		}
+		stats.WarrantedItems += c.WarrantedItems
+		stats.TotalClaims += c.TotalClaims
+		stats.CompletedClaims += c.CompletedClaims
+		stats.AbsorbedCost = roundMoney(stats.AbsorbedCost + c.AbsorbedCost)
+		stats.ByCategory = append(stats.ByCategory, *c)
+	}
+	if stats.WarrantedItems > 0 {
+		stats.ClaimRate = float64(stats.TotalClaims) / float64(stats.WarrantedItems) * 100
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,234 @@
+package usecases
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+// ApprovalFollowUp is how items left waiting for the customer's approval are
+// chased, read from the maintenance.approval_* settings. A zero interval
+// turns its step off.
+type ApprovalFollowUp struct {
+	RemindAfter   time.Duration // send the customer a fresh approval link
+	EscalateAfter time.Duration // ask the service advisor to phone the customer
+	AutoSkip      bool
+	SkipAfter     time.Duration // minimum wait before an item can be skipped
+}
+func (u *SettingUsecase) GetApprovalFollowUp(ctx context.Context) ApprovalFollowUp {
+	minutes := func(key string, fallback int) time.Duration {
+		return time.Duration(u.GetIntValue(ctx, key, fallback)) * time.Minute
+	}
+	return ApprovalFollowUp{
+		RemindAfter:   minutes("maintenance.approval_reminder_minutes", 60),
+		EscalateAfter: minutes("maintenance.approval_escalation_minutes", 180),
+		AutoSkip:      u.GetBoolValue(ctx, "maintenance.approval_auto_skip", false),
+		SkipAfter:     minutes("maintenance.approval_auto_skip_minutes", 240),
+	}
+}
+// NextStep returns the follow-up due for an item that has waited since
+// since, given the steps already logged on it. ticketDone reports whether
+// the rest of the ticket's work is finished, which is the only time an item
+// is skipped. Each step happens once; a skip wins over the others and an
+// escalation over a reminder, so a late run does not remind after the call.
+func (f ApprovalFollowUp) NextStep(since time.Time, done map[entities.MaintenanceItemLogAction]bool, ticketDone bool, now time.Time) (entities.MaintenanceItemLogAction, bool) {
+	waited := now.Sub(since)
+	if f.AutoSkip && ticketDone && waited >= f.SkipAfter {
+		return entities.MaintenanceItemLogAutoSkipped, true
+	}
+	if f.EscalateAfter > 0 && waited >= f.EscalateAfter && !done[entities.MaintenanceItemLogEscalated] {
+		return entities.MaintenanceItemLogEscalated, true
+	}
+	if f.RemindAfter > 0 && waited >= f.RemindAfter && !done[entities.MaintenanceItemLogReminderSent] && !done[entities.MaintenanceItemLogEscalated] {
+		return entities.MaintenanceItemLogReminderSent, true
+	}
+	return "", false
+}
+// TicketWorkDone reports whether nothing is left to do on a ticket's items
+// apart from waiting for approvals.
+func TicketWorkDone(items []*entities.MaintenanceItem) bool {
+	for _, item := range items {
+		switch item.Status {
+		case entities.MaintenanceItemStatusCompleted, entities.MaintenanceItemStatusSkipped,
+			entities.MaintenanceItemStatusCanceled, entities.MaintenanceItemStatusRejected:
+		case entities.MaintenanceItemStatusInspected:
+			if !item.RequiresApproval {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+// FollowUpApprovals runs the due follow-up for every item waiting for
+// approval and logs it on the item. It returns how many of each step were
+// taken; a failure on one ticket does not stop the others.
+func (u *MaintenanceItemUsecase) FollowUpApprovals(ctx context.Context, now time.Time) (map[entities.MaintenanceItemLogAction]int, error) {
+	rules := u.settingUsecase.GetApprovalFollowUp(ctx)
+	pending, err := u.maintenanceItemRepo.GetAllPendingApproval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	done, err := u.loggedActions(ctx, pending)
+	if err != nil {
+		return nil, err
+	}
+	var tickets []types.MSSQLUUID
+	byTicket := make(map[types.MSSQLUUID][]*entities.MaintenanceItem)
+	for _, item := range pending {
+		if item.WaitingList == nil {
+			continue // ticket deleted
+		}
+		if _, ok := byTicket[item.WaitingListID]; !ok {
+			tickets = append(tickets, item.WaitingListID)
+		}
+		byTicket[item.WaitingListID] = append(byTicket[item.WaitingListID], item)
+	}
+	taken := make(map[entities.MaintenanceItemLogAction]int)
+	var errs []error
+	for _, waitingListID := range tickets {
+		all, err := u.maintenanceItemRepo.GetByWaitingListID(ctx, waitingListID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ticketDone := TicketWorkDone(all)
+		var reminders []*entities.MaintenanceItem
+		for _, item := range byTicket[waitingListID] {
+			since := item.CreatedAt
+			if item.InspectedAt != nil {
+				since = *item.InspectedAt
+			}
+			step, ok := rules.NextStep(since, done[item.ID], ticketDone, now)
+			if !ok {
+				continue
+			}
+			waited := now.Sub(since).Round(time.Minute)
+			log := &entities.MaintenanceItemLog{MaintenanceItemID: item.ID, WaitingListID: waitingListID, Action: step}
+			switch step {
+			case entities.MaintenanceItemLogReminderSent:
+				reminders = append(reminders, item)
+				continue
+			case entities.MaintenanceItemLogEscalated:
+				customer := item.WaitingList.Customer
+				log.Message = fmt.Sprintf("No answer after %s; call %s on %s", waited, customer.Name, customer.Phone)
+				err = u.logRepo.Create(ctx, log)
+			case entities.MaintenanceItemLogAutoSkipped:
+				log.Message = fmt.Sprintf("Skipped after %s without an answer; the rest of the work is done", waited)
+				err = u.logRepo.Skip(ctx, log)
+				if errors.Is(err, repositories.ErrItemNotAwaitingApproval) {
+					continue // decided while the job was running
+				}
+			}
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			taken[step]++
+		}
+		if len(reminders) == 0 {
+			continue
+		}
+		// One link covers every pending item of the ticket, so one reminder is
+		// sent per ticket and logged on each item it was due for.
+		if _, err := u.SendApprovalLink(ctx, waitingListID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remind ticket %s: %w", waitingListID, err))
+			continue
+		}
+		for _, item := range reminders {
+			log := &entities.MaintenanceItemLog{
+				MaintenanceItemID: item.ID,
+				WaitingListID:     waitingListID,
+				Action:            entities.MaintenanceItemLogReminderSent,
+				Message:           "Sent the customer a new approval link",
+			}
+			if err := u.logRepo.Create(ctx, log); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			taken[entities.MaintenanceItemLogReminderSent]++
+		}
+	}
+	return taken, errors.Join(errs...)
+}
+// GetApprovalCalls lists the service advisor's phone-call tasks: tickets with
+// items that were escalated and are still waiting for an answer. A task is
+// closed by recording the customer's decisions over the phone.
+func (u *MaintenanceItemUsecase) GetApprovalCalls(ctx context.Context) ([]dto.ApprovalCallResponse, error) {
+	pending, err := u.maintenanceItemRepo.GetAllPendingApproval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := u.logRepo.GetByItemIDs(ctx, itemIDs(pending))
+	if err != nil {
+		return nil, err
+	}
+	escalatedAt := make(map[types.MSSQLUUID]time.Time)
+	for _, log := range logs {
+		if log.Action == entities.MaintenanceItemLogEscalated {
+			if _, ok := escalatedAt[log.MaintenanceItemID]; !ok {
+				escalatedAt[log.MaintenanceItemID] = log.CreatedAt
+			}
+		}
+	}
+	calls := make([]dto.ApprovalCallResponse, 0)
+	index := make(map[types.MSSQLUUID]int)
+	for _, item := range pending {
+		at, ok := escalatedAt[item.ID]
+		if !ok || item.WaitingList == nil {
+			continue
+		}
+		i, ok := index[item.WaitingListID]
+		if !ok {
+			waitingList := item.WaitingList
+			calls = append(calls, dto.ApprovalCallResponse{
+				WaitingListID: item.WaitingListID,
+				QueueNumber:   waitingList.QueueNumber,
+				CustomerName:  waitingList.Customer.Name,
+				CustomerPhone: waitingList.Customer.Phone,
+				LicensePlate:  waitingList.Vehicle.LicensePlate,
+				EscalatedAt:   at,
+			})
+			i = len(calls) - 1
+			index[item.WaitingListID] = i
+		}
+		if at.Before(calls[i].EscalatedAt) {
+			calls[i].EscalatedAt = at
+		}
+		calls[i].Items = append(calls[i].Items, u.buildItemResponses([]*entities.MaintenanceItem{item})...)
+		calls[i].TotalEstimatedCost = roundMoney(calls[i].TotalEstimatedCost + item.EstimatedCost)
+	}
+	return calls, nil
+}
+func (u *MaintenanceItemUsecase) GetItemLogs(ctx context.Context, itemID types.MSSQLUUID) ([]*entities.MaintenanceItemLog, error) {
+	if _, err := u.maintenanceItemRepo.GetByID(ctx, itemID); err != nil {
+		return nil, errors.New("item not found")
+	}
+	return u.logRepo.GetByItemID(ctx, itemID)
+}
+// loggedActions returns, per item, the follow-up steps already taken.
+func (u *MaintenanceItemUsecase) loggedActions(ctx context.Context, items []*entities.MaintenanceItem) (map[types.MSSQLUUID]map[entities.MaintenanceItemLogAction]bool, error) {
+	logs, err := u.logRepo.GetByItemIDs(ctx, itemIDs(items))
+	if err != nil {
+		return nil, err
+	}
+	done := make(map[types.MSSQLUUID]map[entities.MaintenanceItemLogAction]bool)
+	for _, log := range logs {
+		if done[log.MaintenanceItemID] == nil {
+			done[log.MaintenanceItemID] = make(map[entities.MaintenanceItemLogAction]bool)
+		}
+		done[log.MaintenanceItemID][log.Action] = true
+	}
+	return done, nil
+}
+func itemIDs(items []*entities.MaintenanceItem) []types.MSSQLUUID {
+	ids := make([]types.MSSQLUUID, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
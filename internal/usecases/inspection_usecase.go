@@ -0,0 +1,519 @@
+package usecases
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/domain/services"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+var (
+	ErrInspectionForbidden  = errors.New("not allowed to see this inspection")
+	ErrInspectionCompleted  = errors.New("inspection is already completed")
+	ErrFindingsAlreadyAdded = errors.New("findings of this inspection were already added")
+)
+type InspectionUsecase struct {
+	templateRepo           repositories.InspectionTemplateRepository
+	inspectionRepo         repositories.InspectionRepository
+	waitingListRepo        repositories.WaitingListRepository
+	maintenanceItemUsecase *MaintenanceItemUsecase
+	storage                services.FileStorage
+}
+func NewInspectionUsecase(
+	templateRepo repositories.InspectionTemplateRepository,
+	inspectionRepo repositories.InspectionRepository,
+	waitingListRepo repositories.WaitingListRepository,
+	maintenanceItemUsecase *MaintenanceItemUsecase,
+	storage services.FileStorage,
+) *InspectionUsecase {
+	return &InspectionUsecase{
+		templateRepo:           templateRepo,
+		inspectionRepo:         inspectionRepo,
+		waitingListRepo:        waitingListRepo,
+		maintenanceItemUsecase: maintenanceItemUsecase,
+		storage:                storage,
+	}
+}
+func (u *InspectionUsecase) CreateTemplate(ctx context.Context, req *dto.InspectionTemplateRequest) (*entities.InspectionTemplate, error) {
+	template, err := buildInspectionTemplate(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+func (u *InspectionUsecase) GetTemplate(ctx context.Context, id types.MSSQLUUID) (*entities.InspectionTemplate, error) {
+	template, err := u.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, errors.New("inspection template not found")
+	}
+	return template, nil
+}
+func (u *InspectionUsecase) GetAllTemplates(ctx context.Context) ([]*entities.InspectionTemplate, error) {
+	return u.templateRepo.GetAll(ctx)
+}
+func (u *InspectionUsecase) GetActiveTemplates(ctx context.Context) ([]*entities.InspectionTemplate, error) {
+	return u.templateRepo.GetActive(ctx)
+}
+// UpdateTemplate replaces the template's settings and points. Inspections
+// already started keep the points they were started with.
+func (u *InspectionUsecase) UpdateTemplate(ctx context.Context, id types.MSSQLUUID, req *dto.InspectionTemplateRequest) (*entities.InspectionTemplate, error) {
+	existing, err := u.GetTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	template, err := buildInspectionTemplate(req)
+	if err != nil {
+		return nil, err
+	}
+	template.ID = existing.ID
+	template.CreatedAt = existing.CreatedAt
+	if err := u.templateRepo.Update(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+func (u *InspectionUsecase) DeleteTemplate(ctx context.Context, id types.MSSQLUUID) error {
+	if _, err := u.GetTemplate(ctx, id); err != nil {
+		return err
+	}
+	return u.templateRepo.Delete(ctx, id)
+}
+func buildInspectionTemplate(req *dto.InspectionTemplateRequest) (*entities.InspectionTemplate, error) {
+	template := &entities.InspectionTemplate{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		IsActive:    req.IsActive == nil || *req.IsActive,
+	}
+	if template.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if len(req.Points) == 0 {
+		return nil, errors.New("a template needs at least one point")
+	}
+	for i, point := range req.Points {
+		category, name := strings.TrimSpace(point.Category), strings.TrimSpace(point.Name)
+		if category == "" || name == "" {
+			return nil, fmt.Errorf("point %d: category and name are required", i+1)
+		}
+		template.Points = append(template.Points, entities.InspectionTemplatePoint{
+			Position: i,
+			Category: category,
+			Name:     name,
+			Unit:     strings.TrimSpace(point.Unit),
+		})
+	}
+	return template, nil
+}
+// StartInspection opens a checklist for a ticket in service, with every
+// point of the template unchecked. A ticket has one inspection.
+func (u *InspectionUsecase) StartInspection(ctx context.Context, mechanicID types.MSSQLUUID, req dto.StartInspectionRequest) (*dto.InspectionResponse, error) {
+	waitingList, err := u.waitingListRepo.GetByID(ctx, req.WaitingListID)
+	if err != nil {
+		return nil, errors.New("waiting list not found")
+	}
+	if waitingList.Status != entities.WaitingListStatusInService {
+		return nil, errors.New("service must be in progress to inspect the vehicle")
+	}
+	existing, err := u.inspectionRepo.GetByWaitingListID(ctx, waitingList.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("ticket already has an inspection")
+	}
+	template, err := u.GetTemplate(ctx, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if !template.IsActive {
+		return nil, errors.New("inspection template is not active")
+	}
+	inspection := &entities.Inspection{
+		WaitingListID: waitingList.ID,
+		TemplateID:    template.ID,
+		TemplateName:  template.Name,
+		MechanicID:    mechanicID,
+	}
+	for _, point := range template.Points {
+		inspection.Results = append(inspection.Results, entities.InspectionResult{
+			Position: point.Position,
+			Category: point.Category,
+			Name:     point.Name,
+			Unit:     point.Unit,
+		})
+	}
+	if err := u.inspectionRepo.Create(ctx, inspection); err != nil {
+		return nil, err
+	}
+	return u.GetInspection(ctx, inspection.ID)
+}
+func (u *InspectionUsecase) GetInspection(ctx context.Context, id types.MSSQLUUID) (*dto.InspectionResponse, error) {
+	inspection, err := u.getInspection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return BuildInspectionResponse(inspection), nil
+}
+func (u *InspectionUsecase) GetInspectionByWaitingList(ctx context.Context, waitingListID types.MSSQLUUID) (*dto.InspectionResponse, error) {
+	inspection, err := u.inspectionRepo.GetByWaitingListID(ctx, waitingListID)
+	if err != nil {
+		return nil, err
+	}
+	if inspection == nil {
+		return nil, errors.New("inspection not found")
+	}
+	return BuildInspectionResponse(inspection), nil
+}
+// RecordResults sets the outcome of the given points. Points left out keep
+// their current result, so the form can be saved as the mechanic goes.
+func (u *InspectionUsecase) RecordResults(ctx context.Context, id types.MSSQLUUID, req dto.RecordInspectionResultsRequest) (*dto.InspectionResponse, error) {
+	inspection, err := u.editableInspection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[types.MSSQLUUID]*entities.InspectionResult, len(inspection.Results))
+	for i := range inspection.Results {
+		results[inspection.Results[i].ID] = &inspection.Results[i]
+	}
+	changed := make([]*entities.InspectionResult, 0, len(req.Results))
+	for _, entry := range req.Results {
+		result, ok := results[entry.ResultID]
+		if !ok {
+			return nil, fmt.Errorf("result %s is not part of this inspection", entry.ResultID)
+		}
+		status := entities.InspectionResultStatus(strings.ToLower(strings.TrimSpace(entry.Status)))
+		if !status.IsValid() {
+			return nil, fmt.Errorf("%s: status must be green, yellow or red", result.Name)
+		}
+		if entry.Measurement != nil && *entry.Measurement < 0 {
+			return nil, fmt.Errorf("%s: measurement cannot be negative", result.Name)
+		}
+		result.Status = status
+		result.Measurement = entry.Measurement
+		result.Notes = entry.Notes
+		changed = append(changed, result)
+	}
+	if req.Notes != nil {
+		inspection.Notes = *req.Notes
+	}
+	if err := u.inspectionRepo.Update(ctx, inspection, changed); err != nil {
+		return nil, err
+	}
+	return BuildInspectionResponse(inspection), nil
+}
+// CompleteInspection closes the checklist once every point is checked,
+// which publishes the report to the customer.
+func (u *InspectionUsecase) CompleteInspection(ctx context.Context, id types.MSSQLUUID) (*dto.InspectionResponse, error) {
+	inspection, err := u.editableInspection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, _, unchecked := CountInspectionResults(inspection.Results); unchecked > 0 {
+		return nil, fmt.Errorf("%d point(s) are not checked yet", unchecked)
+	}
+	now := time.Now()
+	inspection.CompletedAt = &now
+	if err := u.inspectionRepo.Update(ctx, inspection, nil); err != nil {
+		return nil, err
+	}
+	return BuildInspectionResponse(inspection), nil
+}
+// CreateFindings turns every red and yellow result that has no maintenance
+// item yet into a discovered item waiting for the customer's approval. It
+// runs once per inspection, so a retried submit cannot duplicate the items.
+func (u *InspectionUsecase) CreateFindings(ctx context.Context, mechanicID, id types.MSSQLUUID) ([]*entities.MaintenanceItem, error) {
+	inspection, err := u.getInspection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if inspection.FindingsAt != nil {
+		return nil, ErrFindingsAlreadyAdded
+	}
+	var reqs []dto.AddDiscoveredItemRequest
+	var findings []*entities.InspectionResult
+	for i := range inspection.Results {
+		result := &inspection.Results[i]
+		req, ok := FindingItemRequest(inspection.WaitingListID, result, inspectionPhotoURL(inspection.ID, result))
+		if !ok {
+			continue
+		}
+		reqs = append(reqs, req)
+		findings = append(findings, result)
+	}
+	if len(reqs) == 0 {
+		return nil, errors.New("no red or yellow findings left to add")
+	}
+	waitingList, items, err := u.maintenanceItemUsecase.newDiscoveredItems(ctx, mechanicID, inspection.WaitingListID, reqs)
+	if err != nil {
+		return nil, err
+	}
+	err = u.inspectionRepo.AddFindings(ctx, inspection, findings, items)
+	if errors.Is(err, repositories.ErrFindingsAlreadyAdded) {
+		return nil, ErrFindingsAlreadyAdded
+	}
+	if err != nil {
+		return nil, err
+	}
+	u.maintenanceItemUsecase.discoveredItemsAdded(ctx, waitingList, items)
+	return items, nil
+}
+// UploadPhoto stores a photo for one result, replacing any earlier one.
+func (u *InspectionUsecase) UploadPhoto(ctx context.Context, id, resultID types.MSSQLUUID, size int64, content io.Reader) (*dto.InspectionResultResponse, error) {
+	inspection, err := u.getInspection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	result, err := findInspectionResult(inspection, resultID)
+	if err != nil {
+		return nil, err
+	}
+	contentType, content, err := sniffContentType(content)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := ClassifyAttachment(contentType, size)
+	if err != nil {
+		return nil, err
+	}
+	if kind != entities.AttachmentKindPhoto {
+		return nil, fmt.Errorf("%w: inspection results take photos only", ErrAttachmentTypeNotAllowed)
+	}
+	previous := result.PhotoKey
+	result.PhotoKey = fmt.Sprintf("inspections/%s/%s.%s", inspection.ID, result.ID, strings.TrimPrefix(contentType, "image/"))
+	result.PhotoContentType = contentType
+	if err := u.storage.Save(ctx, result.PhotoKey, content); err != nil {
+		return nil, err
+	}
+	if err := u.inspectionRepo.Update(ctx, inspection, []*entities.InspectionResult{result}); err != nil {
+		return nil, err
+	}
+	if previous != "" && previous != result.PhotoKey {
+		_ = u.storage.Delete(ctx, previous)
+	}
+	resp := buildInspectionResultResponse(inspection.ID, result)
+	return &resp, nil
+}
+// OpenPhoto returns a result's photo and its content type. Customers may
+// only open photos of their own tickets. The caller must close the reader.
+func (u *InspectionUsecase) OpenPhoto(ctx context.Context, userID types.MSSQLUUID, role string, id, resultID types.MSSQLUUID) (string, io.ReadCloser, error) {
+	inspection, err := u.getInspection(ctx, id)
+	if err != nil {
+		return "", nil, err
+	}
+	if !isStaff(role) {
+		waitingList, err := u.waitingListRepo.GetByID(ctx, inspection.WaitingListID)
+		if err != nil {
+			return "", nil, errors.New("waiting list not found")
+		}
+		if !canViewTicket(waitingList, userID, role) {
+			return "", nil, ErrInspectionForbidden
+		}
+	}
+	result, err := findInspectionResult(inspection, resultID)
+	if err != nil {
+		return "", nil, err
+	}
+	if result.PhotoKey == "" {
+		return "", nil, fmt.Errorf("%w: result has no photo", services.ErrFileNotFound)
+	}
+	content, err := u.storage.Open(ctx, result.PhotoKey)
+	if err != nil {
+		return "", nil, err
+	}
+	return result.PhotoContentType, content, nil
+}
+// GetReport is the customer-facing inspection report of a ticket. Customers
+// see it once the inspection is completed; staff can see it any time.
+func (u *InspectionUsecase) GetReport(ctx context.Context, userID types.MSSQLUUID, role string, waitingListID types.MSSQLUUID) (*dto.InspectionReportResponse, error) {
+	waitingList, err := u.waitingListRepo.GetByID(ctx, waitingListID)
+	if err != nil {
+		return nil, errors.New("waiting list not found")
+	}
+	if !canViewTicket(waitingList, userID, role) {
+		return nil, ErrInspectionForbidden
+	}
+	inspection, err := u.inspectionRepo.GetByWaitingListID(ctx, waitingListID)
+	if err != nil {
+		return nil, err
+	}
+	if inspection == nil || (inspection.CompletedAt == nil && !isStaff(role)) {
+		return nil, errors.New("inspection report is not available yet")
+	}
+	green, yellow, red, _ := CountInspectionResults(inspection.Results)
+	report := &dto.InspectionReportResponse{
+		WaitingListID: waitingList.ID,
+		QueueNumber:   waitingList.QueueNumber,
+		VehicleBrand:  waitingList.Vehicle.Brand,
+		VehicleModel:  waitingList.Vehicle.Model,
+		LicensePlate:  waitingList.Vehicle.LicensePlate,
+		Notes:         inspection.Notes,
+		Green:         green,
+		Yellow:        yellow,
+		Red:           red,
+		Categories:    GroupInspectionResults(BuildInspectionResponse(inspection).Results),
+		InspectedAt:   inspection.CreatedAt,
+	}
+	if inspection.CompletedAt != nil {
+		report.InspectedAt = *inspection.CompletedAt
+	}
+	if inspection.Mechanic != nil {
+		report.MechanicName = inspection.Mechanic.Name
+	}
+	return report, nil
+}
+func (u *InspectionUsecase) getInspection(ctx context.Context, id types.MSSQLUUID) (*entities.Inspection, error) {
+	inspection, err := u.inspectionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if inspection == nil {
+		return nil, errors.New("inspection not found")
+	}
+	return inspection, nil
+}
+func (u *InspectionUsecase) editableInspection(ctx context.Context, id types.MSSQLUUID) (*entities.Inspection, error) {
+	inspection, err := u.getInspection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if inspection.CompletedAt != nil {
+		return nil, ErrInspectionCompleted
+	}
+	return inspection, nil
+}
+func findInspectionResult(inspection *entities.Inspection, resultID types.MSSQLUUID) (*entities.InspectionResult, error) {
+	for i := range inspection.Results {
+		if inspection.Results[i].ID == resultID {
+			return &inspection.Results[i], nil
+		}
+	}
+	return nil, errors.New("inspection result not found")
+}
+// FindingItemRequest is the discovered item a red or yellow result becomes.
+// It reports false for green and unchecked results and for results that
+// already have an item.
+func FindingItemRequest(waitingListID types.MSSQLUUID, result *entities.InspectionResult, photoURL string) (dto.AddDiscoveredItemRequest, bool) {
+	if result.MaintenanceItemID != nil {
+		return dto.AddDiscoveredItemRequest{}, false
+	}
+	priority := ""
+	switch result.Status {
+	case entities.InspectionResultRed:
+		priority = "urgent"
+	case entities.InspectionResultYellow:
+		priority = "normal"
+	default:
+		return dto.AddDiscoveredItemRequest{}, false
+	}
+	description := fmt.Sprintf("%s: %s", result.Name, result.Status)
+	if result.Measurement != nil {
+		description = fmt.Sprintf("%s: %s %s (%s)", result.Name, strconv.FormatFloat(*result.Measurement, 'f', -1, 64), result.Unit, result.Status)
+	}
+	return dto.AddDiscoveredItemRequest{
+		WaitingListID:    waitingListID,
+		Category:         result.Category,
+		Name:             result.Name,
+		Description:      description,
+		Priority:         priority,
+		RequiresApproval: true,
+		ImageURL:         photoURL,
+		Notes:            result.Notes,
+	}, true
+}
+// CountInspectionResults counts the results of each colour and those not
+// checked yet.
+func CountInspectionResults(results []entities.InspectionResult) (green, yellow, red, unchecked int) {
+	for _, result := range results {
+		switch result.Status {
+		case entities.InspectionResultGreen:
+			green++
+		case entities.InspectionResultYellow:
+			yellow++
+		case entities.InspectionResultRed:
+			red++
+		default:
+			unchecked++
+		}
+	}
+	return green, yellow, red, unchecked
+}
+// GroupInspectionResults groups results by category in the order the
+// categories first appear, each with the worst status among its results.
+func GroupInspectionResults(results []dto.InspectionResultResponse) []dto.InspectionCategoryResponse {
+	severity := map[string]int{
+		string(entities.InspectionResultGreen):  1,
+		string(entities.InspectionResultYellow): 2,
+		string(entities.InspectionResultRed):    3,
+	}
+	categories := make([]dto.InspectionCategoryResponse, 0)
+	index := make(map[string]int)
+	for _, result := range results {
+		i, ok := index[result.Category]
+		if !ok {
+			categories = append(categories, dto.InspectionCategoryResponse{Category: result.Category})
+			i = len(categories) - 1
+			index[result.Category] = i
+		}
+		if severity[result.Status] > severity[categories[i].Status] {
+			categories[i].Status = result.Status
+		}
+		categories[i].Results = append(categories[i].Results, result)
+	}
+	return categories
+}
+func BuildInspectionResponse(inspection *entities.Inspection) *dto.InspectionResponse {
+	green, yellow, red, unchecked := CountInspectionResults(inspection.Results)
+	resp := &dto.InspectionResponse{
+		ID:            inspection.ID,
+		WaitingListID: inspection.WaitingListID,
+		TemplateID:    inspection.TemplateID,
+		TemplateName:  inspection.TemplateName,
+		MechanicID:    inspection.MechanicID,
+		Notes:         inspection.Notes,
+		Green:         green,
+		Yellow:        yellow,
+		Red:           red,
+		Unchecked:     unchecked,
+		Results:       make([]dto.InspectionResultResponse, len(inspection.Results)),
+		CompletedAt:   inspection.CompletedAt,
+		CreatedAt:     inspection.CreatedAt,
+	}
+	if inspection.Mechanic != nil {
+		resp.MechanicName = inspection.Mechanic.Name
+	}
+	for i := range inspection.Results {
+		resp.Results[i] = buildInspectionResultResponse(inspection.ID, &inspection.Results[i])
+	}
+	return resp
+}
+func buildInspectionResultResponse(inspectionID types.MSSQLUUID, result *entities.InspectionResult) dto.InspectionResultResponse {
+	return dto.InspectionResultResponse{
+		ID:                result.ID,
+		Category:          result.Category,
+		Name:              result.Name,
+		Status:            string(result.Status),
+		Measurement:       result.Measurement,
+		Unit:              result.Unit,
+		Notes:             result.Notes,
+		PhotoURL:          inspectionPhotoURL(inspectionID, result),
+		MaintenanceItemID: result.MaintenanceItemID,
+	}
+}
+func inspectionPhotoURL(inspectionID types.MSSQLUUID, result *entities.InspectionResult) string {
+	if result.PhotoKey == "" {
+		return ""
+	}
+	return fmt.Sprintf("/api/v1/maintenance/inspections/%s/results/%s/photo", inspectionID, result.ID)
+}
@@ -0,0 +1,226 @@
+package usecases
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/domain/services"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/shared/utils"
+)
+var (
+	// ErrKioskUnauthorized is returned for unknown or revoked kiosk tokens.
+	ErrKioskUnauthorized = errors.New("invalid or revoked kiosk token")
+	// ErrVehicleNotRegistered is returned when no vehicle matches a license plate.
+	ErrVehicleNotRegistered = errors.New("no vehicle registered with this license plate")
+	// ErrVehicleAlreadyRegistered is returned when a guest is created for a
+	// license plate that already belongs to a customer.
+	ErrVehicleAlreadyRegistered = errors.New("vehicle is already registered")
+)
+type KioskUsecase struct {
+	kioskRepo          repositories.KioskDeviceRepository
+	vehicleRepo        repositories.VehicleRepository
+	waitingListUsecase *WaitingListUsecase
+	authService        services.AuthService
+	hasher             *utils.HashService
+}
+func NewKioskUsecase(
+	kioskRepo repositories.KioskDeviceRepository,
+	vehicleRepo repositories.VehicleRepository,
+	waitingListUsecase *WaitingListUsecase,
+	authService services.AuthService,
+) *KioskUsecase {
+	return &KioskUsecase{
+		kioskRepo:          kioskRepo,
+		vehicleRepo:        vehicleRepo,
+		waitingListUsecase: waitingListUsecase,
+		authService:        authService,
+		hasher:             utils.NewHashService(),
+	}
+}
+// RegisterDevice creates a kiosk and returns its token. The token is only
+// available here; the device stores a hash of it.
+func (u *KioskUsecase) RegisterDevice(ctx context.Context, name string) (*entities.KioskDevice, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", errors.New("name is required")
+	}
+	secret, err := u.hasher.GenerateRandomToken(32)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate kiosk token: %w", err)
+	}
+	token := "kiosk_" + secret
+	device := &entities.KioskDevice{
+		Name:      name,
+		TokenHash: u.hasher.HashSHA256(token),
+		CreatedBy: actorFromContext(ctx),
+	}
+	if err := u.kioskRepo.Create(ctx, device); err != nil {
+		return nil, "", err
+	}
+	return device, token, nil
+}
+func (u *KioskUsecase) GetDevices(ctx context.Context) ([]*entities.KioskDevice, error) {
+	return u.kioskRepo.GetAll(ctx)
+}
+func (u *KioskUsecase) GetDevice(ctx context.Context, id types.MSSQLUUID) (*entities.KioskDevice, error) {
+	device, err := u.kioskRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if device == nil {
+		return nil, errors.New("kiosk device not found")
+	}
+	return device, nil
+}
+func (u *KioskUsecase) RevokeDevice(ctx context.Context, id types.MSSQLUUID) error {
+	device, err := u.GetDevice(ctx, id)
+	if err != nil {
+		return err
+	}
+	if device.IsRevoked() {
+		return nil
+	}
+	now := time.Now()
+	device.RevokedAt = &now
+	return u.kioskRepo.Update(ctx, device)
+}
+func (u *KioskUsecase) GetActivity(ctx context.Context, id types.MSSQLUUID, limit int) ([]*entities.KioskActivity, error) {
+	if _, err := u.GetDevice(ctx, id); err != nil {
+		return nil, err
+	}
+	return u.kioskRepo.GetActivity(ctx, id, limit)
+}
+// AuthenticateKiosk resolves a kiosk token to its device ID.
+func (u *KioskUsecase) AuthenticateKiosk(ctx context.Context, token string) (types.MSSQLUUID, error) {
+	device, err := u.kioskRepo.GetByTokenHash(ctx, u.hasher.HashSHA256(token))
+	if err != nil {
+		return types.MSSQLUUID{}, err
+	}
+	if device == nil || device.IsRevoked() {
+		return types.MSSQLUUID{}, ErrKioskUnauthorized
+	}
+	now := time.Now()
+	if device.LastSeenAt == nil || now.Sub(*device.LastSeenAt) > time.Minute {
+		device.LastSeenAt = &now
+		_ = u.kioskRepo.Update(ctx, device)
+	}
+	return device.ID, nil
+}
+// LookupVehicle finds the vehicle for a license plate typed at a kiosk.
+func (u *KioskUsecase) LookupVehicle(ctx context.Context, deviceID types.MSSQLUUID, plate string) (*entities.Vehicle, error) {
+	vehicle, err := u.vehicleRepo.GetByLicensePlate(ctx, plate)
+	if err != nil {
+		return nil, err
+	}
+	activity := &entities.KioskActivity{KioskDeviceID: deviceID, Action: entities.KioskActionLookupVehicle, LicensePlate: plate}
+	if vehicle != nil {
+		activity.CustomerID = &vehicle.OwnerID
+	}
+	if err := u.recordActivity(ctx, activity); err != nil {
+		return nil, err
+	}
+	if vehicle == nil {
+		return nil, ErrVehicleNotRegistered
+	}
+	return vehicle, nil
+}
+// TakeQueue takes a walk-in ticket for today on behalf of the owner of the
+// vehicle with the given plate. Every attempt is recorded as kiosk activity,
+// including failed ones.
+func (u *KioskUsecase) TakeQueue(ctx context.Context, deviceID types.MSSQLUUID, plate string, waitingList *entities.WaitingList) error {
+	activity := &entities.KioskActivity{KioskDeviceID: deviceID, Action: entities.KioskActionTakeQueue, LicensePlate: plate}
+	err := u.takeQueue(ctx, deviceID, plate, waitingList, activity)
+	if err != nil {
+		activity.Error = truncate(err.Error(), 500)
+		_ = u.recordActivity(ctx, activity)
+		return err
+	}
+	return u.recordActivity(ctx, activity)
+}
+func (u *KioskUsecase) takeQueue(ctx context.Context, deviceID types.MSSQLUUID, plate string, waitingList *entities.WaitingList, activity *entities.KioskActivity) error {
+	device, err := u.GetDevice(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+	vehicle, err := u.vehicleRepo.GetByLicensePlate(ctx, plate)
+	if err != nil {
+		return err
+	}
+	if vehicle == nil {
+		return ErrVehicleNotRegistered
+	}
+	activity.LicensePlate = vehicle.LicensePlate
+	activity.CustomerID = &vehicle.OwnerID
+	now := time.Now()
+	waitingList.VehicleID = vehicle.ID
+	waitingList.CustomerID = vehicle.OwnerID
+	waitingList.ServiceDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	ctx = WithTransitionReason(ctx, fmt.Sprintf("taken at kiosk %s", device.Name))
+	if err := u.waitingListUsecase.TakeQueueNumber(ctx, waitingList); err != nil {
+		return err
+	}
+	activity.WaitingListID = &waitingList.ID
+	return nil
+}
+// CreateGuest registers a walk-in customer and their vehicle. Guests get an
+// unusable password and cannot log in.
+func (u *KioskUsecase) CreateGuest(ctx context.Context, deviceID types.MSSQLUUID, guest *entities.User, vehicle *entities.Vehicle) error {
+	guest.Name = strings.TrimSpace(guest.Name)
+	vehicle.LicensePlate = strings.ToUpper(strings.TrimSpace(vehicle.LicensePlate))
+	if guest.Name == "" || vehicle.LicensePlate == "" {
+		return errors.New("name and license plate are required")
+	}
+	existing, err := u.vehicleRepo.GetByLicensePlate(ctx, vehicle.LicensePlate)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return ErrVehicleAlreadyRegistered
+	}
+	secret, err := u.hasher.GenerateRandomToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate guest password: %w", err)
+	}
+	password, err := u.authService.HashPassword(secret)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	guest.Email = fmt.Sprintf("guest-%s@kiosk.local", types.NewMSSQLUUID().String())
+	guest.Password = password
+	guest.IsGuest = true
+	if err := u.vehicleRepo.CreateWithOwner(ctx, guest, vehicle); err != nil {
+		return err
+	}
+	return u.recordActivity(ctx, &entities.KioskActivity{
+		KioskDeviceID: deviceID,
+		Action:        entities.KioskActionCreateGuest,
+		LicensePlate:  vehicle.LicensePlate,
+		CustomerID:    &guest.ID,
+	})
+}
+func (u *KioskUsecase) recordActivity(ctx context.Context, activity *entities.KioskActivity) error {
+	if err := u.kioskRepo.CreateActivity(ctx, activity); err != nil {
+		return fmt.Errorf("failed to record kiosk activity: %w", err)
+	}
+	return nil
+}
+// truncate cuts s to at most n runes.
+func truncate(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
+// MaskName keeps the first letter of each word, e.g. "Budi Santoso" becomes
+// "B*** S***", so a kiosk can confirm the owner without showing their name.
+func MaskName(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		words[i] = string([]rune(word)[0]) + "***"
+	}
+	return strings.Join(words, " ")
+}
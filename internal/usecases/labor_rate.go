@@ -0,0 +1,68 @@
+package usecases
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+// LaborRates is the shop's labor pricing, read from the labor.* settings.
+type LaborRates struct {
+	DefaultRate        float64            // per hour
+	CategoryRates      map[string]float64 // per hour, keyed by lower-case item category
+	SkillMultipliers   map[string]float64 // keyed by lower-case mechanic skill level
+	OvertimeMultiplier float64            // outside business hours
+	WeekendMultiplier  float64            // Saturday and Sunday
+}
+func (u *SettingUsecase) GetLaborRates(ctx context.Context) LaborRates {
+	return LaborRates{
+		DefaultRate:        u.GetFloatValue(ctx, "labor.default_rate", 100000),
+		CategoryRates:      ParseRateList(u.GetStringValue(ctx, "labor.category_rates", "")),
+		SkillMultipliers:   ParseRateList(u.GetStringValue(ctx, "labor.skill_multipliers", "junior:0.8,senior:1.25,master:1.5")),
+		OvertimeMultiplier: u.GetFloatValue(ctx, "labor.overtime_multiplier", 1.5),
+		WeekendMultiplier:  u.GetFloatValue(ctx, "labor.weekend_multiplier", 1.5),
+	}
+}
+// ParseRateList reads comma-separated name:value pairs such as
+// "Engine:150000,Brakes:120000" into a map keyed by lower-case name.
+// Malformed and negative entries are skipped.
+func ParseRateList(value string) map[string]float64 {
+	rates := make(map[string]float64)
+	for _, entry := range strings.Split(value, ",") {
+		name, raw, ok := strings.Cut(entry, ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" {
+			continue
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil || rate < 0 {
+			continue
+		}
+		rates[name] = rate
+	}
+	return rates
+}
+// HourlyRate is the rate for work on an item of category by a mechanic of
+// skillLevel at time at. The category rate replaces the default rate and the
+// skill multiplier scales it. Overtime and weekend premiums do not stack;
+// the higher one applies.
+func (r LaborRates) HourlyRate(category, skillLevel string, at time.Time, hours BusinessHours) float64 {
+	rate := r.DefaultRate
+	if categoryRate, ok := r.CategoryRates[strings.ToLower(strings.TrimSpace(category))]; ok {
+		rate = categoryRate
+	}
+	if multiplier, ok := r.SkillMultipliers[strings.ToLower(strings.TrimSpace(skillLevel))]; ok {
+		rate *= multiplier
+	}
+	premium := 1.0
+	if at.Weekday() == time.Saturday || at.Weekday() == time.Sunday {
+		premium = math.Max(premium, r.WeekendMultiplier)
+	}
+	if at.Before(hours.OpenAt(at)) || !at.Before(hours.CloseAt(at)) {
+		premium = math.Max(premium, r.OvertimeMultiplier)
+	}
+	return roundMoney(rate * premium)
+}
+func LaborCost(laborHours, rate float64) float64 {
+	return roundMoney(laborHours * rate)
+}
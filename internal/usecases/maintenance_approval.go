@@ -0,0 +1,195 @@
+package usecases
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/shared/utils"
+)
+// ErrApprovalLinkUsed is returned for an approval link whose decisions were
+// already submitted.
+var ErrApprovalLinkUsed = errors.New("approval link has already been used")
+// SendApprovalLink issues a new approval link for the ticket, which revokes
+// any earlier link, and sends it to the customer.
+func (u *MaintenanceItemUsecase) SendApprovalLink(ctx context.Context, waitingListID types.MSSQLUUID) (*dto.ApprovalLinkResponse, error) {
+	waitingList, err := u.waitingListRepo.GetByID(ctx, waitingListID)
+	if err != nil {
+		return nil, errors.New("waiting list not found")
+	}
+	pending, err := u.maintenanceItemRepo.GetPendingApproval(ctx, waitingListID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) == 0 {
+		return nil, errors.New("no items are waiting for approval")
+	}
+	if err := u.approvalTokenRepo.RevokeByWaitingList(ctx, waitingListID); err != nil {
+		return nil, err
+	}
+	token := &entities.ApprovalToken{
+		WaitingListID: waitingListID,
+		ExpiresAt:     time.Now().Add(time.Duration(u.settingUsecase.GetApprovalLinkHours(ctx)) * time.Hour),
+	}
+	if err := u.approvalTokenRepo.Create(ctx, token); err != nil {
+		return nil, err
+	}
+	link := &dto.ApprovalLinkResponse{
+		URL:       u.publicURL + "/api/v1/maintenance/approvals/" + u.approvalTokens.Sign(token.ID, token.ExpiresAt),
+		ExpiresAt: token.ExpiresAt,
+	}
+	if u.notifier != nil {
+		_ = u.notifier.NotifyApprovalNeeded(ctx, waitingList, pending, link.URL, link.ExpiresAt)
+	}
+	return link, nil
+}
+// GetPendingApprovalByToken shows the items an approval link asks about.
+func (u *MaintenanceItemUsecase) GetPendingApprovalByToken(ctx context.Context, token string) (*dto.PendingApprovalResponse, error) {
+	approval, waitingList, err := u.resolveApprovalToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	items, err := u.maintenanceItemRepo.GetPendingApproval(ctx, waitingList.ID)
+	if err != nil {
+		return nil, err
+	}
+	total := 0.0
+	for _, item := range items {
+		total += item.EstimatedCost
+	}
+	response := &dto.PendingApprovalResponse{
+		WaitingListID:      waitingList.ID,
+		QueueNumber:        waitingList.QueueNumber,
+		VehicleBrand:       waitingList.Vehicle.Brand,
+		VehicleModel:       waitingList.Vehicle.Model,
+		LicensePlate:       waitingList.Vehicle.LicensePlate,
+		Items:              u.buildItemResponses(items),
+		TotalEstimatedCost: roundMoney(total),
+		ExpiresAt:          approval.ExpiresAt,
+	}
+	return response, nil
+}
+// DecideByToken applies the customer's answers given through an approval
+// link. The link is used up by the first submission; items left out stay
+// pending until the shop sends a new link.
+func (u *MaintenanceItemUsecase) DecideByToken(ctx context.Context, token string, req dto.ApprovalLinkDecisionRequest) error {
+	approval, waitingList, err := u.resolveApprovalToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	approvals, err := u.ticketApprovals(ctx, waitingList, req.Decisions, entities.ApprovalChannelMagicLink, nil)
+	if err != nil {
+		return err
+	}
+	err = u.approvalRepo.RecordLinkDecisions(ctx, approval.ID, approvals)
+	if errors.Is(err, repositories.ErrApprovalTokenUsed) {
+		return ErrApprovalLinkUsed
+	}
+	if err != nil {
+		return err
+	}
+	u.reviseEstimate(ctx, waitingList.ID)
+	return nil
+}
+// RecordPhoneDecisions stores decisions a customer gave over the phone, with
+// the staff member who took the call.
+func (u *MaintenanceItemUsecase) RecordPhoneDecisions(ctx context.Context, staffID, waitingListID types.MSSQLUUID, req dto.RecordPhoneApprovalRequest) error {
+	waitingList, err := u.waitingListRepo.GetByID(ctx, waitingListID)
+	if err != nil {
+		return errors.New("waiting list not found")
+	}
+	approvals, err := u.ticketApprovals(ctx, waitingList, req.Decisions, entities.ApprovalChannelPhone, &staffID)
+	if err != nil {
+		return err
+	}
+	if err := u.approvalRepo.RecordDecisions(ctx, approvals); err != nil {
+		return err
+	}
+	u.reviseEstimate(ctx, waitingList.ID)
+	return nil
+}
+// ticketApprovals checks that every decision is for an item of waitingList
+// that is waiting for one, at most once per item.
+func (u *MaintenanceItemUsecase) ticketApprovals(ctx context.Context, waitingList *entities.WaitingList, decisions []dto.ApprovalDecision, channel entities.ApprovalChannel, recordedBy *types.MSSQLUUID) ([]*entities.MaintenanceItemApproval, error) {
+	if len(decisions) == 0 {
+		return nil, errors.New("at least one decision is required")
+	}
+	inspected, err := u.maintenanceItemRepo.GetByStatus(ctx, waitingList.ID, entities.MaintenanceItemStatusInspected)
+	if err != nil {
+		return nil, err
+	}
+	waiting := make(map[types.MSSQLUUID]bool, len(inspected))
+	for _, item := range inspected {
+		waiting[item.ID] = true
+	}
+	approvals := make([]*entities.MaintenanceItemApproval, 0, len(decisions))
+	for _, decision := range decisions {
+		if !waiting[decision.ItemID] {
+			return nil, fmt.Errorf("%w: %s", repositories.ErrItemNotAwaitingApproval, decision.ItemID)
+		}
+		delete(waiting, decision.ItemID)
+		approvals = append(approvals, newApproval(waitingList, decision, channel, recordedBy))
+	}
+	return approvals, nil
+}
+func newApproval(waitingList *entities.WaitingList, decision dto.ApprovalDecision, channel entities.ApprovalChannel, recordedBy *types.MSSQLUUID) *entities.MaintenanceItemApproval {
+	approval := &entities.MaintenanceItemApproval{
+		MaintenanceItemID: decision.ItemID,
+		WaitingListID:     waitingList.ID,
+		CustomerID:        waitingList.CustomerID,
+		Decision:          entities.ApprovalDecisionRejected,
+		Channel:           channel,
+		Reason:            strings.TrimSpace(decision.Reason),
+		RecordedByID:      recordedBy,
+	}
+	if decision.Approve {
+		approval.Decision = entities.ApprovalDecisionApproved
+	}
+	return approval
+}
+func buildApprovalResponses(approvals []*entities.MaintenanceItemApproval) []dto.ApprovalRecordResponse {
+	responses := make([]dto.ApprovalRecordResponse, len(approvals))
+	for i, approval := range approvals {
+		responses[i] = dto.ApprovalRecordResponse{
+			ItemID:       approval.MaintenanceItemID,
+			CustomerID:   approval.CustomerID,
+			Decision:     string(approval.Decision),
+			Channel:      string(approval.Channel),
+			Reason:       approval.Reason,
+			RecordedByID: approval.RecordedByID,
+			DecidedAt:    approval.CreatedAt,
+		}
+		if approval.MaintenanceItem != nil {
+			responses[i].ItemName = approval.MaintenanceItem.Name
+		}
+		if approval.RecordedBy != nil {
+			responses[i].RecordedByName = approval.RecordedBy.Name
+		}
+	}
+	return responses
+}
+func (u *MaintenanceItemUsecase) resolveApprovalToken(ctx context.Context, token string) (*entities.ApprovalToken, *entities.WaitingList, error) {
+	id, err := u.approvalTokens.Verify(token, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	approval, err := u.approvalTokenRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if approval == nil {
+		return nil, nil, utils.ErrApprovalTokenInvalid // replaced by a newer link
+	}
+	if approval.UsedAt != nil {
+		return nil, nil, ErrApprovalLinkUsed
+	}
+	waitingList, err := u.waitingListRepo.GetByID(ctx, approval.WaitingListID)
+	if err != nil {
+		return nil, nil, errors.New("waiting list not found")
+	}
+	return approval, waitingList, nil
+}
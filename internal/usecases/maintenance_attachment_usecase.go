@@ -0,0 +1,251 @@
+package usecases
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/domain/services"
+	"github.com/kuahbanyak/go-crud/internal/shared/constants"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/shared/utils"
+)
+var (
+	ErrAttachmentTypeNotAllowed = errors.New("file type is not allowed")
+	ErrAttachmentTooLarge       = errors.New("file is too large")
+	ErrAttachmentForbidden      = errors.New("not allowed to access this attachment")
+)
+const thumbnailSize = 320
+// attachmentTypes are the accepted content types, as sniffed from the file
+// itself, with their kind and size limit in bytes.
+var attachmentTypes = map[string]struct {
+	kind    entities.AttachmentKind
+	maxSize int64
+}{
+	"image/jpeg":      {entities.AttachmentKindPhoto, 10 << 20},
+	"image/png":       {entities.AttachmentKindPhoto, 10 << 20},
+	"image/gif":       {entities.AttachmentKindPhoto, 10 << 20},
+	"image/webp":      {entities.AttachmentKindPhoto, 10 << 20},
+	"video/mp4":       {entities.AttachmentKindVideo, 50 << 20},
+	"video/webm":      {entities.AttachmentKindVideo, 50 << 20},
+	"application/pdf": {entities.AttachmentKindDocument, 20 << 20},
+}
+// MaxAttachmentSize returns the largest size any accepted file type allows.
+func MaxAttachmentSize() int64 {
+	var largest int64
+	for _, allowed := range attachmentTypes {
+		largest = max(largest, allowed.maxSize)
+	}
+	return largest
+}
+// ClassifyAttachment checks a file's sniffed content type and size and
+// returns the kind of attachment it is.
+func ClassifyAttachment(contentType string, size int64) (entities.AttachmentKind, error) {
+	allowed, ok := attachmentTypes[contentType]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrAttachmentTypeNotAllowed, contentType)
+	}
+	if size > allowed.maxSize {
+		return "", fmt.Errorf("%w: %s files are limited to %d MB", ErrAttachmentTooLarge, allowed.kind, allowed.maxSize>>20)
+	}
+	return allowed.kind, nil
+}
+type MaintenanceAttachmentUsecase struct {
+	attachmentRepo      repositories.MaintenanceItemAttachmentRepository
+	maintenanceItemRepo repositories.MaintenanceItemRepository
+	waitingListRepo     repositories.WaitingListRepository
+	storage             services.FileStorage
+}
+func NewMaintenanceAttachmentUsecase(
+	attachmentRepo repositories.MaintenanceItemAttachmentRepository,
+	maintenanceItemRepo repositories.MaintenanceItemRepository,
+	waitingListRepo repositories.WaitingListRepository,
+	storage services.FileStorage,
+) *MaintenanceAttachmentUsecase {
+	return &MaintenanceAttachmentUsecase{
+		attachmentRepo:      attachmentRepo,
+		maintenanceItemRepo: maintenanceItemRepo,
+		waitingListRepo:     waitingListRepo,
+		storage:             storage,
+	}
+}
+// Upload stores a file for a maintenance item. The content type is sniffed
+// from the content rather than taken from the client. Photos also get a
+// JPEG thumbnail, and the first photo becomes the item's image.
+func (u *MaintenanceAttachmentUsecase) Upload(ctx context.Context, uploaderID, itemID types.MSSQLUUID, fileName string, size int64, content io.Reader) (*entities.MaintenanceItemAttachment, error) {
+	item, err := u.maintenanceItemRepo.GetByID(ctx, itemID)
+	if err != nil {
+		return nil, errors.New("item not found")
+	}
+	contentType, content, err := sniffContentType(content)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := ClassifyAttachment(contentType, size)
+	if err != nil {
+		return nil, err
+	}
+	attachment := &entities.MaintenanceItemAttachment{
+		ID:                types.NewMSSQLUUID(),
+		MaintenanceItemID: item.ID,
+		UploadedByID:      &uploaderID,
+		Kind:              kind,
+		FileName:          filepath.Base(fileName),
+		ContentType:       contentType,
+		Size:              size,
+	}
+	prefix := fmt.Sprintf("maintenance/%s/%s", item.ID, attachment.ID)
+	attachment.StorageKey = prefix + strings.ToLower(filepath.Ext(attachment.FileName))
+	if kind == entities.AttachmentKindPhoto {
+		data, err := io.ReadAll(content)
+		if err != nil {
+			return nil, err
+		}
+		// A thumbnail is a convenience; formats the standard library cannot
+		// decode (WebP) are stored without one.
+		if thumb, err := utils.JPEGThumbnail(data, thumbnailSize); err == nil {
+			attachment.ThumbnailKey = prefix + "_thumb.jpg"
+			if err := u.storage.Save(ctx, attachment.ThumbnailKey, bytes.NewReader(thumb)); err != nil {
+				return nil, err
+			}
+		}
+		content = bytes.NewReader(data)
+	}
+	if err := u.storage.Save(ctx, attachment.StorageKey, content); err != nil {
+		u.deleteFiles(ctx, attachment)
+		return nil, err
+	}
+	if err := u.attachmentRepo.Create(ctx, attachment); err != nil {
+		u.deleteFiles(ctx, attachment)
+		return nil, err
+	}
+	if kind == entities.AttachmentKindPhoto && item.ImageURL == "" {
+		item.ImageURL = AttachmentURL(attachment.ID)
+		_ = u.maintenanceItemRepo.Update(ctx, item)
+	}
+	return attachment, nil
+}
+func (u *MaintenanceAttachmentUsecase) List(ctx context.Context, userID types.MSSQLUUID, role string, itemID types.MSSQLUUID) ([]*entities.MaintenanceItemAttachment, error) {
+	item, err := u.maintenanceItemRepo.GetByID(ctx, itemID)
+	if err != nil {
+		return nil, errors.New("item not found")
+	}
+	if err := u.authorize(ctx, userID, role, item.WaitingListID); err != nil {
+		return nil, err
+	}
+	return u.attachmentRepo.GetByItemID(ctx, itemID)
+}
+// Open returns an attachment's content, or its thumbnail when thumbnail is
+// set. The caller must close the reader.
+func (u *MaintenanceAttachmentUsecase) Open(ctx context.Context, userID types.MSSQLUUID, role string, attachmentID types.MSSQLUUID, thumbnail bool) (*entities.MaintenanceItemAttachment, io.ReadCloser, error) {
+	attachment, err := u.attachmentRepo.GetByID(ctx, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if attachment == nil {
+		return nil, nil, errors.New("attachment not found")
+	}
+	item, err := u.maintenanceItemRepo.GetByID(ctx, attachment.MaintenanceItemID)
+	if err != nil {
+		return nil, nil, errors.New("item not found")
+	}
+	if err := u.authorize(ctx, userID, role, item.WaitingListID); err != nil {
+		return nil, nil, err
+	}
+	key := attachment.StorageKey
+	if thumbnail {
+		if attachment.ThumbnailKey == "" {
+			return nil, nil, errors.New("attachment has no thumbnail")
+		}
+		key = attachment.ThumbnailKey
+	}
+	content, err := u.storage.Open(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+func (u *MaintenanceAttachmentUsecase) Delete(ctx context.Context, attachmentID types.MSSQLUUID) error {
+	attachment, err := u.attachmentRepo.GetByID(ctx, attachmentID)
+	if err != nil {
+		return err
+	}
+	if attachment == nil {
+		return errors.New("attachment not found")
+	}
+	if err := u.attachmentRepo.Delete(ctx, attachmentID); err != nil {
+		return err
+	}
+	u.deleteFiles(ctx, attachment)
+	return nil
+}
+// deleteFiles removes the stored file of an attachment and its thumbnail.
+func (u *MaintenanceAttachmentUsecase) deleteFiles(ctx context.Context, attachment *entities.MaintenanceItemAttachment) {
+	_ = u.storage.Delete(ctx, attachment.StorageKey)
+	if attachment.ThumbnailKey != "" {
+		_ = u.storage.Delete(ctx, attachment.ThumbnailKey)
+	}
+}
+// authorize lets staff see every attachment and customers only those on
+// their own tickets.
+func (u *MaintenanceAttachmentUsecase) authorize(ctx context.Context, userID types.MSSQLUUID, role string, waitingListID types.MSSQLUUID) error {
+	if isStaff(role) {
+		return nil
+	}
+	waitingList, err := u.waitingListRepo.GetByID(ctx, waitingListID)
+	if err != nil {
+		return errors.New("waiting list not found")
+	}
+	if !canViewTicket(waitingList, userID, role) {
+		return ErrAttachmentForbidden
+	}
+	return nil
+}
+func isStaff(role string) bool {
+	return role == constants.RoleAdmin || role == constants.RoleMechanic
+}
+// canViewTicket lets staff see every ticket and customers only their own.
+func canViewTicket(waitingList *entities.WaitingList, userID types.MSSQLUUID, role string) bool {
+	return isStaff(role) || waitingList.CustomerID == userID
+}
+// sniffContentType detects the type of an upload from its first bytes and
+// returns a reader that still yields the whole content.
+func sniffContentType(content io.Reader) (string, io.Reader, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", nil, errors.New("file is empty")
+	}
+	head = head[:n]
+	return http.DetectContentType(head), io.MultiReader(bytes.NewReader(head), content), nil
+}
+// AttachmentURL is the API path an attachment is downloaded from.
+func AttachmentURL(id types.MSSQLUUID) string {
+	return "/api/v1/maintenance/attachments/" + id.String()
+}
+func BuildAttachmentResponse(attachment *entities.MaintenanceItemAttachment) dto.AttachmentResponse {
+	resp := dto.AttachmentResponse{
+		ID:                attachment.ID,
+		MaintenanceItemID: attachment.MaintenanceItemID,
+		Kind:              string(attachment.Kind),
+		FileName:          attachment.FileName,
+		ContentType:       attachment.ContentType,
+		Size:              attachment.Size,
+		URL:               AttachmentURL(attachment.ID),
+		UploadedByID:      attachment.UploadedByID,
+		CreatedAt:         attachment.CreatedAt,
+	}
+	if attachment.ThumbnailKey != "" {
+		resp.ThumbnailURL = resp.URL + "/thumbnail"
+	}
+	if attachment.UploadedBy != nil {
+		resp.UploadedByName = attachment.UploadedBy.Name
+	}
+	return resp
+}
@@ -0,0 +1,359 @@
+package usecases
+import (
+	"context"
+	"errors"
+	"time"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+var (
+	ErrEstimateNotFound  = errors.New("estimate not found")
+	ErrEstimateForbidden = errors.New("unauthorized: not your service ticket")
+)
+// IssueEstimate sends the customer an estimate of the ticket's current
+// items. When nothing changed since the latest version, that version is
+// returned instead of a new one.
+func (u *MaintenanceItemUsecase) IssueEstimate(ctx context.Context, staffID, waitingListID types.MSSQLUUID) (*dto.EstimateResponse, error) {
+	waitingList, err := u.waitingListRepo.GetByID(ctx, waitingListID)
+	if err != nil {
+		return nil, errors.New("waiting list not found")
+	}
+	if !estimateOpen(waitingList) {
+		return nil, errors.New("estimates can only be issued before the service is closed")
+	}
+	estimate, err := u.nextEstimate(ctx, waitingList, &staffID)
+	if err != nil {
+		return nil, err
+	}
+	return u.buildEstimateResponse(ctx, estimate)
+}
+// GetEstimates returns every version of the ticket's estimate, newest first,
+// each with its changes.
+func (u *MaintenanceItemUsecase) GetEstimates(ctx context.Context, userID types.MSSQLUUID, role string, waitingListID types.MSSQLUUID) ([]*dto.EstimateResponse, error) {
+	if _, err := u.viewableTicket(ctx, userID, role, waitingListID); err != nil {
+		return nil, err
+	}
+	estimates, err := u.estimateRepo.GetByWaitingListID(ctx, waitingListID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*dto.EstimateResponse, len(estimates))
+	for i, estimate := range estimates {
+		responses[i] = BuildEstimateResponse(estimate, EstimateDiffBase(estimates[i+1:]))
+	}
+	return responses, nil
+}
+// GetLatestEstimate returns the newest version of the ticket's estimate.
+func (u *MaintenanceItemUsecase) GetLatestEstimate(ctx context.Context, userID types.MSSQLUUID, role string, waitingListID types.MSSQLUUID) (*dto.EstimateResponse, error) {
+	if _, err := u.viewableTicket(ctx, userID, role, waitingListID); err != nil {
+		return nil, err
+	}
+	estimate, err := u.estimateRepo.GetLatest(ctx, waitingListID)
+	if err != nil {
+		return nil, err
+	}
+	if estimate == nil {
+		return nil, ErrEstimateNotFound
+	}
+	return u.buildEstimateResponse(ctx, estimate)
+}
+// DecideEstimate records the customer's answer to an estimate. Only the
+// newest version can be answered; older ones are superseded.
+func (u *MaintenanceItemUsecase) DecideEstimate(ctx context.Context, customerID, estimateID types.MSSQLUUID, req dto.DecideEstimateRequest) (*dto.EstimateResponse, error) {
+	estimate, err := u.estimateRepo.GetByID(ctx, estimateID)
+	if err != nil {
+		return nil, err
+	}
+	if estimate == nil {
+		return nil, ErrEstimateNotFound
+	}
+	waitingList, err := u.waitingListRepo.GetByID(ctx, estimate.WaitingListID)
+	if err != nil {
+		return nil, errors.New("waiting list not found")
+	}
+	if waitingList.CustomerID != customerID {
+		return nil, ErrEstimateForbidden
+	}
+	now := time.Now()
+	estimate.Status = entities.EstimateStatusDeclined
+	if req.Accept {
+		estimate.Status = entities.EstimateStatusAccepted
+	}
+	estimate.DecidedAt = &now
+	estimate.DecisionNote = req.Reason
+	if err := u.estimateRepo.Decide(ctx, estimate); err != nil {
+		return nil, err
+	}
+	return u.buildEstimateResponse(ctx, estimate)
+}
+// CheckEstimateOverrun compares the ticket's final cost with the estimate
+// the customer accepted. It returns nil when no estimate was accepted or the
+// cost is within the overrun threshold.
+func (u *MaintenanceItemUsecase) CheckEstimateOverrun(ctx context.Context, waitingListID types.MSSQLUUID) (*dto.CostOverrunWarning, error) {
+	accepted, err := u.estimateRepo.GetLatestAccepted(ctx, waitingListID)
+	if err != nil || accepted == nil {
+		return nil, err
+	}
+	items, err := u.maintenanceItemRepo.GetByWaitingListID(ctx, waitingListID)
+	if err != nil {
+		return nil, err
+	}
+	return EstimateOverrun(accepted, items, u.settingUsecase.GetEstimateOverrunPercent(ctx)), nil
+}
+// reviseEstimate issues a new version after the ticket's items changed, if
+// the customer was already sent an estimate. It is best effort: a failure
+// must not undo the item change.
+func (u *MaintenanceItemUsecase) reviseEstimate(ctx context.Context, waitingListID types.MSSQLUUID) {
+	latest, err := u.estimateRepo.GetLatest(ctx, waitingListID)
+	if err != nil || latest == nil {
+		return
+	}
+	waitingList, err := u.waitingListRepo.GetByID(ctx, waitingListID)
+	if err != nil || !estimateOpen(waitingList) {
+		return
+	}
+	_, _ = u.nextEstimate(ctx, waitingList, nil)
+}
+// nextEstimate snapshots the ticket's items and stores them as a new version
+// unless they match the latest one.
+func (u *MaintenanceItemUsecase) nextEstimate(ctx context.Context, waitingList *entities.WaitingList, issuedBy *types.MSSQLUUID) (*entities.Estimate, error) {
+	items, err := u.maintenanceItemRepo.GetByWaitingListID(ctx, waitingList.ID)
+	if err != nil {
+		return nil, err
+	}
+	estimate := BuildEstimate(items, u.settingUsecase.GetTaxPercent(ctx))
+	if len(estimate.Lines) == 0 {
+		return nil, errors.New("the ticket has no items to estimate")
+	}
+	latest, err := u.estimateRepo.GetLatest(ctx, waitingList.ID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.TaxPercent == estimate.TaxPercent && len(DiffEstimateLines(latest.Lines, estimate.Lines)) == 0 {
+		return latest, nil
+	}
+	estimate.WaitingListID = waitingList.ID
+	estimate.Status = entities.EstimateStatusSent
+	estimate.IssuedByID = issuedBy
+	if err := u.estimateRepo.Create(ctx, estimate); err != nil {
+		return nil, err
+	}
+	if u.notifier != nil {
+		_ = u.notifier.NotifyEstimateSent(ctx, waitingList, estimate)
+	}
+	return estimate, nil
+}
+func (u *MaintenanceItemUsecase) viewableTicket(ctx context.Context, userID types.MSSQLUUID, role string, waitingListID types.MSSQLUUID) (*entities.WaitingList, error) {
+	waitingList, err := u.waitingListRepo.GetByID(ctx, waitingListID)
+	if err != nil {
+		return nil, errors.New("waiting list not found")
+	}
+	if !canViewTicket(waitingList, userID, role) {
+		return nil, ErrEstimateForbidden
+	}
+	return waitingList, nil
+}
+func (u *MaintenanceItemUsecase) buildEstimateResponse(ctx context.Context, estimate *entities.Estimate) (*dto.EstimateResponse, error) {
+	estimates, err := u.estimateRepo.GetByWaitingListID(ctx, estimate.WaitingListID)
+	if err != nil {
+		return nil, err
+	}
+	var older []*entities.Estimate
+	for _, e := range estimates {
+		if e.Version < estimate.Version {
+			older = append(older, e)
+		}
+	}
+	return BuildEstimateResponse(estimate, EstimateDiffBase(older)), nil
+}
+func estimateOpen(waitingList *entities.WaitingList) bool {
+	switch waitingList.Status {
+	case entities.WaitingListStatusCompleted, entities.WaitingListStatusCanceled, entities.WaitingListStatusNoShow:
+		return false
+	}
+	return true
+}
+// Estimable reports whether an item belongs on the ticket's estimate. Items
+// the customer turned down or the shop dropped are left off, as are items
+// still waiting for approval; they join the next version once approved.
+func Estimable(item *entities.MaintenanceItem) bool {
+	switch item.Status {
+	case entities.MaintenanceItemStatusRejected, entities.MaintenanceItemStatusSkipped, entities.MaintenanceItemStatusCanceled:
+		return false
+	case entities.MaintenanceItemStatusInspected:
+		return !item.RequiresApproval
+	}
+	return true
+}
+// EstimateLineAmount is what an item is quoted at: its estimated cost, or
+// its parts and labor when no cost was estimated. Warranty repairs are free.
+func EstimateLineAmount(item *entities.MaintenanceItem) float64 {
+	if item.WarrantyClaimID != nil {
+		return 0
+	}
+	if item.EstimatedCost > 0 {
+		return roundMoney(item.EstimatedCost)
+	}
+	return roundMoney(PartsCost(item.Parts) + item.LaborCost)
+}
+// BuildEstimate snapshots the estimable items with tax at taxPercent of the
+// subtotal. The result has no ticket or version yet.
+func BuildEstimate(items []*entities.MaintenanceItem, taxPercent float64) *entities.Estimate {
+	estimate := &entities.Estimate{TaxPercent: taxPercent}
+	for _, item := range items {
+		if !Estimable(item) {
+			continue
+		}
+		line := entities.EstimateLine{
+			MaintenanceItemID: item.ID,
+			Position:          len(estimate.Lines),
+			ItemType:          string(item.ItemType),
+			Category:          item.Category,
+			Name:              item.Name,
+			PartsCost:         PartsCost(item.Parts),
+			LaborHours:        item.LaborHours,
+			LaborRate:         item.LaborRate,
+			LaborCost:         item.LaborCost,
+			Amount:            EstimateLineAmount(item),
+		}
+		for _, part := range item.Parts {
+			linePart := entities.EstimateLinePart{ProductID: part.ProductID, Quantity: part.Quantity, UnitPrice: part.UnitPrice}
+			if part.Product != nil {
+				linePart.Name = part.Product.Name
+			}
+			line.Parts = append(line.Parts, linePart)
+		}
+		estimate.Lines = append(estimate.Lines, line)
+		estimate.Subtotal += line.Amount
+	}
+	estimate.Subtotal, estimate.TaxAmount, estimate.Total = withTax(estimate.Subtotal, taxPercent)
+	return estimate
+}
+func withTax(subtotal, taxPercent float64) (float64, float64, float64) {
+	subtotal = roundMoney(subtotal)
+	tax := roundMoney(subtotal * taxPercent / 100)
+	return subtotal, tax, roundMoney(subtotal + tax)
+}
+// DiffEstimateLines lists the items added, removed or repriced between two
+// versions, matching lines by maintenance item.
+func DiffEstimateLines(from, to []entities.EstimateLine) []dto.EstimateLineChange {
+	previous := make(map[types.MSSQLUUID]entities.EstimateLine, len(from))
+	for _, line := range from {
+		previous[line.MaintenanceItemID] = line
+	}
+	changes := []dto.EstimateLineChange{}
+	for _, line := range to {
+		old, ok := previous[line.MaintenanceItemID]
+		delete(previous, line.MaintenanceItemID)
+		change := dto.EstimateLineChange{MaintenanceItemID: line.MaintenanceItemID, Name: line.Name, Amount: line.Amount}
+		switch {
+		case !ok:
+			change.Change = "added"
+		case old.Amount != line.Amount || old.Name != line.Name || old.PartsCost != line.PartsCost || old.LaborHours != line.LaborHours || old.LaborCost != line.LaborCost:
+			change.Change = "changed"
+			change.PreviousAmount = old.Amount
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	for _, line := range from {
+		if _, ok := previous[line.MaintenanceItemID]; ok {
+			changes = append(changes, dto.EstimateLineChange{Change: "removed", MaintenanceItemID: line.MaintenanceItemID, Name: line.Name, PreviousAmount: line.Amount})
+		}
+	}
+	return changes
+}
+// EstimateDiffBase picks the version a new one is compared with: the newest
+// accepted version, else the one just before it. older must be newest first.
+func EstimateDiffBase(older []*entities.Estimate) *entities.Estimate {
+	for _, estimate := range older {
+		if estimate.Status == entities.EstimateStatusAccepted {
+			return estimate
+		}
+	}
+	if len(older) == 0 {
+		return nil
+	}
+	return older[0]
+}
+// EstimateOverrun prices the ticket's items as they stand, completed items
+// at their actual cost, with the accepted estimate's tax. It returns nil
+// unless the total is more than thresholdPercent above the accepted total.
+func EstimateOverrun(accepted *entities.Estimate, items []*entities.MaintenanceItem, thresholdPercent float64) *dto.CostOverrunWarning {
+	subtotal := 0.0
+	for _, item := range items {
+		if !Estimable(item) {
+			continue
+		}
+		if item.Status == entities.MaintenanceItemStatusCompleted && item.WarrantyClaimID == nil {
+			subtotal += item.ActualCost
+		} else {
+			subtotal += EstimateLineAmount(item)
+		}
+	}
+	_, _, total := withTax(subtotal, accepted.TaxPercent)
+	if total <= roundMoney(accepted.Total*(1+thresholdPercent/100)) {
+		return nil
+	}
+	warning := &dto.CostOverrunWarning{
+		EstimateID:       accepted.ID,
+		EstimateVersion:  accepted.Version,
+		AcceptedTotal:    accepted.Total,
+		FinalTotal:       total,
+		Overrun:          roundMoney(total - accepted.Total),
+		ThresholdPercent: thresholdPercent,
+	}
+	if accepted.Total > 0 {
+		warning.OverrunPercent = roundMoney(warning.Overrun / accepted.Total * 100)
+	}
+	return warning
+}
+func BuildEstimateResponse(estimate *entities.Estimate, base *entities.Estimate) *dto.EstimateResponse {
+	resp := &dto.EstimateResponse{
+		ID:            estimate.ID,
+		WaitingListID: estimate.WaitingListID,
+		Version:       estimate.Version,
+		Status:        string(estimate.Status),
+		Lines:         make([]dto.EstimateLineResponse, len(estimate.Lines)),
+		Subtotal:      estimate.Subtotal,
+		TaxPercent:    estimate.TaxPercent,
+		TaxAmount:     estimate.TaxAmount,
+		Total:         estimate.Total,
+		DecidedAt:     estimate.DecidedAt,
+		DecisionNote:  estimate.DecisionNote,
+		CreatedAt:     estimate.CreatedAt,
+	}
+	for i, line := range estimate.Lines {
+		resp.Lines[i] = dto.EstimateLineResponse{
+			MaintenanceItemID: line.MaintenanceItemID,
+			ItemType:          line.ItemType,
+			Category:          line.Category,
+			Name:              line.Name,
+			PartsCost:         line.PartsCost,
+			LaborHours:        line.LaborHours,
+			LaborRate:         line.LaborRate,
+			LaborCost:         line.LaborCost,
+			Amount:            line.Amount,
+		}
+		for _, part := range line.Parts {
+			resp.Lines[i].Parts = append(resp.Lines[i].Parts, dto.EstimateLinePartResponse{
+				ProductID: part.ProductID,
+				Name:      part.Name,
+				Quantity:  part.Quantity,
+				UnitPrice: part.UnitPrice,
+				Total:     roundMoney(float64(part.Quantity) * part.UnitPrice),
+			})
+		}
+	}
+	if base != nil {
+		resp.Diff = &dto.EstimateDiff{
+			AgainstVersion: base.Version,
+			AgainstStatus:  string(base.Status),
+			Lines:          DiffEstimateLines(base.Lines, estimate.Lines),
+			TotalChange:    roundMoney(estimate.Total - base.Total),
+		}
+	}
+	return resp
+}
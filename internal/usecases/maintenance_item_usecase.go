@@ -2,90 +2,215 @@ package usecases
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 	"github.com/kuahbanyak/go-crud/internal/domain/entities"
 	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/domain/services"
 	"github.com/kuahbanyak/go-crud/internal/shared/dto"
 	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/shared/utils"
 )
 type MaintenanceItemUsecase struct {
 	maintenanceItemRepo repositories.MaintenanceItemRepository
+	partRepo            repositories.MaintenanceItemPartRepository
 	waitingListRepo     repositories.WaitingListRepository
 	userRepo            repositories.UserRepository
+	productRepo         repositories.ProductRepository
+	packageUsecase      *ServicePackageUsecase
+	settingUsecase      *SettingUsecase
+	approvalTokenRepo   repositories.ApprovalTokenRepository
+	approvalRepo        repositories.MaintenanceItemApprovalRepository
+	logRepo             repositories.MaintenanceItemLogRepository
+	estimateRepo        repositories.EstimateRepository
+	notifier            services.NotificationService
+	approvalTokens      *utils.ApprovalTokenService
+	publicURL           string
 }
 func NewMaintenanceItemUsecase(
 	maintenanceItemRepo repositories.MaintenanceItemRepository,
+	partRepo repositories.MaintenanceItemPartRepository,
 	waitingListRepo repositories.WaitingListRepository,
 	userRepo repositories.UserRepository,
+	productRepo repositories.ProductRepository,
+	packageUsecase *ServicePackageUsecase,
+	settingUsecase *SettingUsecase,
+	approvalTokenRepo repositories.ApprovalTokenRepository,
+	approvalRepo repositories.MaintenanceItemApprovalRepository,
+	logRepo repositories.MaintenanceItemLogRepository,
+	estimateRepo repositories.EstimateRepository,
+	notifier services.NotificationService,
+	approvalTokens *utils.ApprovalTokenService,
+	publicURL string,
 ) *MaintenanceItemUsecase {
 	return &MaintenanceItemUsecase{
 		maintenanceItemRepo: maintenanceItemRepo,
+		partRepo:            partRepo,
 		waitingListRepo:     waitingListRepo,
 		userRepo:            userRepo,
+		productRepo:         productRepo,
+		packageUsecase:      packageUsecase,
+		settingUsecase:      settingUsecase,
+		approvalTokenRepo:   approvalTokenRepo,
+		approvalRepo:        approvalRepo,
+		logRepo:             logRepo,
+		estimateRepo:        estimateRepo,
+		notifier:            notifier,
+		approvalTokens:      approvalTokens,
+		publicURL:           publicURL,
 	}
 }
-func (u *MaintenanceItemUsecase) CreateInitialItems(ctx context.Context, waitingListID types.MSSQLUUID, requests []dto.CreateMaintenanceItemRequest) error {
-	_, err := u.waitingListRepo.GetByID(ctx, waitingListID)
+// CreateInitialItems adds the tasks the customer asked for: the items of
+// req.PackageCode, minus the excluded ones, followed by req.Items.
+func (u *MaintenanceItemUsecase) CreateInitialItems(ctx context.Context, waitingListID types.MSSQLUUID, req dto.CreateInitialItemsRequest) ([]*entities.MaintenanceItem, error) {
+	waitingList, err := u.waitingListRepo.GetByID(ctx, waitingListID)
 	if err != nil {
-		return errors.New("waiting list not found")
+		return nil, errors.New("waiting list not found")
 	}
-	items := make([]*entities.MaintenanceItem, len(requests))
-	for i, req := range requests {
-		items[i] = &entities.MaintenanceItem{
-			WaitingListID:    waitingListID,
+	var items []*entities.MaintenanceItem
+	if req.PackageCode != "" {
+		pkg, prices, err := u.packageUsecase.GetActiveByCode(ctx, req.PackageCode)
+		if err != nil {
+			return nil, err
+		}
+		existing, err := u.maintenanceItemRepo.GetInitialItems(ctx, waitingListID)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range existing {
+			if item.PackageCode == pkg.Code {
+				return nil, fmt.Errorf("%w: package %s is already on this ticket", ErrPackageNotApplicable, pkg.Code)
+			}
+		}
+		if items, err = ExpandPackage(pkg, prices, req.Exclude); err != nil {
+			return nil, err
+		}
+	}
+	for _, itemReq := range req.Items {
+		items = append(items, &entities.MaintenanceItem{
 			ItemType:         entities.MaintenanceItemTypeInitial,
 			Status:           entities.MaintenanceItemStatusPending,
-			Category:         req.Category,
-			Name:             req.Name,
-			Description:      req.Description,
-			EstimatedCost:    req.EstimatedCost,
+			Category:         itemReq.Category,
+			Name:             itemReq.Name,
+			Description:      itemReq.Description,
+			EstimatedCost:    itemReq.EstimatedCost,
 			Priority:         "normal",
 			RequiresApproval: false, // Initial items don't need approval
-		}
+		})
+	}
+	if len(items) == 0 {
+		return nil, errors.New("at least one maintenance item is required")
+	}
+	for _, item := range items {
+		item.WaitingListID = waitingListID
+		u.priceLabor(ctx, item, waitingList)
+	}
+	if err := u.maintenanceItemRepo.CreateMany(ctx, items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+// RemoveInitialItem lets the customer drop a requested task, including one
+// that came with a package, as long as work on it has not started.
+func (u *MaintenanceItemUsecase) RemoveInitialItem(ctx context.Context, customerID, itemID types.MSSQLUUID) error {
+	item, err := u.maintenanceItemRepo.GetByID(ctx, itemID)
+	if err != nil {
+		return errors.New("item not found")
+	}
+	waitingList, err := u.waitingListRepo.GetByID(ctx, item.WaitingListID)
+	if err != nil {
+		return errors.New("waiting list not found")
+	}
+	if waitingList.CustomerID != customerID {
+		return errors.New("unauthorized: not your maintenance item")
+	}
+	if item.ItemType != entities.MaintenanceItemTypeInitial || item.Status != entities.MaintenanceItemStatusPending {
+		return errors.New("only pending requested items can be removed")
+	}
+	if waitingList.Status != entities.WaitingListStatusWaiting && waitingList.Status != entities.WaitingListStatusCalled {
+		return errors.New("items can only be removed before service starts")
+	}
+	if err := u.maintenanceItemRepo.Delete(ctx, itemID); err != nil {
+		return err
 	}
-	return u.maintenanceItemRepo.CreateMany(ctx, items)
+	u.reviseEstimate(ctx, item.WaitingListID)
+	return nil
 }
 func (u *MaintenanceItemUsecase) AddDiscoveredItem(ctx context.Context, mechanicID types.MSSQLUUID, req dto.AddDiscoveredItemRequest) (*entities.MaintenanceItem, error) {
-	waitingList, err := u.waitingListRepo.GetByID(ctx, req.WaitingListID)
+	items, err := u.AddDiscoveredItems(ctx, mechanicID, req.WaitingListID, []dto.AddDiscoveredItemRequest{req})
 	if err != nil {
-		return nil, errors.New("waiting list not found")
+		return nil, err
+	}
+	return items[0], nil
+}
+// AddDiscoveredItems adds issues found on one ticket during service. The
+// customer gets a single approval link covering all of them.
+func (u *MaintenanceItemUsecase) AddDiscoveredItems(ctx context.Context, mechanicID, waitingListID types.MSSQLUUID, reqs []dto.AddDiscoveredItemRequest) ([]*entities.MaintenanceItem, error) {
+	waitingList, items, err := u.newDiscoveredItems(ctx, mechanicID, waitingListID, reqs)
+	if err != nil || len(items) == 0 {
+		return items, err
+	}
+	if err := u.maintenanceItemRepo.CreateMany(ctx, items); err != nil {
+		return nil, err
+	}
+	u.discoveredItemsAdded(ctx, waitingList, items)
+	return items, nil
+}
+// newDiscoveredItems prices the discovered items of reqs without storing
+// them. The ticket must be in service.
+func (u *MaintenanceItemUsecase) newDiscoveredItems(ctx context.Context, mechanicID, waitingListID types.MSSQLUUID, reqs []dto.AddDiscoveredItemRequest) (*entities.WaitingList, []*entities.MaintenanceItem, error) {
+	waitingList, err := u.waitingListRepo.GetByID(ctx, waitingListID)
+	if err != nil {
+		return nil, nil, errors.New("waiting list not found")
 	}
 	if waitingList.Status != entities.WaitingListStatusInService {
-		return nil, errors.New("service must be in progress to add discovered items")
+		return nil, nil, errors.New("service must be in progress to add discovered items")
 	}
 	_, err = u.userRepo.GetByID(ctx, mechanicID)
 	if err != nil {
-		return nil, errors.New("mechanic not found")
+		return nil, nil, errors.New("mechanic not found")
 	}
 	now := time.Now()
-	item := &entities.MaintenanceItem{
-		WaitingListID:    req.WaitingListID,
-		MechanicID:       &mechanicID,
-		ItemType:         entities.MaintenanceItemTypeDiscovered,
-		Status:           entities.MaintenanceItemStatusInspected,
-		Category:         req.Category,
-		Name:             req.Name,
-		Description:      req.Description,
-		Priority:         req.Priority,
-		EstimatedCost:    req.EstimatedCost,
-		LaborHours:       req.LaborHours,
-		RequiresApproval: req.RequiresApproval,
-		ImageURL:         req.ImageURL,
-		Notes:            req.Notes,
-		InspectedAt:      &now,
-	}
-	err = u.maintenanceItemRepo.Create(ctx, item)
-	if err != nil {
-		return nil, err
+	items := make([]*entities.MaintenanceItem, 0, len(reqs))
+	for _, req := range reqs {
+		item := &entities.MaintenanceItem{
+			WaitingListID:    waitingListID,
+			MechanicID:       &mechanicID,
+			ItemType:         entities.MaintenanceItemTypeDiscovered,
+			Status:           entities.MaintenanceItemStatusInspected,
+			Category:         req.Category,
+			Name:             req.Name,
+			Description:      req.Description,
+			Priority:         req.Priority,
+			EstimatedCost:    req.EstimatedCost,
+			LaborHours:       req.LaborHours,
+			RequiresApproval: req.RequiresApproval,
+			ImageURL:         req.ImageURL,
+			Notes:            req.Notes,
+			InspectedAt:      &now,
+		}
+		u.priceLabor(ctx, item, waitingList)
+		items = append(items, item)
+	}
+	return waitingList, items, nil
+}
+// discoveredItemsAdded asks the customer for approval when any of the
+// stored items needs it and revises the ticket's estimate.
+func (u *MaintenanceItemUsecase) discoveredItemsAdded(ctx context.Context, waitingList *entities.WaitingList, items []*entities.MaintenanceItem) {
+	for _, item := range items {
+		if item.RequiresApproval {
+			_, _ = u.SendApprovalLink(ctx, waitingList.ID)
+			break
+		}
 	}
-	return item, nil
+	u.reviseEstimate(ctx, waitingList.ID)
 }
 func (u *MaintenanceItemUsecase) GetItemsByWaitingList(ctx context.Context, waitingListID types.MSSQLUUID) (*dto.MaintenanceItemListResponse, error) {
 	items, err := u.maintenanceItemRepo.GetByWaitingListID(ctx, waitingListID)
 	if err != nil {
 		return nil, err
 	}
-	estimated, actual, err := u.maintenanceItemRepo.GetTotalCost(ctx, waitingListID)
+	totals, err := u.maintenanceItemRepo.GetTotalCost(ctx, waitingListID)
 	if err != nil {
 		return nil, err
 	}
@@ -96,8 +221,12 @@ func (u *MaintenanceItemUsecase) GetItemsByWaitingList(ctx context.Context, wait
 	response := &dto.MaintenanceItemListResponse{
 		Items:                u.buildItemResponses(items),
 		Total:                len(items),
-		TotalEstimatedCost:   estimated,
-		TotalActualCost:      actual,
+		TotalEstimatedCost:   totals.Estimated,
+		TotalDiscount:        totalDiscount(items),
+		TotalActualCost:      totals.Actual,
+		TotalPartsCost:       roundMoney(totals.Parts),
+		TotalLaborCost:       roundMoney(totals.Labor),
+		TotalWarrantyCost:    roundMoney(totals.Warranty),
 		PendingApprovalCount: counts["inspected"],
 		CompletedCount:       counts["completed"],
 	}
@@ -119,7 +248,7 @@ func (u *MaintenanceItemUsecase) GetInspectionSummary(ctx context.Context, waiti
 	if err != nil {
 		return nil, err
 	}
-	estimated, _, err := u.maintenanceItemRepo.GetTotalCost(ctx, waitingListID)
+	totals, err := u.maintenanceItemRepo.GetTotalCost(ctx, waitingListID)
 	if err != nil {
 		return nil, err
 	}
@@ -130,6 +259,10 @@ func (u *MaintenanceItemUsecase) GetInspectionSummary(ctx context.Context, waiti
 			break
 		}
 	}
+	approvals, err := u.approvalRepo.GetByWaitingListID(ctx, waitingListID)
+	if err != nil {
+		return nil, err
+	}
 	summary := &dto.MaintenanceInspectionSummary{
 		WaitingListID:      waitingListID,
 		QueueNumber:        waitingList.QueueNumber,
@@ -138,21 +271,39 @@ func (u *MaintenanceItemUsecase) GetInspectionSummary(ctx context.Context, waiti
 		LicensePlate:       waitingList.Vehicle.LicensePlate,
 		InitialItems:       u.buildItemResponses(initialItems),
 		DiscoveredItems:    u.buildItemResponses(discoveredItems),
-		TotalEstimatedCost: estimated,
+		TotalEstimatedCost: totals.Estimated,
+		TotalPartsCost:     roundMoney(totals.Parts),
+		TotalLaborCost:     roundMoney(totals.Labor),
 		RequiresApproval:   requiresApproval,
+		Decisions:          buildApprovalResponses(approvals),
 		InspectedAt:        time.Now(),
 	}
 	return summary, nil
 }
+// ApproveItems records the customer's decisions made in the app. item_ids
+// all get the same decision with notes as the reason; decisions carry their
+// own.
 func (u *MaintenanceItemUsecase) ApproveItems(ctx context.Context, customerID types.MSSQLUUID, req dto.ApproveMaintenanceItemRequest) error {
+	decisions := req.Decisions
 	for _, itemID := range req.ItemIDs {
-		item, err := u.maintenanceItemRepo.GetByID(ctx, itemID)
+		decisions = append(decisions, dto.ApprovalDecision{ItemID: itemID, Approve: req.Approve, Reason: req.Notes})
+	}
+	if len(decisions) == 0 {
+		return errors.New("at least one decision is required")
+	}
+	tickets := make(map[types.MSSQLUUID]*entities.WaitingList)
+	approvals := make([]*entities.MaintenanceItemApproval, 0, len(decisions))
+	for _, decision := range decisions {
+		item, err := u.maintenanceItemRepo.GetByID(ctx, decision.ItemID)
 		if err != nil {
 			return errors.New("item not found")
 		}
-		waitingList, err := u.waitingListRepo.GetByID(ctx, item.WaitingListID)
-		if err != nil {
-			return errors.New("waiting list not found")
+		waitingList, ok := tickets[item.WaitingListID]
+		if !ok {
+			if waitingList, err = u.waitingListRepo.GetByID(ctx, item.WaitingListID); err != nil {
+				return errors.New("waiting list not found")
+			}
+			tickets[item.WaitingListID] = waitingList
 		}
 		if waitingList.CustomerID != customerID {
 			return errors.New("unauthorized: not your maintenance item")
@@ -160,19 +311,33 @@ func (u *MaintenanceItemUsecase) ApproveItems(ctx context.Context, customerID ty
 		if item.Status != entities.MaintenanceItemStatusInspected {
 			return errors.New("item is not in inspected status")
 		}
+		approvals = append(approvals, newApproval(waitingList, decision, entities.ApprovalChannelApp, nil))
+	}
+	if err := u.approvalRepo.RecordDecisions(ctx, approvals); err != nil {
+		return err
 	}
-	if req.Approve {
-		return u.maintenanceItemRepo.ApproveItems(ctx, req.ItemIDs)
+	for waitingListID := range tickets {
+		u.reviseEstimate(ctx, waitingListID)
 	}
-	return u.maintenanceItemRepo.RejectItems(ctx, req.ItemIDs)
+	return nil
 }
 func (u *MaintenanceItemUsecase) UpdateItem(ctx context.Context, itemID types.MSSQLUUID, req dto.UpdateMaintenanceItemRequest) error {
 	item, err := u.maintenanceItemRepo.GetByID(ctx, itemID)
 	if err != nil {
 		return errors.New("item not found")
 	}
+	restock := false
 	if req.Status != "" {
-		item.Status = entities.MaintenanceItemStatus(req.Status)
+		status := entities.MaintenanceItemStatus(req.Status)
+		if status == entities.MaintenanceItemStatusCompleted && item.Status != status {
+			return errors.New("use the complete endpoint to complete an item")
+		}
+		restock = item.Status == entities.MaintenanceItemStatusCompleted && status != item.Status
+		item.Status = status
+		if restock {
+			item.CompletedAt = nil
+			ApplyWarranty(item, WarrantyTerms{}, 0) // reopened work is no longer covered
+		}
 	}
 	if req.Description != "" {
 		item.Description = req.Description
@@ -192,9 +357,22 @@ func (u *MaintenanceItemUsecase) UpdateItem(ctx context.Context, itemID types.MS
 	if req.Notes != "" {
 		item.Notes = req.Notes
 	}
-	return u.maintenanceItemRepo.Update(ctx, item)
+	u.priceLabor(ctx, item, item.WaitingList)
+	if restock {
+		err = u.partRepo.RestockItem(ctx, item)
+	} else {
+		err = u.maintenanceItemRepo.Update(ctx, item)
+	}
+	if err != nil {
+		return err
+	}
+	u.reviseEstimate(ctx, item.WaitingListID)
+	return nil
 }
-func (u *MaintenanceItemUsecase) CompleteItem(ctx context.Context, itemID types.MSSQLUUID, actualCost float64) error {
+// CompleteItem marks the item done and takes its parts out of stock in the
+// same transaction. Only admins may pass overrideStock to complete an item
+// whose parts are short; stock then goes negative until it is recounted.
+func (u *MaintenanceItemUsecase) CompleteItem(ctx context.Context, itemID types.MSSQLUUID, actualCost float64, overrideStock bool) error {
 	item, err := u.maintenanceItemRepo.GetByID(ctx, itemID)
 	if err != nil {
 		return errors.New("item not found")
@@ -204,12 +382,182 @@ func (u *MaintenanceItemUsecase) CompleteItem(ctx context.Context, itemID types.
 	}
 	now := time.Now()
 	item.Status = entities.MaintenanceItemStatusCompleted
-	item.ActualCost = actualCost
 	item.CompletedAt = &now
-	return u.maintenanceItemRepo.Update(ctx, item)
+	u.priceLabor(ctx, item, item.WaitingList)
+	if actualCost == 0 {
+		parts, err := u.partRepo.GetByItemID(ctx, itemID)
+		if err != nil {
+			return err
+		}
+		for _, part := range parts {
+			actualCost += part.Total()
+		}
+		actualCost = roundMoney(actualCost + item.LaborCost)
+	}
+	item.ActualCost = actualCost
+	mileage := 0
+	if waitingList, err := u.waitingListRepo.GetByID(ctx, item.WaitingListID); err == nil {
+		mileage = waitingList.Vehicle.Mileage
+	}
+	ApplyWarranty(item, u.settingUsecase.GetWarrantyPolicy(ctx).For(item.Category), mileage)
+	return u.partRepo.CompleteItem(ctx, item, overrideStock)
+}
+// CancelItem drops an item from the job. A completed item gives its parts
+// back to stock.
+func (u *MaintenanceItemUsecase) CancelItem(ctx context.Context, itemID types.MSSQLUUID) error {
+	item, err := u.maintenanceItemRepo.GetByID(ctx, itemID)
+	if err != nil {
+		return errors.New("item not found")
+	}
+	if item.Status == entities.MaintenanceItemStatusCanceled {
+		return errors.New("item is already canceled")
+	}
+	wasCompleted := item.Status == entities.MaintenanceItemStatusCompleted
+	item.Status = entities.MaintenanceItemStatusCanceled
+	item.CompletedAt = nil
+	ApplyWarranty(item, WarrantyTerms{}, 0)
+	if wasCompleted {
+		err = u.partRepo.RestockItem(ctx, item)
+	} else {
+		err = u.maintenanceItemRepo.Update(ctx, item)
+	}
+	if err != nil {
+		return err
+	}
+	u.reviseEstimate(ctx, item.WaitingListID)
+	return nil
 }
 func (u *MaintenanceItemUsecase) DeleteItem(ctx context.Context, itemID types.MSSQLUUID) error {
-	return u.maintenanceItemRepo.Delete(ctx, itemID)
+	item, err := u.maintenanceItemRepo.GetByID(ctx, itemID)
+	if err != nil {
+		return errors.New("item not found")
+	}
+	if item.Status == entities.MaintenanceItemStatusCompleted {
+		if err := u.partRepo.RestockItem(ctx, item); err != nil {
+			return err
+		}
+	}
+	if err := u.maintenanceItemRepo.Delete(ctx, itemID); err != nil {
+		return err
+	}
+	u.reviseEstimate(ctx, item.WaitingListID)
+	return nil
+}
+// AddPart records a product used on the item. The unit price is fixed when
+// the part is added; stock is only taken when the item is completed.
+func (u *MaintenanceItemUsecase) AddPart(ctx context.Context, itemID types.MSSQLUUID, req dto.AddItemPartRequest) (*entities.MaintenanceItemPart, error) {
+	item, err := u.maintenanceItemRepo.GetByID(ctx, itemID)
+	if err != nil {
+		return nil, errors.New("item not found")
+	}
+	if !partsEditable(item.Status) {
+		return nil, fmt.Errorf("parts cannot be changed on a %s item", item.Status)
+	}
+	if req.Quantity <= 0 {
+		return nil, errors.New("quantity must be at least 1")
+	}
+	product, err := u.productRepo.GetByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, errors.New("product not found")
+	}
+	part := &entities.MaintenanceItemPart{
+		MaintenanceItemID: item.ID,
+		ProductID:         product.ID,
+		Quantity:          req.Quantity,
+		UnitPrice:         product.Price,
+	}
+	if req.UnitPrice != nil {
+		if *req.UnitPrice < 0 {
+			return nil, errors.New("unit price cannot be negative")
+		}
+		part.UnitPrice = *req.UnitPrice
+	}
+	if err := u.partRepo.Create(ctx, part); err != nil {
+		return nil, err
+	}
+	part.Product = product
+	u.reviseEstimate(ctx, item.WaitingListID)
+	return part, nil
+}
+func (u *MaintenanceItemUsecase) RemovePart(ctx context.Context, itemID, partID types.MSSQLUUID) error {
+	item, err := u.maintenanceItemRepo.GetByID(ctx, itemID)
+	if err != nil {
+		return errors.New("item not found")
+	}
+	part, err := u.partRepo.GetByID(ctx, partID)
+	if err != nil {
+		return err
+	}
+	if part == nil || part.MaintenanceItemID != item.ID {
+		return errors.New("part not found")
+	}
+	if !partsEditable(item.Status) || part.DeductedAt != nil {
+		return fmt.Errorf("parts cannot be changed on a %s item", item.Status)
+	}
+	if err := u.partRepo.Delete(ctx, partID); err != nil {
+		return err
+	}
+	u.reviseEstimate(ctx, item.WaitingListID)
+	return nil
+}
+func partsEditable(status entities.MaintenanceItemStatus) bool {
+	switch status {
+	case entities.MaintenanceItemStatusCompleted, entities.MaintenanceItemStatusCanceled,
+		entities.MaintenanceItemStatusRejected, entities.MaintenanceItemStatusSkipped:
+		return false
+	}
+	return true
+}
+// priceLabor sets the item's labor rate and cost from the labor settings,
+// the skill of the item's mechanic (or the ticket's) and when the work is
+// done: at completion, else when service started, else the booked slot, else
+// the opening time of the service day.
+func (u *MaintenanceItemUsecase) priceLabor(ctx context.Context, item *entities.MaintenanceItem, waitingList *entities.WaitingList) {
+	hours := u.settingUsecase.GetBusinessHours(ctx)
+	at := time.Now()
+	mechanicID := item.MechanicID
+	if waitingList != nil {
+		switch {
+		case waitingList.ServiceStartAt != nil:
+			at = *waitingList.ServiceStartAt
+		case waitingList.AppointmentAt != nil:
+			at = *waitingList.AppointmentAt
+		default:
+			at = hours.OpenAt(waitingList.ServiceDate)
+		}
+		if mechanicID == nil {
+			mechanicID = waitingList.MechanicID
+		}
+	}
+	if item.CompletedAt != nil {
+		at = *item.CompletedAt
+	}
+	skillLevel := ""
+	if mechanicID != nil {
+		if mechanic, err := u.userRepo.GetByID(ctx, *mechanicID); err == nil && mechanic != nil {
+			skillLevel = mechanic.SkillLevel
+		}
+	}
+	item.LaborRate = u.settingUsecase.GetLaborRates(ctx).HourlyRate(item.Category, skillLevel, at, hours)
+	item.LaborCost = LaborCost(item.LaborHours, item.LaborRate)
+}
+// PartsCost is the price of the parts on an item at their recorded unit prices.
+func PartsCost(parts []entities.MaintenanceItemPart) float64 {
+	total := 0.0
+	for i := range parts {
+		total += parts[i].Total()
+	}
+	return roundMoney(total)
+}
+func totalDiscount(items []*entities.MaintenanceItem) float64 {
+	total := 0.0
+	for _, item := range items {
+		total += item.DiscountAmount
+	}
+	return roundMoney(total)
 }
 func (u *MaintenanceItemUsecase) buildItemResponses(items []*entities.MaintenanceItem) []dto.MaintenanceItemResponse {
 	responses := make([]dto.MaintenanceItemResponse, len(items))
@@ -227,9 +575,19 @@ func (u *MaintenanceItemUsecase) buildItemResponses(items []*entities.Maintenanc
 			EstimatedCost:    item.EstimatedCost,
 			ActualCost:       item.ActualCost,
 			LaborHours:       item.LaborHours,
+			LaborRate:        item.LaborRate,
+			LaborCost:        item.LaborCost,
 			RequiresApproval: item.RequiresApproval,
 			ImageURL:         item.ImageURL,
 			Notes:            item.Notes,
+			PackageCode:      item.PackageCode,
+			DiscountAmount:   item.DiscountAmount,
+			WarrantyMonths:   item.WarrantyMonths,
+			WarrantyKm:       item.WarrantyKm,
+			WarrantyUntil:    item.WarrantyUntil,
+			WarrantyUntilKm:  item.WarrantyUntilKm,
+			WarrantyClaimID:  item.WarrantyClaimID,
+			PartsCost:        PartsCost(item.Parts),
 			InspectedAt:      item.InspectedAt,
 			ApprovedAt:       item.ApprovedAt,
 			CompletedAt:      item.CompletedAt,
@@ -239,7 +597,24 @@ func (u *MaintenanceItemUsecase) buildItemResponses(items []*entities.Maintenanc
 		if item.Mechanic != nil {
 			responses[i].MechanicName = item.Mechanic.Name
 		}
+		for j := range item.Parts {
+			responses[i].Parts = append(responses[i].Parts, BuildItemPartResponse(&item.Parts[j]))
+		}
 	}
 	return responses
 }
-
+func BuildItemPartResponse(part *entities.MaintenanceItemPart) dto.MaintenanceItemPartResponse {
+	resp := dto.MaintenanceItemPartResponse{
+		ID:         part.ID,
+		ProductID:  part.ProductID,
+		Quantity:   part.Quantity,
+		UnitPrice:  part.UnitPrice,
+		Total:      roundMoney(part.Total()),
+		DeductedAt: part.DeductedAt,
+	}
+	if part.Product != nil {
+		resp.ProductName = part.Product.Name
+		resp.SKU = part.Product.SKU
+	}
+	return resp
+}
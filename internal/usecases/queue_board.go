@@ -0,0 +1,86 @@
+package usecases
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+)
+// QueueBoard fans out anonymized ticket updates to live queue displays.
+// Subscribers that fall behind miss updates instead of blocking the caller.
+type QueueBoard struct {
+	mu          sync.RWMutex
+	subscribers map[chan dto.QueueBoardEntry]struct{}
+}
+func NewQueueBoard() *QueueBoard {
+	return &QueueBoard{
+		subscribers: make(map[chan dto.QueueBoardEntry]struct{}),
+	}
+}
+func (b *QueueBoard) Subscribe() (<-chan dto.QueueBoardEntry, func()) {
+	ch := make(chan dto.QueueBoardEntry, 16)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			close(ch)
+			b.mu.Unlock()
+		})
+	}
+	return ch, unsubscribe
+}
+func (b *QueueBoard) Publish(entry dto.QueueBoardEntry) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- entry:
+		default:
+		}
+	}
+}
+func NewQueueBoardEntry(wl *entities.WaitingList) dto.QueueBoardEntry {
+	entry := dto.QueueBoardEntry{
+		QueueNumber:  wl.QueueNumber,
+		Status:       string(wl.Status),
+		LicensePlate: MaskLicensePlate(wl.Vehicle.LicensePlate),
+		ServiceDate:  wl.ServiceDate.Format("2006-01-02"),
+		UpdatedAt:    time.Now(),
+	}
+	if wl.Status == entities.WaitingListStatusInService && wl.ServiceBay != nil {
+		entry.ServiceBay = wl.ServiceBay.Name
+	}
+	return entry
+}
+// MaskLicensePlate keeps the first and last two characters of a plate and
+// hides the rest, e.g. "B 1234 XYZ" becomes "B 1*** *YZ". Plates too short to
+// hide anything that way are masked completely.
+func MaskLicensePlate(plate string) string {
+	runes := []rune(strings.TrimSpace(plate))
+	total := 0
+	for _, r := range runes {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			total++
+		}
+	}
+	keep := 2
+	if total <= 2*keep {
+		keep = 0
+	}
+	seen := 0
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			continue
+		}
+		if seen >= keep && seen < total-keep {
+			runes[i] = '*'
+		}
+		seen++
+	}
+	return string(runes)
+}
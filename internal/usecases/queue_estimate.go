@@ -0,0 +1,105 @@
+package usecases
+import (
+	"sort"
+	"strings"
+	"time"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+)
+// ServiceDurations resolves how long a ticket is expected to take: the
+// historical average for its catalog service type code, then for its
+// service type name, then the ticket's own EstimatedTime, then the shop-wide
+// default.
+type ServiceDurations struct {
+	Averages       repositories.ServiceAverages // minutes
+	DefaultMinutes int
+}
+func (d ServiceDurations) For(ticket *entities.WaitingList) time.Duration {
+	if avg := d.Averages.ByCode[ticket.ServiceTypeCode]; ticket.ServiceTypeCode != "" && avg > 0 {
+		return time.Duration(avg * float64(time.Minute))
+	}
+	if avg := d.Averages.ByName[strings.ToLower(strings.TrimSpace(ticket.ServiceType))]; avg > 0 {
+		return time.Duration(avg * float64(time.Minute))
+	}
+	if ticket.EstimatedTime > 0 {
+		return time.Duration(ticket.EstimatedTime) * time.Minute
+	}
+	return time.Duration(d.DefaultMinutes) * time.Minute
+}
+type QueueEstimate struct {
+	WaitingAhead      int
+	CurrentlyServing  int
+	WaitMinutes       int
+	EstimatedCalledAt *time.Time
+	EstimatedDoneAt   *time.Time
+}
+// EstimateQueue simulates the day's queue over the given number of service
+// lanes: vehicles in service finish first, then tickets ahead are served in
+// queue order, each on whichever lane frees up earliest.
+func EstimateQueue(ticket *entities.WaitingList, tickets []*entities.WaitingList, durations ServiceDurations, lanes int, now time.Time) QueueEstimate {
+	if lanes < 1 {
+		lanes = 1
+	}
+	estimate := QueueEstimate{}
+	free := make([]time.Time, lanes)
+	for i := range free {
+		free[i] = now
+	}
+	var ahead []*entities.WaitingList
+	for _, t := range tickets {
+		if t.Status == entities.WaitingListStatusInService {
+			estimate.CurrentlyServing = t.QueueNumber
+			if t.ID == ticket.ID {
+				continue
+			}
+			end := now
+			if t.ServiceStartAt != nil {
+				end = t.ServiceStartAt.Add(durations.For(t))
+			}
+			if i := earliestLane(free); end.After(free[i]) {
+				free[i] = end
+			}
+		}
+		if t.ID != ticket.ID && t.QueueNumber < ticket.QueueNumber &&
+			(t.Status == entities.WaitingListStatusWaiting || t.Status == entities.WaitingListStatusCalled) {
+			ahead = append(ahead, t)
+		}
+	}
+	estimate.WaitingAhead = len(ahead)
+	own := durations.For(ticket)
+	switch ticket.Status {
+	case entities.WaitingListStatusWaiting:
+		sort.Slice(ahead, func(i, j int) bool { return ahead[i].QueueNumber < ahead[j].QueueNumber })
+		for _, t := range ahead {
+			i := earliestLane(free)
+			free[i] = free[i].Add(durations.For(t))
+		}
+		calledAt := free[earliestLane(free)]
+		if ticket.AppointmentAt != nil && ticket.AppointmentAt.After(calledAt) {
+			calledAt = *ticket.AppointmentAt
+		}
+		doneAt := calledAt.Add(own)
+		estimate.WaitMinutes = int(calledAt.Sub(now).Round(time.Minute) / time.Minute)
+		estimate.EstimatedCalledAt = &calledAt
+		estimate.EstimatedDoneAt = &doneAt
+	case entities.WaitingListStatusCalled:
+		doneAt := now.Add(own)
+		estimate.EstimatedDoneAt = &doneAt
+	case entities.WaitingListStatusInService:
+		doneAt := now
+		if ticket.ServiceStartAt != nil && ticket.ServiceStartAt.Add(own).After(now) {
+			doneAt = ticket.ServiceStartAt.Add(own)
+		}
+		estimate.EstimatedDoneAt = &doneAt
+	}
+	return estimate
+}
+func earliestLane(free []time.Time) int {
+	best := 0
+	for i := range free {
+		if free[i].Before(free[best]) {
+			best = i
+		}
+	}
+	return best
+}
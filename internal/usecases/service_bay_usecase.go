@@ -0,0 +1,109 @@
+package usecases
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+type ServiceBayUsecase struct {
+	serviceBayRepo  repositories.ServiceBayRepository
+	waitingListRepo repositories.WaitingListRepository
+}
+func NewServiceBayUsecase(serviceBayRepo repositories.ServiceBayRepository, waitingListRepo repositories.WaitingListRepository) *ServiceBayUsecase {
+	return &ServiceBayUsecase{
+		serviceBayRepo:  serviceBayRepo,
+		waitingListRepo: waitingListRepo,
+	}
+}
+func (u *ServiceBayUsecase) CreateServiceBay(ctx context.Context, bay *entities.ServiceBay) error {
+	bay.Code = strings.ToUpper(strings.TrimSpace(bay.Code))
+	existing, err := u.serviceBayRepo.GetByCode(ctx, bay.Code)
+	if err != nil {
+		return fmt.Errorf("failed to check existing service bay: %w", err)
+	}
+	if existing != nil {
+		return errors.New("service bay with this code already exists")
+	}
+	return u.serviceBayRepo.Create(ctx, bay)
+}
+func (u *ServiceBayUsecase) GetServiceBay(ctx context.Context, id types.MSSQLUUID) (*entities.ServiceBay, error) {
+	bay, err := u.serviceBayRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if bay == nil {
+		return nil, errors.New("service bay not found")
+	}
+	return bay, nil
+}
+func (u *ServiceBayUsecase) GetAllServiceBays(ctx context.Context) ([]*entities.ServiceBay, error) {
+	return u.serviceBayRepo.GetAll(ctx)
+}
+func (u *ServiceBayUsecase) UpdateServiceBay(ctx context.Context, id types.MSSQLUUID, req *dto.UpdateServiceBayRequest) (*entities.ServiceBay, error) {
+	bay, err := u.GetServiceBay(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		bay.Name = req.Name
+	}
+	if req.Notes != "" {
+		bay.Notes = req.Notes
+	}
+	if req.IsActive != nil {
+		bay.IsActive = *req.IsActive
+	}
+	if err := u.serviceBayRepo.Update(ctx, bay); err != nil {
+		return nil, err
+	}
+	return bay, nil
+}
+func (u *ServiceBayUsecase) DeleteServiceBay(ctx context.Context, id types.MSSQLUUID) error {
+	if _, err := u.GetServiceBay(ctx, id); err != nil {
+		return err
+	}
+	return u.serviceBayRepo.Delete(ctx, id)
+}
+func (u *ServiceBayUsecase) GetBayOverview(ctx context.Context) (*dto.ServiceBayOverview, error) {
+	bays, err := u.serviceBayRepo.GetActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tickets, err := u.waitingListRepo.GetByServiceDate(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get today's queue: %w", err)
+	}
+	overview := BuildBayOverview(bays, tickets)
+	return &overview, nil
+}
+// BuildBayOverview matches in-service tickets to their bays. Customer details
+// are reduced to a masked plate so the overview is safe to show to customers.
+func BuildBayOverview(bays []*entities.ServiceBay, tickets []*entities.WaitingList) dto.ServiceBayOverview {
+	overview := dto.ServiceBayOverview{
+		Bays:      make([]dto.ServiceBayStatus, 0, len(bays)),
+		TotalBays: len(bays),
+	}
+	for _, bay := range bays {
+		status := dto.ServiceBayStatus{ID: bay.ID, Code: bay.Code, Name: bay.Name}
+		for _, t := range tickets {
+			if t.Status == entities.WaitingListStatusInService && t.ServiceBayID != nil && *t.ServiceBayID == bay.ID {
+				status.Busy = true
+				status.QueueNumber = t.QueueNumber
+				status.ServiceType = t.ServiceType
+				status.LicensePlate = MaskLicensePlate(t.Vehicle.LicensePlate)
+				status.ServiceStartAt = t.ServiceStartAt
+				break
+			}
+		}
+		if !status.Busy {
+			overview.FreeBays++
+		}
+		overview.Bays = append(overview.Bays, status)
+	}
+	return overview
+}
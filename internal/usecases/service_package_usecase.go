@@ -0,0 +1,339 @@
+package usecases
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+// ErrUnknownServicePackage is returned for package codes that do not exist
+// or have been deactivated.
+var ErrUnknownServicePackage = errors.New("unknown service package")
+// ErrPackageNotApplicable is returned when a package cannot be applied to a
+// ticket as requested.
+var ErrPackageNotApplicable = errors.New("package cannot be applied")
+type ServicePackageUsecase struct {
+	packageRepo repositories.ServicePackageRepository
+	productRepo repositories.ProductRepository
+}
+func NewServicePackageUsecase(packageRepo repositories.ServicePackageRepository, productRepo repositories.ProductRepository) *ServicePackageUsecase {
+	return &ServicePackageUsecase{
+		packageRepo: packageRepo,
+		productRepo: productRepo,
+	}
+}
+func (u *ServicePackageUsecase) CreatePackage(ctx context.Context, req *dto.ServicePackageRequest) (*dto.ServicePackageResponse, error) {
+	pkg, err := u.buildPackage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := u.packageRepo.GetByCode(ctx, pkg.Code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing package: %w", err)
+	}
+	if existing != nil {
+		return nil, errors.New("service package with this code already exists")
+	}
+	if err := u.packageRepo.Create(ctx, pkg); err != nil {
+		return nil, err
+	}
+	return u.buildResponse(ctx, pkg)
+}
+func (u *ServicePackageUsecase) GetPackage(ctx context.Context, id types.MSSQLUUID) (*dto.ServicePackageResponse, error) {
+	pkg, err := u.getPackage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return u.buildResponse(ctx, pkg)
+}
+func (u *ServicePackageUsecase) GetAllPackages(ctx context.Context) ([]*dto.ServicePackageResponse, error) {
+	packages, err := u.packageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.buildResponses(ctx, packages)
+}
+func (u *ServicePackageUsecase) GetActivePackages(ctx context.Context) ([]*dto.ServicePackageResponse, error) {
+	packages, err := u.packageRepo.GetActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.buildResponses(ctx, packages)
+}
+// UpdatePackage replaces the package's settings and items. The code cannot
+// change because maintenance items refer to the package by code.
+func (u *ServicePackageUsecase) UpdatePackage(ctx context.Context, id types.MSSQLUUID, req *dto.ServicePackageRequest) (*dto.ServicePackageResponse, error) {
+	existing, err := u.getPackage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := u.buildPackage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if pkg.Code != existing.Code {
+		return nil, errors.New("package code cannot be changed")
+	}
+	pkg.ID = existing.ID
+	pkg.CreatedAt = existing.CreatedAt
+	if err := u.packageRepo.Update(ctx, pkg); err != nil {
+		return nil, err
+	}
+	return u.buildResponse(ctx, pkg)
+}
+func (u *ServicePackageUsecase) DeletePackage(ctx context.Context, id types.MSSQLUUID) error {
+	if _, err := u.getPackage(ctx, id); err != nil {
+		return err
+	}
+	return u.packageRepo.Delete(ctx, id)
+}
+// GetActiveByCode returns the active package with code and the current price
+// of every product its items use.
+func (u *ServicePackageUsecase) GetActiveByCode(ctx context.Context, code string) (*entities.ServicePackage, map[types.MSSQLUUID]float64, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	pkg, err := u.packageRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pkg == nil || !pkg.IsActive {
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnknownServicePackage, code)
+	}
+	products, err := u.loadProducts(ctx, pkg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pkg, productPrices(products), nil
+}
+func (u *ServicePackageUsecase) getPackage(ctx context.Context, id types.MSSQLUUID) (*entities.ServicePackage, error) {
+	pkg, err := u.packageRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pkg == nil {
+		return nil, errors.New("service package not found")
+	}
+	return pkg, nil
+}
+func (u *ServicePackageUsecase) buildPackage(ctx context.Context, req *dto.ServicePackageRequest) (*entities.ServicePackage, error) {
+	pkg := &entities.ServicePackage{
+		Code:            strings.ToUpper(strings.TrimSpace(req.Code)),
+		Name:            strings.TrimSpace(req.Name),
+		Description:     req.Description,
+		DiscountPercent: req.DiscountPercent,
+		FixedPrice:      req.FixedPrice,
+		IsActive:        req.IsActive == nil || *req.IsActive,
+	}
+	if pkg.Code == "" || pkg.Name == "" {
+		return nil, errors.New("code and name are required")
+	}
+	if pkg.DiscountPercent < 0 || pkg.DiscountPercent > 100 {
+		return nil, errors.New("discount percent must be between 0 and 100")
+	}
+	if pkg.FixedPrice < 0 {
+		return nil, errors.New("fixed price cannot be negative")
+	}
+	if len(req.Items) == 0 {
+		return nil, errors.New("a package needs at least one item")
+	}
+	names := make(map[string]bool, len(req.Items))
+	for i, itemReq := range req.Items {
+		item := entities.ServicePackageItem{
+			Position:      i + 1,
+			Category:      strings.TrimSpace(itemReq.Category),
+			Name:          strings.TrimSpace(itemReq.Name),
+			Description:   itemReq.Description,
+			EstimatedCost: itemReq.EstimatedCost,
+			LaborHours:    itemReq.LaborHours,
+		}
+		if item.Category == "" || item.Name == "" {
+			return nil, fmt.Errorf("item %d: category and name are required", i+1)
+		}
+		if names[strings.ToLower(item.Name)] {
+			return nil, fmt.Errorf("item %d: %q is listed twice", i+1, item.Name)
+		}
+		names[strings.ToLower(item.Name)] = true
+		if item.EstimatedCost < 0 || item.LaborHours < 0 {
+			return nil, fmt.Errorf("item %d: estimated cost and labor hours cannot be negative", i+1)
+		}
+		for _, partReq := range itemReq.Parts {
+			if partReq.Quantity < 1 {
+				return nil, fmt.Errorf("item %d: part quantity must be at least 1", i+1)
+			}
+			product, err := u.productRepo.GetByID(ctx, partReq.ProductID)
+			if err != nil {
+				return nil, err
+			}
+			if product == nil {
+				return nil, fmt.Errorf("item %d: product %s not found", i+1, partReq.ProductID)
+			}
+			item.Parts = append(item.Parts, entities.ServicePackagePart{ProductID: partReq.ProductID, Quantity: partReq.Quantity})
+		}
+		pkg.Items = append(pkg.Items, item)
+	}
+	return pkg, nil
+}
+func (u *ServicePackageUsecase) loadProducts(ctx context.Context, packages ...*entities.ServicePackage) (map[types.MSSQLUUID]*entities.Product, error) {
+	products := make(map[types.MSSQLUUID]*entities.Product)
+	for _, pkg := range packages {
+		for _, item := range pkg.Items {
+			for _, part := range item.Parts {
+				if _, ok := products[part.ProductID]; ok {
+					continue
+				}
+				product, err := u.productRepo.GetByID(ctx, part.ProductID)
+				if err != nil {
+					return nil, err
+				}
+				products[part.ProductID] = product // nil for deleted products, priced at 0
+			}
+		}
+	}
+	return products, nil
+}
+func (u *ServicePackageUsecase) buildResponses(ctx context.Context, packages []*entities.ServicePackage) ([]*dto.ServicePackageResponse, error) {
+	products, err := u.loadProducts(ctx, packages...)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*dto.ServicePackageResponse, len(packages))
+	for i, pkg := range packages {
+		responses[i] = buildPackageResponse(pkg, products)
+	}
+	return responses, nil
+}
+func (u *ServicePackageUsecase) buildResponse(ctx context.Context, pkg *entities.ServicePackage) (*dto.ServicePackageResponse, error) {
+	products, err := u.loadProducts(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+	return buildPackageResponse(pkg, products), nil
+}
+func buildPackageResponse(pkg *entities.ServicePackage, products map[types.MSSQLUUID]*entities.Product) *dto.ServicePackageResponse {
+	prices := productPrices(products)
+	listPrice := PackageListPrice(pkg, prices)
+	resp := &dto.ServicePackageResponse{
+		ID:              pkg.ID,
+		Code:            pkg.Code,
+		Name:            pkg.Name,
+		Description:     pkg.Description,
+		DiscountPercent: pkg.DiscountPercent,
+		FixedPrice:      pkg.FixedPrice,
+		IsActive:        pkg.IsActive,
+		ListPrice:       listPrice,
+		Price:           roundMoney(listPrice * (1 - PackageDiscountRate(pkg, listPrice))),
+		Items:           make([]dto.ServicePackageItemResponse, len(pkg.Items)),
+		CreatedAt:       pkg.CreatedAt,
+		UpdatedAt:       pkg.UpdatedAt,
+	}
+	for i, item := range pkg.Items {
+		resp.Items[i] = dto.ServicePackageItemResponse{
+			Category:      item.Category,
+			Name:          item.Name,
+			Description:   item.Description,
+			EstimatedCost: item.EstimatedCost,
+			LaborHours:    item.LaborHours,
+		}
+		for _, part := range item.Parts {
+			partResp := dto.ServicePackagePartResponse{ProductID: part.ProductID, Quantity: part.Quantity}
+			if product := products[part.ProductID]; product != nil {
+				partResp.Name = product.Name
+				partResp.UnitPrice = product.Price
+			}
+			resp.Items[i].Parts = append(resp.Items[i].Parts, partResp)
+		}
+	}
+	return resp
+}
+func productPrices(products map[types.MSSQLUUID]*entities.Product) map[types.MSSQLUUID]float64 {
+	prices := make(map[types.MSSQLUUID]float64, len(products))
+	for id, product := range products {
+		if product != nil {
+			prices[id] = product.Price
+		}
+	}
+	return prices
+}
+// PackageItemListPrice is the full price of one package item: its estimated
+// cost plus its parts at the given product prices.
+func PackageItemListPrice(item entities.ServicePackageItem, prices map[types.MSSQLUUID]float64) float64 {
+	total := item.EstimatedCost
+	for _, part := range item.Parts {
+		total += prices[part.ProductID] * float64(part.Quantity)
+	}
+	return roundMoney(total)
+}
+func PackageListPrice(pkg *entities.ServicePackage, prices map[types.MSSQLUUID]float64) float64 {
+	total := 0.0
+	for _, item := range pkg.Items {
+		total += PackageItemListPrice(item, prices)
+	}
+	return roundMoney(total)
+}
+// PackageDiscountRate is the share of the list price the package takes off.
+// A fixed price is turned into a rate so every item carries its part of the
+// discount and leaving an item out lowers the price by what it was worth.
+func PackageDiscountRate(pkg *entities.ServicePackage, listPrice float64) float64 {
+	if pkg.FixedPrice > 0 {
+		if listPrice <= 0 || pkg.FixedPrice >= listPrice {
+			return 0
+		}
+		return 1 - pkg.FixedPrice/listPrice
+	}
+	return math.Min(math.Max(pkg.DiscountPercent, 0), 100) / 100
+}
+// ExpandPackage turns pkg into pending initial maintenance items, leaving
+// out the items named in exclude. Unknown names in exclude are an error.
+func ExpandPackage(pkg *entities.ServicePackage, prices map[types.MSSQLUUID]float64, exclude []string) ([]*entities.MaintenanceItem, error) {
+	skip := make(map[string]bool, len(exclude))
+	for _, name := range exclude {
+		skip[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	rate := PackageDiscountRate(pkg, PackageListPrice(pkg, prices))
+	items := make([]*entities.MaintenanceItem, 0, len(pkg.Items))
+	for _, template := range pkg.Items {
+		key := strings.ToLower(template.Name)
+		if skip[key] {
+			delete(skip, key)
+			continue
+		}
+		listPrice := PackageItemListPrice(template, prices)
+		discount := roundMoney(listPrice * rate)
+		items = append(items, &entities.MaintenanceItem{
+			ItemType:       entities.MaintenanceItemTypeInitial,
+			Status:         entities.MaintenanceItemStatusPending,
+			Category:       template.Category,
+			Name:           template.Name,
+			Description:    template.Description,
+			Priority:       "normal",
+			EstimatedCost:  listPrice - discount,
+			DiscountAmount: discount,
+			LaborHours:     template.LaborHours,
+			PackageCode:    pkg.Code,
+			Parts:          packageItemParts(template, prices),
+		})
+	}
+	for name := range skip {
+		return nil, fmt.Errorf("%w: package %s has no item named %q", ErrPackageNotApplicable, pkg.Code, name)
+	}
+	return items, nil
+}
+// packageItemParts copies the template's parts onto the new item at today's
+// prices so completing the item takes them out of stock.
+func packageItemParts(template entities.ServicePackageItem, prices map[types.MSSQLUUID]float64) []entities.MaintenanceItemPart {
+	var parts []entities.MaintenanceItemPart
+	for _, part := range template.Parts {
+		parts = append(parts, entities.MaintenanceItemPart{
+			ProductID: part.ProductID,
+			Quantity:  part.Quantity,
+			UnitPrice: prices[part.ProductID],
+		})
+	}
+	return parts
+}
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,154 @@
+package usecases
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+// ErrUnknownServiceType is returned for service types that are not in the
+// catalog or have been deactivated.
+var ErrUnknownServiceType = errors.New("unknown service type")
+type ServiceTypeUsecase struct {
+	serviceTypeRepo repositories.ServiceTypeRepository
+}
+func NewServiceTypeUsecase(serviceTypeRepo repositories.ServiceTypeRepository) *ServiceTypeUsecase {
+	return &ServiceTypeUsecase{
+		serviceTypeRepo: serviceTypeRepo,
+	}
+}
+func (u *ServiceTypeUsecase) CreateServiceType(ctx context.Context, serviceType *entities.ServiceType) error {
+	serviceType.Code = NormalizeServiceTypeCode(serviceType.Code)
+	serviceType.Name = strings.TrimSpace(serviceType.Name)
+	serviceType.Category = strings.TrimSpace(serviceType.Category)
+	if serviceType.Code == "" || serviceType.Name == "" || serviceType.Category == "" {
+		return errors.New("code, name and category are required")
+	}
+	if err := validateServiceType(serviceType); err != nil {
+		return err
+	}
+	existing, err := u.serviceTypeRepo.GetByCode(ctx, serviceType.Code)
+	if err != nil {
+		return fmt.Errorf("failed to check existing service type: %w", err)
+	}
+	if existing != nil {
+		return errors.New("service type with this code already exists")
+	}
+	if err := u.checkNameFree(ctx, serviceType); err != nil {
+		return err
+	}
+	return u.serviceTypeRepo.Create(ctx, serviceType)
+}
+func (u *ServiceTypeUsecase) GetServiceType(ctx context.Context, id types.MSSQLUUID) (*entities.ServiceType, error) {
+	serviceType, err := u.serviceTypeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if serviceType == nil {
+		return nil, errors.New("service type not found")
+	}
+	return serviceType, nil
+}
+func (u *ServiceTypeUsecase) GetAllServiceTypes(ctx context.Context) ([]*entities.ServiceType, error) {
+	return u.serviceTypeRepo.GetAll(ctx)
+}
+func (u *ServiceTypeUsecase) GetActiveServiceTypes(ctx context.Context) ([]*entities.ServiceType, error) {
+	return u.serviceTypeRepo.GetActive(ctx)
+}
+func (u *ServiceTypeUsecase) UpdateServiceType(ctx context.Context, id types.MSSQLUUID, req *dto.UpdateServiceTypeRequest) (*entities.ServiceType, error) {
+	serviceType, err := u.GetServiceType(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if name := strings.TrimSpace(req.Name); name != "" {
+		serviceType.Name = name
+		if err := u.checkNameFree(ctx, serviceType); err != nil {
+			return nil, err
+		}
+	}
+	if category := strings.TrimSpace(req.Category); category != "" {
+		serviceType.Category = category
+	}
+	if req.DefaultDuration != nil {
+		serviceType.DefaultDuration = *req.DefaultDuration
+	}
+	if req.BaseLaborPrice != nil {
+		serviceType.BaseLaborPrice = *req.BaseLaborPrice
+	}
+	if req.IsActive != nil {
+		serviceType.IsActive = *req.IsActive
+	}
+	if err := validateServiceType(serviceType); err != nil {
+		return nil, err
+	}
+	if err := u.serviceTypeRepo.Update(ctx, serviceType); err != nil {
+		return nil, err
+	}
+	return serviceType, nil
+}
+func (u *ServiceTypeUsecase) DeleteServiceType(ctx context.Context, id types.MSSQLUUID) error {
+	if _, err := u.GetServiceType(ctx, id); err != nil {
+		return err
+	}
+	return u.serviceTypeRepo.Delete(ctx, id)
+}
+// ResolveServiceType finds the active catalog entry for what a customer
+// picked, matching either the code or the name regardless of case.
+func (u *ServiceTypeUsecase) ResolveServiceType(ctx context.Context, value string) (*entities.ServiceType, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, fmt.Errorf("%w: service type is required", ErrUnknownServiceType)
+	}
+	serviceType, err := u.serviceTypeRepo.GetByCode(ctx, NormalizeServiceTypeCode(value))
+	if err != nil {
+		return nil, err
+	}
+	if serviceType == nil {
+		if serviceType, err = u.serviceTypeRepo.GetByName(ctx, value); err != nil {
+			return nil, err
+		}
+	}
+	if serviceType == nil || !serviceType.IsActive {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownServiceType, strings.TrimSpace(value))
+	}
+	return serviceType, nil
+}
+func (u *ServiceTypeUsecase) checkNameFree(ctx context.Context, serviceType *entities.ServiceType) error {
+	existing, err := u.serviceTypeRepo.GetByName(ctx, serviceType.Name)
+	if err != nil {
+		return fmt.Errorf("failed to check existing service type: %w", err)
+	}
+	if existing != nil && existing.ID != serviceType.ID {
+		return errors.New("service type with this name already exists")
+	}
+	return nil
+}
+func validateServiceType(serviceType *entities.ServiceType) error {
+	if serviceType.DefaultDuration < 0 {
+		return errors.New("default duration cannot be negative")
+	}
+	if serviceType.BaseLaborPrice < 0 {
+		return errors.New("base labor price cannot be negative")
+	}
+	return nil
+}
+// NormalizeServiceTypeCode upper-cases code and joins its words with
+// underscores, so "oil change" and "Oil-Change" both become OIL_CHANGE.
+func NormalizeServiceTypeCode(code string) string {
+	var b strings.Builder
+	pending := false
+	for _, r := range strings.ToUpper(strings.TrimSpace(code)) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			if pending && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			pending = false
+			b.WriteRune(r)
+			continue
+		}
+		pending = true
+	}
+	return b.String()
+}
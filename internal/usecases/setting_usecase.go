@@ -3,6 +3,8 @@ import (
 	"context"
 	"errors"
 	"strconv"
+	"strings"
+	"time"
 	"github.com/kuahbanyak/go-crud/internal/domain/entities"
 	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
 	"github.com/kuahbanyak/go-crud/internal/shared/types"
@@ -97,6 +99,13 @@ func (u *SettingUsecase) GetFloatValue(ctx context.Context, key string, defaultV
 func (u *SettingUsecase) GetMaxTicketsPerDay(ctx context.Context) int {
 	return u.GetIntValue(ctx, "waiting_list.max_tickets_per_day", 10)
 }
+// GetLimitMode returns LimitModeTickets or LimitModeCapacity.
+func (u *SettingUsecase) GetLimitMode(ctx context.Context) string {
+	if strings.EqualFold(strings.TrimSpace(u.GetStringValue(ctx, "waiting_list.limit_mode", LimitModeTickets)), LimitModeCapacity) {
+		return LimitModeCapacity
+	}
+	return LimitModeTickets
+}
 func (u *SettingUsecase) GetCleanupRetentionDays(ctx context.Context) int {
 	return u.GetIntValue(ctx, "waiting_list.cleanup_retention_days", 7)
 }
@@ -106,4 +115,88 @@ func (u *SettingUsecase) IsCleanupJobEnabled(ctx context.Context) bool {
 func (u *SettingUsecase) GetJobSchedule(ctx context.Context) string {
 	return u.GetStringValue(ctx, "waiting_list.job_schedule", "0 0 * * *")
 }
+func (u *SettingUsecase) GetFutureBookingDays(ctx context.Context) int {
+	return u.GetIntValue(ctx, "waiting_list.allow_future_booking_days", 30)
+}
+func (u *SettingUsecase) GetSlotIntervalMinutes(ctx context.Context) int {
+	return u.GetIntValue(ctx, "waiting_list.slot_interval_minutes", 30)
+}
+func (u *SettingUsecase) GetDefaultServiceMinutes(ctx context.Context) int {
+	return u.GetIntValue(ctx, "waiting_list.default_service_minutes", 30)
+}
+func (u *SettingUsecase) GetETAHistoryDays(ctx context.Context) int {
+	return u.GetIntValue(ctx, "waiting_list.eta_history_days", 90)
+}
+func (u *SettingUsecase) IsAutoAssignMechanicEnabled(ctx context.Context) bool {
+	return u.GetBoolValue(ctx, "waiting_list.auto_assign_mechanic", false)
+}
+func (u *SettingUsecase) IsNoShowJobEnabled(ctx context.Context) bool {
+	return u.GetBoolValue(ctx, "waiting_list.no_show_job_enabled", false)
+}
+func (u *SettingUsecase) GetNoShowJobSchedule(ctx context.Context) string {
+	return u.GetStringValue(ctx, "waiting_list.no_show_job_schedule", "*/5 * * * *")
+}
+func (u *SettingUsecase) GetNoShowGraceMinutes(ctx context.Context) int {
+	return u.GetIntValue(ctx, "waiting_list.no_show_grace_minutes", 15)
+}
+func (u *SettingUsecase) IsAutoCallNextEnabled(ctx context.Context) bool {
+	return u.GetBoolValue(ctx, "waiting_list.auto_call_next", false)
+}
+func (u *SettingUsecase) GetApprovalLinkHours(ctx context.Context) int {
+	return u.GetIntValue(ctx, "maintenance.approval_link_hours", 48)
+}
+func (u *SettingUsecase) IsApprovalJobEnabled(ctx context.Context) bool {
+	return u.GetBoolValue(ctx, "maintenance.approval_job_enabled", true)
+}
+func (u *SettingUsecase) GetApprovalJobSchedule(ctx context.Context) string {
+	return u.GetStringValue(ctx, "maintenance.approval_job_schedule", "*/5 * * * *")
+}
+func (u *SettingUsecase) GetTaxPercent(ctx context.Context) float64 {
+	return u.GetFloatValue(ctx, "maintenance.tax_percent", 11)
+}
+func (u *SettingUsecase) GetEstimateOverrunPercent(ctx context.Context) float64 {
+	return u.GetFloatValue(ctx, "maintenance.estimate_overrun_percent", 10)
+}
+func (u *SettingUsecase) GetBusinessHours(ctx context.Context) BusinessHours {
+	hours := BusinessHours{
+		Opening:     parseClock(u.GetStringValue(ctx, "business.opening_time", "08:00"), 8*time.Hour),
+		Closing:     parseClock(u.GetStringValue(ctx, "business.closing_time", "18:00"), 18*time.Hour),
+		WorkingDays: make(map[time.Weekday]bool),
+	}
+	days := u.GetStringValue(ctx, "business.working_days", "Monday,Tuesday,Wednesday,Thursday,Friday,Saturday")
+	for _, day := range strings.Split(days, ",") {
+		for wd := time.Sunday; wd <= time.Saturday; wd++ {
+			if strings.EqualFold(strings.TrimSpace(day), wd.String()) {
+				hours.WorkingDays[wd] = true
+			}
+		}
+	}
+	return hours
+}
 
+// BusinessHours holds the shop's daily opening window and working weekdays,
+// with Opening and Closing expressed as offsets from midnight.
+type BusinessHours struct {
+	Opening     time.Duration
+	Closing     time.Duration
+	WorkingDays map[time.Weekday]bool
+}
+func (h BusinessHours) IsWorkingDay(date time.Time) bool {
+	return h.WorkingDays[date.Weekday()]
+}
+func (h BusinessHours) OpenAt(date time.Time) time.Time {
+	return startOfDay(date).Add(h.Opening)
+}
+func (h BusinessHours) CloseAt(date time.Time) time.Time {
+	return startOfDay(date).Add(h.Closing)
+}
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+func parseClock(value string, fallback time.Duration) time.Duration {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return fallback
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
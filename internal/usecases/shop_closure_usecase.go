@@ -0,0 +1,193 @@
+package usecases
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+// ErrShopClosed is wrapped by errors for dates the shop does not open.
+var ErrShopClosed = errors.New("the shop is closed")
+// TimeWindow is a closed period within a working day.
+type TimeWindow struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+type ShopClosureUsecase struct {
+	closureRepo    repositories.ShopClosureRepository
+	settingUsecase *SettingUsecase
+}
+func NewShopClosureUsecase(closureRepo repositories.ShopClosureRepository, settingUsecase *SettingUsecase) *ShopClosureUsecase {
+	return &ShopClosureUsecase{
+		closureRepo:    closureRepo,
+		settingUsecase: settingUsecase,
+	}
+}
+func (u *ShopClosureUsecase) CreateClosure(ctx context.Context, closure *entities.ShopClosure) error {
+	if err := validateClosure(closure); err != nil {
+		return err
+	}
+	return u.closureRepo.Create(ctx, closure)
+}
+func (u *ShopClosureUsecase) GetClosure(ctx context.Context, id types.MSSQLUUID) (*entities.ShopClosure, error) {
+	closure, err := u.closureRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if closure == nil {
+		return nil, errors.New("closure not found")
+	}
+	return closure, nil
+}
+func (u *ShopClosureUsecase) GetAllClosures(ctx context.Context) ([]*entities.ShopClosure, error) {
+	return u.closureRepo.GetAll(ctx)
+}
+func (u *ShopClosureUsecase) UpdateClosure(ctx context.Context, id types.MSSQLUUID, updates *entities.ShopClosure) (*entities.ShopClosure, error) {
+	closure, err := u.GetClosure(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	closure.Name = updates.Name
+	closure.StartDate = updates.StartDate
+	closure.EndDate = updates.EndDate
+	closure.StartTime = updates.StartTime
+	closure.EndTime = updates.EndTime
+	closure.IsRecurring = updates.IsRecurring
+	if err := validateClosure(closure); err != nil {
+		return nil, err
+	}
+	if err := u.closureRepo.Update(ctx, closure); err != nil {
+		return nil, err
+	}
+	return closure, nil
+}
+func (u *ShopClosureUsecase) DeleteClosure(ctx context.Context, id types.MSSQLUUID) error {
+	if _, err := u.GetClosure(ctx, id); err != nil {
+		return err
+	}
+	return u.closureRepo.Delete(ctx, id)
+}
+func validateClosure(closure *entities.ShopClosure) error {
+	if closure.Name == "" {
+		return errors.New("closure name is required")
+	}
+	if closure.EndDate.IsZero() {
+		closure.EndDate = closure.StartDate
+	}
+	if closure.EndDate.Before(closure.StartDate) && !closure.IsRecurring {
+		return errors.New("end_date cannot be before start_date")
+	}
+	if closure.IsFullDay() {
+		return nil
+	}
+	start, errStart := time.Parse("15:04", closure.StartTime)
+	end, errEnd := time.Parse("15:04", closure.EndTime)
+	if errStart != nil || errEnd != nil {
+		return errors.New("start_time and end_time must both be set in HH:MM format for a partial-day closure")
+	}
+	if !end.After(start) {
+		return errors.New("end_time must be after start_time")
+	}
+	return nil
+}
+// CheckOpen returns an error wrapping ErrShopClosed when date is not a working
+// day or a full-day closure covers it.
+func (u *ShopClosureUsecase) CheckOpen(ctx context.Context, date time.Time) error {
+	hours := u.settingUsecase.GetBusinessHours(ctx)
+	if !hours.IsWorkingDay(date) {
+		return fmt.Errorf("%w on %s", ErrShopClosed, date.Weekday())
+	}
+	closures, err := u.closureRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get shop closures: %w", err)
+	}
+	for _, closure := range closures {
+		if closure.IsFullDay() && closure.Covers(date) {
+			return fmt.Errorf("%w on %s (%s)", ErrShopClosed, date.Format("2006-01-02"), closure.Name)
+		}
+	}
+	return nil
+}
+// ClosedWindows lists the partial-day closures that apply on date.
+func (u *ShopClosureUsecase) ClosedWindows(ctx context.Context, date time.Time) ([]TimeWindow, error) {
+	closures, err := u.closureRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shop closures: %w", err)
+	}
+	return closedWindows(closures, date), nil
+}
+func closedWindows(closures []*entities.ShopClosure, date time.Time) []TimeWindow {
+	var windows []TimeWindow
+	for _, closure := range closures {
+		if closure.IsFullDay() || !closure.Covers(date) {
+			continue
+		}
+		windows = append(windows, TimeWindow{
+			Name:  closure.Name,
+			Start: startOfDay(date).Add(parseClock(closure.StartTime, 0)),
+			End:   startOfDay(date).Add(parseClock(closure.EndTime, 0)),
+		})
+	}
+	return windows
+}
+func overlapsWindow(start, end time.Time, windows []TimeWindow) (TimeWindow, bool) {
+	for _, window := range windows {
+		if window.Start.Before(end) && window.End.After(start) {
+			return window, true
+		}
+	}
+	return TimeWindow{}, false
+}
+// GetCalendar describes each day from from through from+days-1 so clients can
+// grey out days the shop is closed.
+func (u *ShopClosureUsecase) GetCalendar(ctx context.Context, from time.Time, days int) (*dto.ShopCalendarResponse, error) {
+	closures, err := u.closureRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shop closures: %w", err)
+	}
+	hours := u.settingUsecase.GetBusinessHours(ctx)
+	calendar := &dto.ShopCalendarResponse{
+		From: from.Format("2006-01-02"),
+		To:   from.AddDate(0, 0, days-1).Format("2006-01-02"),
+		Days: make([]dto.CalendarDayResponse, 0, days),
+	}
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if hours.WorkingDays[wd] {
+			calendar.WorkingDays = append(calendar.WorkingDays, wd.String())
+		}
+	}
+	for i := 0; i < days; i++ {
+		date := from.AddDate(0, 0, i)
+		day := dto.CalendarDayResponse{
+			Date:         date.Format("2006-01-02"),
+			IsWorkingDay: hours.IsWorkingDay(date),
+		}
+		day.IsOpen = day.IsWorkingDay
+		if !day.IsWorkingDay {
+			day.Reason = "Not a working day"
+		}
+		for _, closure := range closures {
+			if day.IsOpen && closure.IsFullDay() && closure.Covers(date) {
+				day.IsOpen = false
+				day.Reason = closure.Name
+			}
+		}
+		if day.IsOpen {
+			day.OpeningTime = hours.OpenAt(date).Format("15:04")
+			day.ClosingTime = hours.CloseAt(date).Format("15:04")
+			for _, window := range closedWindows(closures, date) {
+				day.Closures = append(day.Closures, dto.ClosureWindowResponse{
+					Name:      window.Name,
+					StartTime: window.Start.Format("15:04"),
+					EndTime:   window.End.Format("15:04"),
+				})
+			}
+		}
+		calendar.Days = append(calendar.Days, day)
+	}
+	return calendar, nil
+}
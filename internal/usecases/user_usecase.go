@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/kuahbanyak/go-crud/internal/domain/entities"
 	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
@@ -43,6 +44,9 @@ func (u *UserUsecase) Login(ctx context.Context, email, password string) (*entit
 	if err := u.authService.ComparePassword(user.Password, password); err != nil {
 		return nil, "", errors.New("invalid credentials")
 	}
+	if user.IsGuest {
+		return nil, "", errors.New("guest accounts cannot log in")
+	}
 
 	// Get role name from Roles relationship (use first role if multiple, or empty string if none)
 	roleName := ""
@@ -73,6 +77,9 @@ func (u *UserUsecase) UpdateUser(ctx context.Context, id types.MSSQLUUID, update
 	if updateData.Phone != "" {
 		existingUser.Phone = updateData.Phone
 	}
+	if updateData.SkillLevel != "" {
+		existingUser.SkillLevel = strings.ToLower(strings.TrimSpace(updateData.SkillLevel))
+	}
 	err = u.userRepo.Update(ctx, existingUser)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,146 @@
+package usecases
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+)
+const (
+	// LimitModeTickets caps each day at waiting_list.max_tickets_per_day tickets.
+	LimitModeTickets = "tickets"
+	// LimitModeCapacity caps each day at the bay-minutes the shop is open and
+	// only accepts tickets whose estimated time still fits.
+	LimitModeCapacity = "capacity"
+)
+// DayAvailability describes how much room a service date has left in both
+// tickets and bay-minutes. Available follows the configured limit mode.
+type DayAvailability struct {
+	Mode             string
+	Available        bool
+	ActiveTickets    int
+	MaxTickets       int
+	RemainingTickets int
+	CapacityMinutes  int
+	UsedMinutes      int
+	RemainingMinutes int
+}
+// DayCapacityMinutes is the number of bay-minutes available on date: the
+// opening hours minus partial closures, times the number of bays.
+func DayCapacityMinutes(hours BusinessHours, date time.Time, bays int, closed []TimeWindow) int {
+	if !hours.IsWorkingDay(date) {
+		return 0
+	}
+	openAt, closeAt := hours.OpenAt(date), hours.CloseAt(date)
+	open := closeAt.Sub(openAt)
+	for _, window := range closed {
+		start, end := window.Start, window.End
+		if start.Before(openAt) {
+			start = openAt
+		}
+		if end.After(closeAt) {
+			end = closeAt
+		}
+		if end.After(start) {
+			open -= end.Sub(start)
+		}
+	}
+	if open <= 0 {
+		return 0
+	}
+	return int(open/time.Minute) * max(bays, 1)
+}
+// TicketMinutes is the sum of estimated minutes of the active tickets in
+// entries, counting defaultMinutes for tickets without an estimate.
+func TicketMinutes(entries []*entities.WaitingList, defaultMinutes int) (int, int) {
+	tickets, minutes := 0, 0
+	for _, entry := range entries {
+		if !isOpenTicket(entry) {
+			continue
+		}
+		tickets++
+		if entry.EstimatedTime > 0 {
+			minutes += entry.EstimatedTime
+		} else {
+			minutes += defaultMinutes
+		}
+	}
+	return tickets, minutes
+}
+func (u *WaitingListUsecase) GetDayAvailability(ctx context.Context, serviceDate time.Time) (*DayAvailability, error) {
+	if err := u.closureUsecase.CheckOpen(ctx, serviceDate); err != nil {
+		if errors.Is(err, ErrShopClosed) {
+			return &DayAvailability{Mode: u.settingUsecase.GetLimitMode(ctx)}, nil
+		}
+		return nil, err
+	}
+	entries, err := u.waitingListRepo.GetByServiceDate(ctx, serviceDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entries for date: %w", err)
+	}
+	return u.availability(ctx, serviceDate, entries)
+}
+func (u *WaitingListUsecase) availability(ctx context.Context, serviceDate time.Time, entries []*entities.WaitingList) (*DayAvailability, error) {
+	capacity, err := u.dayCapacityMinutes(ctx, serviceDate)
+	if err != nil {
+		return nil, err
+	}
+	defaultMinutes := u.settingUsecase.GetDefaultServiceMinutes(ctx)
+	tickets, minutes := TicketMinutes(entries, defaultMinutes)
+	a := &DayAvailability{
+		Mode:             u.settingUsecase.GetLimitMode(ctx),
+		ActiveTickets:    tickets,
+		MaxTickets:       u.settingUsecase.GetMaxTicketsPerDay(ctx),
+		CapacityMinutes:  capacity,
+		UsedMinutes:      minutes,
+		RemainingMinutes: max(capacity-minutes, 0),
+	}
+	if a.Mode == LimitModeCapacity {
+		a.MaxTickets = 0
+		if defaultMinutes > 0 {
+			a.RemainingTickets = a.RemainingMinutes / defaultMinutes
+		}
+		a.Available = a.RemainingMinutes > 0 && a.RemainingMinutes >= defaultMinutes
+	} else {
+		a.RemainingTickets = max(a.MaxTickets-tickets, 0)
+		a.Available = tickets < a.MaxTickets
+	}
+	return a, nil
+}
+func (u *WaitingListUsecase) dayCapacityMinutes(ctx context.Context, serviceDate time.Time) (int, error) {
+	closed, err := u.closureUsecase.ClosedWindows(ctx, serviceDate)
+	if err != nil {
+		return 0, err
+	}
+	bays, err := u.serviceBayRepo.GetActive(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get service bays: %w", err)
+	}
+	return DayCapacityMinutes(u.settingUsecase.GetBusinessHours(ctx), serviceDate, len(bays), closed), nil
+}
+// dailyLimit returns the limit new tickets for serviceDate are checked
+// against when they are given a queue number.
+func (u *WaitingListUsecase) dailyLimit(ctx context.Context, serviceDate time.Time) (repositories.DailyLimit, error) {
+	defaultMinutes := u.settingUsecase.GetDefaultServiceMinutes(ctx)
+	if u.settingUsecase.GetLimitMode(ctx) != LimitModeCapacity {
+		return repositories.DailyLimit{MaxTickets: u.settingUsecase.GetMaxTicketsPerDay(ctx), DefaultMinutes: defaultMinutes}, nil
+	}
+	capacity, err := u.dayCapacityMinutes(ctx, serviceDate)
+	if err != nil {
+		return repositories.DailyLimit{}, err
+	}
+	if capacity == 0 {
+		return repositories.DailyLimit{}, fmt.Errorf("%w: no service capacity on this date", repositories.ErrCapacityExceeded)
+	}
+	return repositories.DailyLimit{CapacityMinutes: capacity, DefaultMinutes: defaultMinutes}, nil
+}
+func dailyLimitError(err error, limit repositories.DailyLimit) error {
+	if errors.Is(err, repositories.ErrCapacityExceeded) {
+		return err
+	}
+	if errors.Is(err, repositories.ErrDailyLimitReached) {
+		return fmt.Errorf("%w: maximum %d tickets per day (0 remaining)", err, limit.MaxTickets)
+	}
+	return err
+}
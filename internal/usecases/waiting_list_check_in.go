@@ -0,0 +1,53 @@
+package usecases
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+)
+// ErrCheckInWrongDay is returned when a ticket is scanned on a day other than
+// its service date.
+var ErrCheckInWrongDay = errors.New("ticket is not for today")
+// IssueCheckInToken returns the signed token encoded in a ticket's QR code.
+// It stays valid until the end of the ticket's service date.
+func (u *WaitingListUsecase) IssueCheckInToken(ctx context.Context, waitingList *entities.WaitingList) (string, error) {
+	if waitingList.Status != entities.WaitingListStatusWaiting && waitingList.Status != entities.WaitingListStatusCalled {
+		return "", fmt.Errorf("%w: ticket is %s and can no longer be checked in", ErrInvalidTransition, waitingList.Status)
+	}
+	return u.checkInTokens.Sign(waitingList.ID, CheckInExpiry(waitingList.ServiceDate)), nil
+}
+// CheckInExpiry is the last moment a ticket for serviceDate can be checked in.
+func CheckInExpiry(serviceDate time.Time) time.Time {
+	return time.Date(serviceDate.Year(), serviceDate.Month(), serviceDate.Day(), 23, 59, 59, 0, time.Local)
+}
+// CheckIn verifies a scanned QR token and records that the customer has
+// arrived. Scanning the same ticket again keeps the first arrival time.
+func (u *WaitingListUsecase) CheckIn(ctx context.Context, token string) (*entities.WaitingList, error) {
+	now := time.Now()
+	id, err := u.checkInTokens.Verify(token, now)
+	if err != nil {
+		return nil, err
+	}
+	waitingList, err := u.waitingListRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if waitingList == nil {
+		return nil, errors.New("waiting list entry not found")
+	}
+	if waitingList.ServiceDate.Format("2006-01-02") != now.Format("2006-01-02") {
+		return nil, fmt.Errorf("%w: ticket is for %s", ErrCheckInWrongDay, waitingList.ServiceDate.Format("2006-01-02"))
+	}
+	if waitingList.Status != entities.WaitingListStatusWaiting && waitingList.Status != entities.WaitingListStatusCalled {
+		return nil, fmt.Errorf("%w: ticket is %s and can no longer be checked in", ErrInvalidTransition, waitingList.Status)
+	}
+	if waitingList.ArrivedAt != nil {
+		return waitingList, nil
+	}
+	waitingList.ArrivedAt = &now
+	if err := u.waitingListRepo.Update(ctx, waitingList); err != nil {
+		return nil, err
+	}
+	return waitingList, nil
+}
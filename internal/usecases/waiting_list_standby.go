@@ -0,0 +1,135 @@
+package usecases
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+// ErrTicketsAvailable is returned when a customer asks to join the standby
+// list for a day that still has tickets left.
+var ErrTicketsAvailable = errors.New("tickets are still available for this date")
+// JoinStandby puts the customer on the standby list of a fully booked day.
+// Entries are promoted to real tickets in the order they joined.
+func (u *WaitingListUsecase) JoinStandby(ctx context.Context, entry *entities.StandbyEntry) error {
+	if err := u.verifyVehicleAndCustomer(ctx, &entities.WaitingList{VehicleID: entry.VehicleID, CustomerID: entry.CustomerID}); err != nil {
+		return err
+	}
+	ticket := &entities.WaitingList{ServiceType: entry.ServiceType, EstimatedTime: entry.EstimatedTime}
+	if err := u.applyServiceType(ctx, ticket); err != nil {
+		return err
+	}
+	entry.ServiceType, entry.ServiceTypeCode, entry.EstimatedTime = ticket.ServiceType, ticket.ServiceTypeCode, ticket.EstimatedTime
+	if err := u.checkServiceDate(ctx, entry.ServiceDate); err != nil {
+		return err
+	}
+	availability, err := u.GetDayAvailability(ctx, entry.ServiceDate)
+	if err != nil {
+		return err
+	}
+	available := availability.Available
+	if available && availability.Mode == LimitModeCapacity && entry.EstimatedTime > availability.RemainingMinutes {
+		available = false // the day has room, just not for a job this long
+	}
+	if available {
+		return fmt.Errorf("%w: take a queue number instead", ErrTicketsAvailable)
+	}
+	waiting, err := u.standbyRepo.GetWaitingByDate(ctx, entry.ServiceDate)
+	if err != nil {
+		return fmt.Errorf("failed to get standby list: %w", err)
+	}
+	for _, other := range waiting {
+		if other.CustomerID == entry.CustomerID && other.VehicleID == entry.VehicleID {
+			return errors.New("vehicle is already on the standby list for this date")
+		}
+	}
+	entry.Status = entities.StandbyStatusWaiting
+	if err := u.standbyRepo.Create(ctx, entry); err != nil {
+		return err
+	}
+	entry.Position = len(waiting) + 1
+	return nil
+}
+// GetCustomerStandby returns the customer's standby entries from today on
+// with their current place in each day's list.
+func (u *WaitingListUsecase) GetCustomerStandby(ctx context.Context, customerID types.MSSQLUUID) ([]*entities.StandbyEntry, error) {
+	entries, err := u.standbyRepo.GetWaitingByCustomer(ctx, customerID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	days := make(map[string][]*entities.StandbyEntry)
+	for _, entry := range entries {
+		date := entry.ServiceDate.Format("2006-01-02")
+		if _, ok := days[date]; !ok {
+			if days[date], err = u.standbyRepo.GetWaitingByDate(ctx, entry.ServiceDate); err != nil {
+				return nil, err
+			}
+		}
+		entry.Position = StandbyPosition(days[date], entry.ID)
+	}
+	return entries, nil
+}
+// StandbyPosition returns the 1-based place of id in a day's standby list
+// ordered oldest first, or 0 when it is not on the list.
+func StandbyPosition(entries []*entities.StandbyEntry, id types.MSSQLUUID) int {
+	for i, entry := range entries {
+		if entry.ID == id {
+			return i + 1
+		}
+	}
+	return 0
+}
+// promoteStandby turns standby entries for serviceDate into tickets, oldest
+// first, for as long as the day has room. It runs after a ticket leaves the
+// day, so callers ignore its error rather than undo their own change.
+func (u *WaitingListUsecase) promoteStandby(ctx context.Context, serviceDate time.Time) error {
+	if u.standbyRepo == nil || serviceDate.Format("2006-01-02") < time.Now().Format("2006-01-02") {
+		return nil
+	}
+	if err := u.closureUsecase.CheckOpen(ctx, serviceDate); err != nil {
+		return err
+	}
+	entries, err := u.standbyRepo.GetWaitingByDate(ctx, serviceDate)
+	if err != nil {
+		return err
+	}
+	limit, err := u.dailyLimit(ctx, serviceDate)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		waitingList := &entities.WaitingList{
+			VehicleID:       entry.VehicleID,
+			CustomerID:      entry.CustomerID,
+			ServiceDate:     entry.ServiceDate,
+			ServiceType:     entry.ServiceType,
+			ServiceTypeCode: entry.ServiceTypeCode,
+			EstimatedTime:   entry.EstimatedTime,
+			Notes:           entry.Notes,
+			Status:          entities.WaitingListStatusWaiting,
+		}
+		// The promotion is made by the system, not by whoever freed the place.
+		transition := &entities.WaitingListTransition{
+			ToStatus: entities.WaitingListStatusWaiting,
+			Reason:   "promoted from standby",
+		}
+		if err := u.standbyRepo.Promote(ctx, entry, waitingList, transition, limit); err != nil {
+			if errors.Is(err, repositories.ErrCapacityExceeded) || errors.Is(err, repositories.ErrStandbyNotWaiting) {
+				continue // a shorter job further down may still fit; a concurrent run took this entry
+			}
+			if errors.Is(err, repositories.ErrDailyLimitReached) {
+				return nil
+			}
+			return err
+		}
+		waitingList.Vehicle = entry.Vehicle
+		waitingList.Customer = entry.Customer
+		u.board.Publish(NewQueueBoardEntry(waitingList))
+		if u.notifier != nil {
+			_ = u.notifier.NotifyStandbyPromoted(ctx, waitingList)
+		}
+	}
+	return nil
+}
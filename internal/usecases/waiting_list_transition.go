@@ -0,0 +1,50 @@
+package usecases
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+// ErrInvalidTransition is wrapped by errors for status changes the ticket
+// state machine does not allow.
+var ErrInvalidTransition = errors.New("invalid status transition")
+type transitionReasonKey struct{}
+// WithTransitionReason attaches a reason to status changes made with ctx.
+func WithTransitionReason(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, transitionReasonKey{}, reason)
+}
+func transitionReason(ctx context.Context, fallback string) string {
+	if reason, ok := ctx.Value(transitionReasonKey{}).(string); ok && reason != "" {
+		return reason
+	}
+	return fallback
+}
+// actorFromContext returns the authenticated user set by the auth middleware,
+// or nil for background jobs.
+func actorFromContext(ctx context.Context) *types.MSSQLUUID {
+	if id, ok := ctx.Value("id").(types.MSSQLUUID); ok {
+		return &id
+	}
+	return nil
+}
+func moveTo(waitingList *entities.WaitingList, status entities.WaitingListStatus) error {
+	if !waitingList.Status.CanTransitionTo(status) {
+		return fmt.Errorf("%w: ticket is %s and cannot become %s", ErrInvalidTransition, waitingList.Status, status)
+	}
+	waitingList.Status = status
+	return nil
+}
+// newTransition describes a status change for the ticket history. The
+// repository saves it together with the ticket.
+func newTransition(ctx context.Context, from, to entities.WaitingListStatus, reason string) *entities.WaitingListTransition {
+	return &entities.WaitingListTransition{
+		FromStatus: from,
+		ToStatus:   to,
+		ActorID:    actorFromContext(ctx),
+		Reason:     transitionReason(ctx, reason),
+	}
+}
+func (u *WaitingListUsecase) GetTransitionHistory(ctx context.Context, id types.MSSQLUUID) ([]*entities.WaitingListTransition, error) {
+	return u.transitionRepo.GetByWaitingListID(ctx, id)
+}
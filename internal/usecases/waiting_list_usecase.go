@@ -6,28 +6,93 @@ import (
 	"time"
 	"github.com/kuahbanyak/go-crud/internal/domain/entities"
 	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/domain/services"
+	"github.com/kuahbanyak/go-crud/internal/shared/constants"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
 	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/shared/utils"
 )
 type WaitingListUsecase struct {
 	waitingListRepo repositories.WaitingListRepository
 	vehicleRepo     repositories.VehicleRepository
 	userRepo        repositories.UserRepository
+	serviceBayRepo  repositories.ServiceBayRepository
+	transitionRepo  repositories.WaitingListTransitionRepository
+	standbyRepo     repositories.StandbyRepository
 	settingUsecase  *SettingUsecase
+	closureUsecase  *ShopClosureUsecase
+	serviceTypes    *ServiceTypeUsecase
+	maintenance     *MaintenanceItemUsecase
+	notifier        services.NotificationService
+	checkInTokens   *utils.CheckInTokenService
+	board           *QueueBoard
 }
 func NewWaitingListUsecase(
 	waitingListRepo repositories.WaitingListRepository,
 	vehicleRepo repositories.VehicleRepository,
 	userRepo repositories.UserRepository,
+	serviceBayRepo repositories.ServiceBayRepository,
+	transitionRepo repositories.WaitingListTransitionRepository,
+	standbyRepo repositories.StandbyRepository,
 	settingUsecase *SettingUsecase,
+	closureUsecase *ShopClosureUsecase,
+	serviceTypes *ServiceTypeUsecase,
+	maintenance *MaintenanceItemUsecase,
+	notifier services.NotificationService,
+	checkInTokens *utils.CheckInTokenService,
 ) *WaitingListUsecase {
 	return &WaitingListUsecase{
 		waitingListRepo: waitingListRepo,
 		vehicleRepo:     vehicleRepo,
 		userRepo:        userRepo,
+		serviceBayRepo:  serviceBayRepo,
+		transitionRepo:  transitionRepo,
+		standbyRepo:     standbyRepo,
 		settingUsecase:  settingUsecase,
+		closureUsecase:  closureUsecase,
+		serviceTypes:    serviceTypes,
+		maintenance:     maintenance,
+		notifier:        notifier,
+		checkInTokens:   checkInTokens,
+		board:           NewQueueBoard(),
 	}
 }
 func (u *WaitingListUsecase) TakeQueueNumber(ctx context.Context, waitingList *entities.WaitingList) error {
+	if err := u.verifyVehicleAndCustomer(ctx, waitingList); err != nil {
+		return err
+	}
+	if err := u.applyServiceType(ctx, waitingList); err != nil {
+		return err
+	}
+	if err := u.checkServiceDate(ctx, waitingList.ServiceDate); err != nil {
+		return err
+	}
+	waitingList.Status = entities.WaitingListStatusWaiting
+	limit, err := u.dailyLimit(ctx, waitingList.ServiceDate)
+	if err != nil {
+		return err
+	}
+	transition := newTransition(ctx, "", entities.WaitingListStatusWaiting, "walk-in ticket taken")
+	if err := u.waitingListRepo.SaveWithQueueNumber(ctx, waitingList, limit, transition); err != nil {
+		return dailyLimitError(err, limit)
+	}
+	return nil
+}
+// applyServiceType replaces the free-text service type with the catalog
+// entry it names and defaults the estimated time from it.
+func (u *WaitingListUsecase) applyServiceType(ctx context.Context, waitingList *entities.WaitingList) error {
+	serviceType, err := u.serviceTypes.ResolveServiceType(ctx, waitingList.ServiceType)
+	if err != nil {
+		return err
+	}
+	waitingList.ServiceType = serviceType.Name
+	waitingList.ServiceTypeCode = serviceType.Code
+	if waitingList.EstimatedTime <= 0 {
+		waitingList.EstimatedTime = serviceType.DefaultDuration
+	}
+	return nil
+}
+func (u *WaitingListUsecase) verifyVehicleAndCustomer(ctx context.Context, waitingList *entities.WaitingList) error {
 	if u.vehicleRepo != nil {
 		_, err := u.vehicleRepo.GetByID(ctx, waitingList.VehicleID)
 		if err != nil {
@@ -38,46 +103,173 @@ func (u *WaitingListUsecase) TakeQueueNumber(ctx context.Context, waitingList *e
 	if err != nil {
 		return errors.New("customer not found")
 	}
-	available, _, err := u.CheckTicketAvailability(ctx, waitingList.ServiceDate)
+	return nil
+}
+// checkServiceDate rejects dates in the past, beyond the advance booking
+// window, or on which the shop is closed.
+func (u *WaitingListUsecase) checkServiceDate(ctx context.Context, serviceDate time.Time) error {
+	date := serviceDate.Format("2006-01-02")
+	now := time.Now()
+	if date < now.Format("2006-01-02") {
+		return errors.New("service date cannot be in the past")
+	}
+	futureDays := u.settingUsecase.GetFutureBookingDays(ctx)
+	if date > now.AddDate(0, 0, futureDays).Format("2006-01-02") {
+		return fmt.Errorf("service date must be within %d days from today", futureDays)
+	}
+	return u.closureUsecase.CheckOpen(ctx, serviceDate)
+}
+func (u *WaitingListUsecase) CheckShopOpen(ctx context.Context, serviceDate time.Time) error {
+	return u.closureUsecase.CheckOpen(ctx, serviceDate)
+}
+func (u *WaitingListUsecase) CheckTicketAvailability(ctx context.Context, serviceDate time.Time) (bool, int, error) {
+	availability, err := u.GetDayAvailability(ctx, serviceDate)
 	if err != nil {
-		return fmt.Errorf("failed to check ticket availability: %w", err)
+		return false, 0, err
 	}
-	if !available {
-		maxTickets := u.settingUsecase.GetMaxTicketsPerDay(ctx)
-		return fmt.Errorf("daily ticket limit reached: maximum %d tickets per day (0 remaining)", maxTickets)
+	return availability.Available, availability.RemainingTickets, nil
+}
+func (u *WaitingListUsecase) GetAvailableSlots(ctx context.Context, from time.Time, days, durationMinutes int) ([]dto.DaySlotsResponse, error) {
+	interval := u.settingUsecase.GetSlotIntervalMinutes(ctx)
+	if durationMinutes <= 0 {
+		durationMinutes = interval
 	}
-	queueNumber, err := u.waitingListRepo.GetNextQueueNumber(ctx, waitingList.ServiceDate)
+	hours := u.settingUsecase.GetBusinessHours(ctx)
+	now := time.Now()
+	result := make([]dto.DaySlotsResponse, 0, days)
+	for i := 0; i < days; i++ {
+		date := from.AddDate(0, 0, i)
+		day := dto.DaySlotsResponse{
+			Date:        date.Format("2006-01-02"),
+			SlotMinutes: durationMinutes,
+			Slots:       []dto.TimeSlotResponse{},
+		}
+		if err := u.closureUsecase.CheckOpen(ctx, date); err != nil {
+			if !errors.Is(err, ErrShopClosed) {
+				return nil, err
+			}
+			day.ClosedReason = err.Error()
+		} else {
+			day.IsWorkingDay = true
+			closed, err := u.closureUsecase.ClosedWindows(ctx, date)
+			if err != nil {
+				return nil, err
+			}
+			entries, err := u.waitingListRepo.GetByServiceDate(ctx, date)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get entries for date: %w", err)
+			}
+			availability, err := u.availability(ctx, date, entries)
+			if err != nil {
+				return nil, err
+			}
+			day.RemainingTickets = availability.RemainingTickets
+			day.RemainingMinutes = availability.RemainingMinutes
+			if availability.Available {
+				length := time.Duration(durationMinutes) * time.Minute
+				for _, start := range FreeSlots(date, hours, interval, durationMinutes, entries, now) {
+					if _, overlaps := overlapsWindow(start, start.Add(length), closed); overlaps {
+						continue
+					}
+					day.Slots = append(day.Slots, dto.TimeSlotResponse{
+						StartTime: start.Format("15:04"),
+						EndTime:   start.Add(length).Format("15:04"),
+					})
+				}
+			}
+		}
+		result = append(result, day)
+	}
+	return result, nil
+}
+func (u *WaitingListUsecase) BookSlot(ctx context.Context, waitingList *entities.WaitingList) error {
+	if waitingList.AppointmentAt == nil {
+		return errors.New("appointment time is required")
+	}
+	if err := u.verifyVehicleAndCustomer(ctx, waitingList); err != nil {
+		return err
+	}
+	if err := u.applyServiceType(ctx, waitingList); err != nil {
+		return err
+	}
+	if err := u.checkServiceDate(ctx, waitingList.ServiceDate); err != nil {
+		return err
+	}
+	hours := u.settingUsecase.GetBusinessHours(ctx)
+	interval := u.settingUsecase.GetSlotIntervalMinutes(ctx)
+	minutes := waitingList.EstimatedTime
+	if minutes <= 0 {
+		minutes = interval
+	}
+	start := *waitingList.AppointmentAt
+	openAt := hours.OpenAt(waitingList.ServiceDate)
+	closeAt := hours.CloseAt(waitingList.ServiceDate)
+	if start.Before(openAt) || start.Add(time.Duration(minutes)*time.Minute).After(closeAt) {
+		return fmt.Errorf("appointment must fit within business hours (%s-%s)", openAt.Format("15:04"), closeAt.Format("15:04"))
+	}
+	if interval > 0 && start.Sub(openAt)%(time.Duration(interval)*time.Minute) != 0 {
+		return fmt.Errorf("appointment must start on a %d-minute slot boundary", interval)
+	}
+	if start.Before(time.Now()) {
+		return errors.New("cannot book a slot in the past")
+	}
+	closed, err := u.closureUsecase.ClosedWindows(ctx, waitingList.ServiceDate)
 	if err != nil {
-		return errors.New("failed to generate queue number")
+		return err
 	}
-	maxTickets := u.settingUsecase.GetMaxTicketsPerDay(ctx)
-	if queueNumber > maxTickets {
-		return fmt.Errorf("cannot create ticket: queue number %d exceeds daily limit of %d tickets", queueNumber, maxTickets)
+	if window, overlaps := overlapsWindow(start, start.Add(time.Duration(minutes)*time.Minute), closed); overlaps {
+		return fmt.Errorf("%w from %s to %s (%s)", ErrShopClosed, window.Start.Format("15:04"), window.End.Format("15:04"), window.Name)
 	}
-	waitingList.QueueNumber = queueNumber
 	waitingList.Status = entities.WaitingListStatusWaiting
-	return u.waitingListRepo.Create(ctx, waitingList)
-}
-func (u *WaitingListUsecase) CheckTicketAvailability(ctx context.Context, serviceDate time.Time) (bool, int, error) {
-	entries, err := u.waitingListRepo.GetByServiceDate(ctx, serviceDate)
+	limit, err := u.dailyLimit(ctx, waitingList.ServiceDate)
 	if err != nil {
-		return false, 0, fmt.Errorf("failed to get entries for date: %w", err)
+		return err
 	}
-	activeCount := 0
-	for _, entry := range entries {
-		if entry.Status == entities.WaitingListStatusWaiting ||
-			entry.Status == entities.WaitingListStatusCalled ||
-			entry.Status == entities.WaitingListStatusInService {
-			activeCount++
+	transition := newTransition(ctx, "", entities.WaitingListStatusWaiting,
+		fmt.Sprintf("appointment booked for %s", start.Format("2006-01-02 15:04")))
+	if err := u.waitingListRepo.CreateAppointment(ctx, waitingList, interval, limit, transition); err != nil {
+		return dailyLimitError(err, limit)
+	}
+	return nil
+}
+// FreeSlots lists the start times on date at which a booking of
+// durationMinutes fits inside business hours without overlapping an
+// existing appointment. Walk-in tickets never block a slot.
+func FreeSlots(date time.Time, hours BusinessHours, interval, durationMinutes int, entries []*entities.WaitingList, now time.Time) []time.Time {
+	if interval <= 0 {
+		interval = 30
+	}
+	step := time.Duration(interval) * time.Minute
+	length := time.Duration(durationMinutes) * time.Minute
+	closeAt := hours.CloseAt(date)
+	var slots []time.Time
+	for start := hours.OpenAt(date); !start.Add(length).After(closeAt); start = start.Add(step) {
+		if start.Before(now) {
+			continue
+		}
+		if !overlapsAppointment(start, start.Add(length), entries, interval) {
+			slots = append(slots, start)
 		}
 	}
-	maxTickets := u.settingUsecase.GetMaxTicketsPerDay(ctx)
-	available := activeCount < maxTickets
-	remaining := maxTickets - activeCount
-	if remaining < 0 {
-		remaining = 0
+	return slots
+}
+func overlapsAppointment(start, end time.Time, entries []*entities.WaitingList, defaultMinutes int) bool {
+	for _, entry := range entries {
+		if entry.AppointmentAt == nil ||
+			entry.Status == entities.WaitingListStatusCanceled ||
+			entry.Status == entities.WaitingListStatusNoShow {
+			continue
+		}
+		minutes := entry.EstimatedTime
+		if minutes <= 0 {
+			minutes = defaultMinutes
+		}
+		bookedEnd := entry.AppointmentAt.Add(time.Duration(minutes) * time.Minute)
+		if entry.AppointmentAt.Before(end) && bookedEnd.After(start) {
+			return true
+		}
 	}
-	return available, remaining, nil
+	return false
 }
 func (u *WaitingListUsecase) GetWaitingList(ctx context.Context, id types.MSSQLUUID) (*entities.WaitingList, error) {
 	return u.waitingListRepo.GetByID(ctx, id)
@@ -95,141 +287,406 @@ func (u *WaitingListUsecase) GetTodayQueue(ctx context.Context) ([]*entities.Wai
 func (u *WaitingListUsecase) GetQueueByDate(ctx context.Context, serviceDate time.Time) ([]*entities.WaitingList, error) {
 	return u.waitingListRepo.GetByServiceDate(ctx, serviceDate)
 }
-func (u *WaitingListUsecase) CheckServiceProgress(ctx context.Context, id types.MSSQLUUID) (*ServiceProgressResponse, error) {
-	waitingList, err := u.waitingListRepo.GetByID(ctx, id)
+// CheckServiceProgress estimates when ticket will be called and finished.
+// It also returns the day's tickets the estimate was computed from.
+func (u *WaitingListUsecase) CheckServiceProgress(ctx context.Context, ticket *entities.WaitingList) (QueueEstimate, []*entities.WaitingList, error) {
+	dayTickets, err := u.waitingListRepo.GetByServiceDate(ctx, ticket.ServiceDate)
 	if err != nil {
-		return nil, errors.New("ticket not found")
-	}
-	allTickets, err := u.waitingListRepo.GetByServiceDate(ctx, waitingList.ServiceDate)
-	if err != nil {
-		return nil, errors.New("failed to retrieve queue information")
-	}
-	var currentlyServing int
-	var _ int
-	var waitingAhead int
-	for _, ticket := range allTickets {
-		if ticket.Status == entities.WaitingListStatusInService {
-			currentlyServing = ticket.QueueNumber
-		}
-		if ticket.QueueNumber < waitingList.QueueNumber &&
-			(ticket.Status == entities.WaitingListStatusWaiting || ticket.Status == entities.WaitingListStatusCalled) {
-			waitingAhead++
-		}
-	}
-	estimatedWaitMin := waitingAhead * 30 // Assume 30 minutes per service on average
-	response := &ServiceProgressResponse{
-		ID:                   waitingList.ID,
-		QueueNumber:          waitingList.QueueNumber,
-		Status:               string(waitingList.Status),
-		ServiceDate:          waitingList.ServiceDate,
-		ServiceType:          waitingList.ServiceType,
-		CurrentlyServing:     currentlyServing,
-		QueuePosition:        waitingList.QueueNumber,
-		WaitingAhead:         waitingAhead,
-		EstimatedWaitMinutes: estimatedWaitMin,
-		CalledAt:             waitingList.CalledAt,
-		ServiceStartAt:       waitingList.ServiceStartAt,
-		ServiceEndAt:         waitingList.ServiceEndAt,
-		Message:              u.generateProgressMessage(waitingList.Status, waitingAhead, currentlyServing),
-	}
-	return response, nil
-}
-func (u *WaitingListUsecase) generateProgressMessage(status entities.WaitingListStatus, waitingAhead, currentlyServing int) string {
-	switch status {
-	case entities.WaitingListStatusWaiting:
-		if waitingAhead == 0 {
-			return "You're next! Please be ready."
-		}
-		return fmt.Sprintf("%d customer(s) ahead of you. Currently serving #%d", waitingAhead, currentlyServing)
-	case entities.WaitingListStatusCalled:
-		return "You've been called! Please proceed to the service area."
-	case entities.WaitingListStatusInService:
-		return "Your service is currently in progress."
-	case entities.WaitingListStatusCompleted:
-		return "Your service has been completed. Thank you!"
-	case entities.WaitingListStatusCanceled:
-		return "This ticket has been canceled."
-	case entities.WaitingListStatusNoShow:
-		return "Marked as no-show. Please contact us to reschedule."
-	default:
-		return "Status unknown"
-	}
-}
-type ServiceProgressResponse struct {
-	ID                   types.MSSQLUUID `json:"id"`
-	QueueNumber          int             `json:"queue_number"`
-	Status               string          `json:"status"`
-	ServiceDate          time.Time       `json:"service_date"`
-	ServiceType          string          `json:"service_type"`
-	CurrentlyServing     int             `json:"currently_serving"`
-	QueuePosition        int             `json:"queue_position"`
-	WaitingAhead         int             `json:"waiting_ahead"`
-	EstimatedWaitMinutes int             `json:"estimated_wait_minutes"`
-	CalledAt             *time.Time      `json:"called_at,omitempty"`
-	ServiceStartAt       *time.Time      `json:"service_start_at,omitempty"`
-	ServiceEndAt         *time.Time      `json:"service_end_at,omitempty"`
-	Message              string          `json:"message"`
+		return QueueEstimate{}, nil, err
+	}
+	return u.EstimateServiceTimes(ctx, ticket, dayTickets), dayTickets, nil
+}
+// EstimateServiceTimes predicts when a ticket will be called and finished from
+// the average duration of recently completed services of the same type.
+func (u *WaitingListUsecase) EstimateServiceTimes(ctx context.Context, ticket *entities.WaitingList, dayTickets []*entities.WaitingList) QueueEstimate {
+	durations := u.serviceDurations(ctx)
+	lanes := 1
+	if bays, err := u.serviceBayRepo.GetActive(ctx); err == nil && len(bays) > 0 {
+		lanes = len(bays)
+	}
+	return EstimateQueue(ticket, dayTickets, durations, lanes, time.Now())
+}
+func (u *WaitingListUsecase) serviceDurations(ctx context.Context) ServiceDurations {
+	durations := ServiceDurations{DefaultMinutes: u.settingUsecase.GetDefaultServiceMinutes(ctx)}
+	since := time.Now().AddDate(0, 0, -u.settingUsecase.GetETAHistoryDays(ctx))
+	if averages, err := u.waitingListRepo.GetAverageServiceMinutes(ctx, since); err == nil {
+		durations.Averages = averages
+	}
+	return durations
+}
+// GetBayOverview reports what each active bay is serving among dayTickets.
+// It returns nil when the shop has no bays configured.
+func (u *WaitingListUsecase) GetBayOverview(ctx context.Context, dayTickets []*entities.WaitingList) (*dto.ServiceBayOverview, error) {
+	bays, err := u.serviceBayRepo.GetActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service bays: %w", err)
+	}
+	if len(bays) == 0 {
+		return nil, nil
+	}
+	overview := BuildBayOverview(bays, dayTickets)
+	return &overview, nil
 }
 func (u *WaitingListUsecase) CallCustomer(ctx context.Context, id types.MSSQLUUID) error {
 	waitingList, err := u.waitingListRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	if waitingList.Status != entities.WaitingListStatusWaiting {
-		return errors.New("can only call customers in waiting status")
+	from := waitingList.Status
+	if err := moveTo(waitingList, entities.WaitingListStatusCalled); err != nil {
+		return err
 	}
 	now := time.Now()
-	waitingList.Status = entities.WaitingListStatusCalled
 	waitingList.CalledAt = &now
-	return u.waitingListRepo.Update(ctx, waitingList)
+	if err := u.updateAndBroadcast(ctx, waitingList, from, ""); err != nil {
+		return err
+	}
+	if u.notifier != nil {
+		_ = u.notifier.NotifyCustomerCalled(ctx, waitingList)
+	}
+	return nil
+}
+// CallNextCustomer calls the next waiting ticket on serviceDate and returns
+// it, or nil when nobody is waiting.
+func (u *WaitingListUsecase) CallNextCustomer(ctx context.Context, serviceDate time.Time) (*entities.WaitingList, error) {
+	waiting, err := u.waitingListRepo.GetByStatus(ctx, entities.WaitingListStatusWaiting, serviceDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waiting tickets: %w", err)
+	}
+	next := NextWaitingTicket(waiting, time.Now())
+	if next == nil {
+		return nil, nil
+	}
+	if err := u.CallCustomer(ctx, next.ID); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+// NextWaitingTicket picks who to call next: appointments that are due, in
+// appointment order, then walk-ins by queue number. Appointments later in the
+// day wait for their slot.
+func NextWaitingTicket(tickets []*entities.WaitingList, now time.Time) *entities.WaitingList {
+	var next *entities.WaitingList
+	for _, t := range tickets {
+		if t.Status != entities.WaitingListStatusWaiting {
+			continue
+		}
+		if t.AppointmentAt != nil && t.AppointmentAt.After(now) {
+			continue
+		}
+		switch {
+		case next == nil:
+			next = t
+		case t.AppointmentAt != nil && next.AppointmentAt == nil:
+			next = t
+		case t.AppointmentAt != nil && next.AppointmentAt != nil && t.AppointmentAt.Before(*next.AppointmentAt):
+			next = t
+		case t.AppointmentAt == nil && next.AppointmentAt == nil && t.QueueNumber < next.QueueNumber:
+			next = t
+		}
+	}
+	return next
+}
+// OverdueCalledTickets returns the called tickets whose customer has not
+// shown up within grace of being called. Customers who checked in are at the
+// shop waiting for a bay and are never overdue.
+func OverdueCalledTickets(tickets []*entities.WaitingList, grace time.Duration, now time.Time) []*entities.WaitingList {
+	var overdue []*entities.WaitingList
+	for _, t := range tickets {
+		if t.Status == entities.WaitingListStatusCalled && t.CalledAt != nil && t.ArrivedAt == nil && !t.CalledAt.Add(grace).After(now) {
+			overdue = append(overdue, t)
+		}
+	}
+	return overdue
 }
-func (u *WaitingListUsecase) StartService(ctx context.Context, id types.MSSQLUUID) error {
+// StartService puts a called ticket into service. With bays configured the
+// ticket is placed in bayID, or the first free bay when bayID is nil.
+func (u *WaitingListUsecase) StartService(ctx context.Context, id types.MSSQLUUID, bayID *types.MSSQLUUID) error {
 	waitingList, err := u.waitingListRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	if waitingList.Status != entities.WaitingListStatusCalled {
-		return errors.New("customer must be called before starting service")
+	from := waitingList.Status
+	if !from.CanTransitionTo(entities.WaitingListStatusInService) {
+		return fmt.Errorf("%w: customer must be called before starting service", ErrInvalidTransition)
+	}
+	bays, err := u.serviceBayRepo.GetActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get service bays: %w", err)
+	}
+	if waitingList.MechanicID == nil && u.settingUsecase.IsAutoAssignMechanicEnabled(ctx) {
+		if mechanic, err := u.resolveMechanic(ctx, nil, waitingList.ServiceDate); err == nil {
+			waitingList.MechanicID = &mechanic.ID
+			waitingList.Mechanic = mechanic
+		}
 	}
 	now := time.Now()
 	waitingList.Status = entities.WaitingListStatusInService
 	waitingList.ServiceStartAt = &now
-	return u.waitingListRepo.Update(ctx, waitingList)
+	if len(bays) == 0 {
+		if bayID != nil {
+			return errors.New("service bay not found or inactive")
+		}
+		return u.updateAndBroadcast(ctx, waitingList, from, "")
+	}
+	bay, err := u.pickBay(ctx, bays, bayID, waitingList.ServiceDate)
+	if err != nil {
+		return err
+	}
+	waitingList.ServiceBayID = &bay.ID
+	waitingList.ServiceBay = nil
+	transition := newTransition(ctx, from, waitingList.Status, fmt.Sprintf("started in %s", bay.Name))
+	if err := u.waitingListRepo.StartServiceInBay(ctx, waitingList, transition); err != nil {
+		return err
+	}
+	waitingList.ServiceBay = bay
+	u.board.Publish(NewQueueBoardEntry(waitingList))
+	return nil
 }
-func (u *WaitingListUsecase) CompleteService(ctx context.Context, id types.MSSQLUUID) error {
+func (u *WaitingListUsecase) pickBay(ctx context.Context, bays []*entities.ServiceBay, bayID *types.MSSQLUUID, serviceDate time.Time) (*entities.ServiceBay, error) {
+	if bayID != nil {
+		for _, bay := range bays {
+			if bay.ID == *bayID {
+				return bay, nil
+			}
+		}
+		return nil, errors.New("service bay not found or inactive")
+	}
+	tickets, err := u.waitingListRepo.GetByServiceDate(ctx, serviceDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve queue information: %w", err)
+	}
+	overview := BuildBayOverview(bays, tickets)
+	for i, status := range overview.Bays {
+		if !status.Busy {
+			return bays[i], nil
+		}
+	}
+	return nil, repositories.ErrBayOccupied
+}
+// AssignMechanic puts a mechanic on an open ticket. When mechanicID is nil the
+// mechanic with the least estimated open work that day is chosen.
+func (u *WaitingListUsecase) AssignMechanic(ctx context.Context, id types.MSSQLUUID, mechanicID *types.MSSQLUUID) (*entities.WaitingList, error) {
 	waitingList, err := u.waitingListRepo.GetByID(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if !isOpenTicket(waitingList) {
+		return nil, errors.New("can only assign a mechanic to an open ticket")
+	}
+	mechanic, err := u.resolveMechanic(ctx, mechanicID, waitingList.ServiceDate)
+	if err != nil {
+		return nil, err
+	}
+	waitingList.MechanicID = &mechanic.ID
+	waitingList.Mechanic = mechanic
+	if err := u.waitingListRepo.Update(ctx, waitingList); err != nil {
+		return nil, err
+	}
+	return waitingList, nil
+}
+func (u *WaitingListUsecase) resolveMechanic(ctx context.Context, mechanicID *types.MSSQLUUID, serviceDate time.Time) (*entities.User, error) {
+	mechanics, err := u.userRepo.GetByRole(ctx, constants.RoleMechanic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mechanics: %w", err)
+	}
+	if mechanicID != nil {
+		for _, mechanic := range mechanics {
+			if mechanic.ID == *mechanicID {
+				return mechanic, nil
+			}
+		}
+		return nil, errors.New("mechanic not found")
+	}
+	if len(mechanics) == 0 {
+		return nil, errors.New("no mechanics available")
+	}
+	tickets, err := u.waitingListRepo.GetByServiceDate(ctx, serviceDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve queue information: %w", err)
+	}
+	return PickLeastLoadedMechanic(mechanics, tickets, u.serviceDurations(ctx)), nil
+}
+func (u *WaitingListUsecase) GetMechanicJobsToday(ctx context.Context, mechanicID types.MSSQLUUID) ([]*entities.WaitingList, error) {
+	return u.waitingListRepo.GetByMechanic(ctx, mechanicID, time.Now())
+}
+// PickLeastLoadedMechanic returns the mechanic with the fewest estimated
+// minutes of open work among tickets, breaking ties by open ticket count.
+func PickLeastLoadedMechanic(mechanics []*entities.User, tickets []*entities.WaitingList, durations ServiceDurations) *entities.User {
+	minutes := make(map[types.MSSQLUUID]time.Duration)
+	counts := make(map[types.MSSQLUUID]int)
+	for _, t := range tickets {
+		if t.MechanicID == nil || !isOpenTicket(t) {
+			continue
+		}
+		minutes[*t.MechanicID] += durations.For(t)
+		counts[*t.MechanicID]++
+	}
+	var best *entities.User
+	for _, mechanic := range mechanics {
+		if best == nil ||
+			minutes[mechanic.ID] < minutes[best.ID] ||
+			(minutes[mechanic.ID] == minutes[best.ID] && counts[mechanic.ID] < counts[best.ID]) {
+			best = mechanic
+		}
+	}
+	return best
+}
+func isOpenTicket(t *entities.WaitingList) bool {
+	return t.Status == entities.WaitingListStatusWaiting ||
+		t.Status == entities.WaitingListStatusCalled ||
+		t.Status == entities.WaitingListStatusInService
+}
+// CompleteService closes the ticket. The returned warning is set when the
+// final cost is more than the allowed threshold above the estimate the
+// customer accepted; the service is completed either way.
+func (u *WaitingListUsecase) CompleteService(ctx context.Context, id types.MSSQLUUID) (*dto.CostOverrunWarning, error) {
+	waitingList, err := u.waitingListRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	if waitingList.Status != entities.WaitingListStatusInService {
-		return errors.New("service must be in progress to complete")
+	from := waitingList.Status
+	if !from.CanTransitionTo(entities.WaitingListStatusCompleted) {
+		return nil, fmt.Errorf("%w: service must be in progress to complete", ErrInvalidTransition)
 	}
 	now := time.Now()
 	waitingList.Status = entities.WaitingListStatusCompleted
 	waitingList.ServiceEndAt = &now
-	return u.waitingListRepo.Update(ctx, waitingList)
+	if err := u.updateAndBroadcast(ctx, waitingList, from, ""); err != nil {
+		return nil, err
+	}
+	// A failed check must not undo the completion.
+	warning, _ := u.maintenance.CheckEstimateOverrun(ctx, id)
+	return warning, nil
 }
 func (u *WaitingListUsecase) CancelQueue(ctx context.Context, id types.MSSQLUUID) error {
 	waitingList, err := u.waitingListRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	if waitingList.Status == entities.WaitingListStatusCompleted {
-		return errors.New("cannot cancel completed service")
+	from := waitingList.Status
+	if err := moveTo(waitingList, entities.WaitingListStatusCanceled); err != nil {
+		return err
+	}
+	if err := u.updateAndBroadcast(ctx, waitingList, from, ""); err != nil {
+		return err
 	}
-	waitingList.Status = entities.WaitingListStatusCanceled
-	return u.waitingListRepo.Update(ctx, waitingList)
+	// A failed promotion must not undo the cancellation.
+	_ = u.promoteStandby(ctx, waitingList.ServiceDate)
+	return nil
+}
+// RescheduleQueue moves a waiting ticket to newDate under a new queue number.
+// The ticket keeps its ID, so its maintenance items and history stay attached.
+func (u *WaitingListUsecase) RescheduleQueue(ctx context.Context, id types.MSSQLUUID, newDate time.Time) (*entities.WaitingList, error) {
+	waitingList, err := u.waitingListRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.rescheduleTicket(ctx, waitingList, newDate); err != nil {
+		return nil, err
+	}
+	return waitingList, nil
+}
+// BulkReschedule moves every waiting ticket on fromDate to toDate in queue
+// order. Tickets that cannot be moved, e.g. once toDate is full, are reported
+// as failed and left where they are.
+func (u *WaitingListUsecase) BulkReschedule(ctx context.Context, fromDate, toDate time.Time) (*dto.BulkRescheduleResponse, error) {
+	tickets, err := u.waitingListRepo.GetByStatus(ctx, entities.WaitingListStatusWaiting, fromDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waiting tickets: %w", err)
+	}
+	result := &dto.BulkRescheduleResponse{
+		FromDate: fromDate.Format("2006-01-02"),
+		ToDate:   toDate.Format("2006-01-02"),
+		Moved:    []dto.RescheduledTicket{},
+		Failed:   []dto.RescheduledTicket{},
+	}
+	for _, ticket := range tickets {
+		moved := dto.RescheduledTicket{ID: ticket.ID, PreviousQueueNumber: ticket.QueueNumber}
+		if err := u.rescheduleTicket(ctx, ticket, toDate); err != nil {
+			moved.Error = err.Error()
+			result.Failed = append(result.Failed, moved)
+			continue
+		}
+		moved.QueueNumber = ticket.QueueNumber
+		result.Moved = append(result.Moved, moved)
+	}
+	return result, nil
+}
+func (u *WaitingListUsecase) rescheduleTicket(ctx context.Context, waitingList *entities.WaitingList, newDate time.Time) error {
+	if waitingList.Status != entities.WaitingListStatusWaiting {
+		return fmt.Errorf("%w: only waiting tickets can be rescheduled", ErrInvalidTransition)
+	}
+	if waitingList.ServiceDate.Format("2006-01-02") == newDate.Format("2006-01-02") {
+		return errors.New("ticket is already scheduled on this date")
+	}
+	if err := u.checkServiceDate(ctx, newDate); err != nil {
+		return err
+	}
+	original := *waitingList
+	previousDate, previousQueue := waitingList.ServiceDate, waitingList.QueueNumber
+	waitingList.RescheduledFromDate = &previousDate
+	waitingList.RescheduledFromQueue = &previousQueue
+	waitingList.RescheduleCount++
+	waitingList.ServiceDate = newDate
+	// Slot bookings and mechanic assignments are specific to the old day.
+	waitingList.AppointmentAt = nil
+	waitingList.MechanicID = nil
+	waitingList.Mechanic = nil
+	limit, err := u.dailyLimit(ctx, newDate)
+	if err != nil {
+		*waitingList = original
+		return err
+	}
+	transition := newTransition(ctx, original.Status, waitingList.Status,
+		fmt.Sprintf("rescheduled from %s #%d", previousDate.Format("2006-01-02"), previousQueue))
+	if err := u.waitingListRepo.SaveWithQueueNumber(ctx, waitingList, limit, transition); err != nil {
+		*waitingList = original
+		return dailyLimitError(err, limit)
+	}
+	u.board.Publish(NewQueueBoardEntry(waitingList))
+	left := NewQueueBoardEntry(waitingList)
+	left.QueueNumber = previousQueue
+	left.ServiceDate = previousDate.Format("2006-01-02")
+	left.Status = "rescheduled"
+	u.board.Publish(left)
+	if u.notifier != nil {
+		// A failed notification must not undo the reschedule.
+		_ = u.notifier.NotifyQueueRescheduled(ctx, waitingList, previousDate, previousQueue)
+	}
+	_ = u.promoteStandby(ctx, previousDate)
+	return nil
 }
 func (u *WaitingListUsecase) MarkNoShow(ctx context.Context, id types.MSSQLUUID) error {
 	waitingList, err := u.waitingListRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	if waitingList.Status != entities.WaitingListStatusCalled {
-		return errors.New("can only mark no-show for called customers")
+	from := waitingList.Status
+	if !from.CanTransitionTo(entities.WaitingListStatusNoShow) {
+		return fmt.Errorf("%w: can only mark no-show for called customers", ErrInvalidTransition)
 	}
 	waitingList.Status = entities.WaitingListStatusNoShow
-	return u.waitingListRepo.Update(ctx, waitingList)
+	if err := u.updateAndBroadcast(ctx, waitingList, from, ""); err != nil {
+		return err
+	}
+	if u.notifier != nil {
+		_ = u.notifier.NotifyNoShow(ctx, waitingList)
+	}
+	_ = u.promoteStandby(ctx, waitingList.ServiceDate)
+	return nil
+}
+// updateAndBroadcast saves a status change made from status from, records it
+// in the ticket history and pushes it to the live queue board.
+func (u *WaitingListUsecase) updateAndBroadcast(ctx context.Context, waitingList *entities.WaitingList, from entities.WaitingListStatus, reason string) error {
+	if err := u.waitingListRepo.UpdateWithTransition(ctx, waitingList, newTransition(ctx, from, waitingList.Status, reason)); err != nil {
+		return err
+	}
+	u.board.Publish(NewQueueBoardEntry(waitingList))
+	return nil
+}
+func (u *WaitingListUsecase) SubscribeQueueBoard() (<-chan dto.QueueBoardEntry, func()) {
+	return u.board.Subscribe()
 }
 func (u *WaitingListUsecase) GetWaitingCount(ctx context.Context, serviceDate time.Time) (int, error) {
 	waitingLists, err := u.waitingListRepo.GetByStatus(ctx, entities.WaitingListStatusWaiting, serviceDate)
@@ -246,7 +703,28 @@ func (u *WaitingListUsecase) UpdateWaitingList(ctx context.Context, id types.MSS
 	if existing == nil {
 		return errors.New("waiting list entry not found")
 	}
+	if updates.Status != "" && updates.Status != existing.Status && !existing.Status.CanTransitionTo(updates.Status) {
+		return fmt.Errorf("%w: ticket is %s and cannot become %s", ErrInvalidTransition, existing.Status, updates.Status)
+	}
+	if updates.ServiceType != "" {
+		if err := u.applyServiceType(ctx, updates); err != nil {
+			return err
+		}
+	}
 	updates.ID = id
-	return u.waitingListRepo.Update(ctx, updates)
+	var transition *entities.WaitingListTransition
+	statusChanged := updates.Status != "" && updates.Status != existing.Status
+	if statusChanged {
+		transition = newTransition(ctx, existing.Status, updates.Status, "")
+	}
+	if err := u.waitingListRepo.UpdateWithTransition(ctx, updates, transition); err != nil {
+		return err
+	}
+	if statusChanged {
+		if updates.Status == entities.WaitingListStatusCanceled || updates.Status == entities.WaitingListStatusNoShow {
+			_ = u.promoteStandby(ctx, existing.ServiceDate)
+		}
+	}
+	return nil
 }
 
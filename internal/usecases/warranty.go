@@ -0,0 +1,99 @@
+package usecases
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+)
+// WarrantyTerms is how long completed work is covered. A zero field means no
+// limit of that kind; when both are set the warranty ends at whichever comes
+// first.
+type WarrantyTerms struct {
+	Months int
+	Km     int
+}
+func (t WarrantyTerms) IsZero() bool {
+	return t.Months == 0 && t.Km == 0
+}
+// WarrantyPolicy is the shop's warranty terms, read from the warranty.*
+// settings.
+type WarrantyPolicy struct {
+	Default    WarrantyTerms
+	Categories map[string]WarrantyTerms // keyed by lower-case item category
+}
+func (u *SettingUsecase) GetWarrantyPolicy(ctx context.Context) WarrantyPolicy {
+	return WarrantyPolicy{
+		Default: WarrantyTerms{
+			Months: u.GetIntValue(ctx, "warranty.default_months", 3),
+			Km:     u.GetIntValue(ctx, "warranty.default_km", 5000),
+		},
+		Categories: ParseWarrantyTerms(u.GetStringValue(ctx, "warranty.category_terms", "")),
+	}
+}
+// ParseWarrantyTerms reads comma-separated category:months/km entries such
+// as "Brakes:6/10000,Tires:12/0" into a map keyed by lower-case category.
+// Malformed and negative entries are skipped.
+func ParseWarrantyTerms(value string) map[string]WarrantyTerms {
+	terms := make(map[string]WarrantyTerms)
+	for _, entry := range strings.Split(value, ",") {
+		category, raw, ok := strings.Cut(entry, ":")
+		category = strings.ToLower(strings.TrimSpace(category))
+		if !ok || category == "" {
+			continue
+		}
+		rawMonths, rawKm, ok := strings.Cut(raw, "/")
+		if !ok {
+			continue
+		}
+		months, err := strconv.Atoi(strings.TrimSpace(rawMonths))
+		if err != nil || months < 0 {
+			continue
+		}
+		km, err := strconv.Atoi(strings.TrimSpace(rawKm))
+		if err != nil || km < 0 {
+			continue
+		}
+		terms[category] = WarrantyTerms{Months: months, Km: km}
+	}
+	return terms
+}
+// For returns the terms for work in category.
+func (p WarrantyPolicy) For(category string) WarrantyTerms {
+	if terms, ok := p.Categories[strings.ToLower(strings.TrimSpace(category))]; ok {
+		return terms
+	}
+	return p.Default
+}
+// ApplyWarranty records terms on a completed item, counting from its
+// completion and from the odometer reading at the time. Repairs done under
+// warranty get no terms of their own; the original work stays the reference.
+func ApplyWarranty(item *entities.MaintenanceItem, terms WarrantyTerms, mileage int) {
+	item.WarrantyMonths, item.WarrantyKm = 0, 0
+	item.WarrantyUntil, item.WarrantyUntilKm = nil, 0
+	if item.WarrantyClaimID != nil || item.CompletedAt == nil || terms.IsZero() {
+		return
+	}
+	item.WarrantyMonths, item.WarrantyKm = terms.Months, terms.Km
+	if terms.Months > 0 {
+		until := item.CompletedAt.AddDate(0, terms.Months, 0)
+		item.WarrantyUntil = &until
+	}
+	if terms.Km > 0 {
+		item.WarrantyUntilKm = mileage + terms.Km
+	}
+}
+// WarrantyActive reports whether completed work is still under warranty for
+// a vehicle with mileage on the odometer at now.
+func WarrantyActive(item *entities.MaintenanceItem, mileage int, now time.Time) bool {
+	if item.Status != entities.MaintenanceItemStatusCompleted || item.WarrantyClaimID != nil {
+		return false
+	}
+	if item.WarrantyUntil == nil && item.WarrantyUntilKm == 0 {
+		return false
+	}
+	if item.WarrantyUntil != nil && now.After(*item.WarrantyUntil) {
+		return false
+	}
+	return item.WarrantyUntilKm == 0 || mileage <= item.WarrantyUntilKm
+}
@@ -0,0 +1,199 @@
+package usecases
+import (
+	"context"
+	"errors"
+	"time"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+)
+var (
+	ErrWarrantyNotFound    = errors.New("warranty not found")
+	ErrWarrantyForbidden   = errors.New("unauthorized: not your vehicle")
+	ErrWarrantyExpired     = errors.New("the work is no longer under warranty")
+	ErrWarrantyClaimExists = errors.New("a warranty claim for this work is already open on the ticket")
+)
+type WarrantyUsecase struct {
+	claimRepo              repositories.WarrantyClaimRepository
+	maintenanceItemRepo    repositories.MaintenanceItemRepository
+	waitingListRepo        repositories.WaitingListRepository
+	vehicleRepo            repositories.VehicleRepository
+	maintenanceItemUsecase *MaintenanceItemUsecase
+}
+func NewWarrantyUsecase(
+	claimRepo repositories.WarrantyClaimRepository,
+	maintenanceItemRepo repositories.MaintenanceItemRepository,
+	waitingListRepo repositories.WaitingListRepository,
+	vehicleRepo repositories.VehicleRepository,
+	maintenanceItemUsecase *MaintenanceItemUsecase,
+) *WarrantyUsecase {
+	return &WarrantyUsecase{
+		claimRepo:              claimRepo,
+		maintenanceItemRepo:    maintenanceItemRepo,
+		waitingListRepo:        waitingListRepo,
+		vehicleRepo:            vehicleRepo,
+		maintenanceItemUsecase: maintenanceItemUsecase,
+	}
+}
+// GetActiveWarranties lists the vehicle's completed work that is still
+// covered at its current mileage. Customers can only see their own vehicles.
+func (u *WarrantyUsecase) GetActiveWarranties(ctx context.Context, userID types.MSSQLUUID, role string, vehicleID types.MSSQLUUID) ([]dto.WarrantyResponse, error) {
+	vehicle, err := u.vehicleRepo.GetByID(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	if vehicle == nil {
+		return nil, errors.New("vehicle not found")
+	}
+	if !isStaff(role) && vehicle.OwnerID != userID {
+		return nil, ErrWarrantyForbidden
+	}
+	items, err := u.maintenanceItemRepo.GetWarrantiesByVehicle(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	active := make([]*entities.MaintenanceItem, 0, len(items))
+	ids := make([]types.MSSQLUUID, 0, len(items))
+	for _, item := range items {
+		if WarrantyActive(item, vehicle.Mileage, now) {
+			active = append(active, item)
+			ids = append(ids, item.ID)
+		}
+	}
+	claimCounts := make(map[types.MSSQLUUID]int)
+	if len(ids) > 0 {
+		claims, err := u.claimRepo.GetByOriginalItemIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, claim := range claims {
+			claimCounts[claim.OriginalItemID]++
+		}
+	}
+	responses := make([]dto.WarrantyResponse, len(active))
+	for i, item := range active {
+		responses[i] = dto.WarrantyResponse{
+			MaintenanceItemID: item.ID,
+			WaitingListID:     item.WaitingListID,
+			Category:          item.Category,
+			Name:              item.Name,
+			CompletedAt:       item.CompletedAt,
+			WarrantyMonths:    item.WarrantyMonths,
+			WarrantyKm:        item.WarrantyKm,
+			WarrantyUntil:     item.WarrantyUntil,
+			WarrantyUntilKm:   item.WarrantyUntilKm,
+			ClaimCount:        claimCounts[item.ID],
+		}
+	}
+	return responses, nil
+}
+// OpenClaim adds a repair for covered work to the vehicle's new ticket. The
+// repair is linked to the original item and is not billed to the customer.
+func (u *WarrantyUsecase) OpenClaim(ctx context.Context, staffID types.MSSQLUUID, req dto.OpenWarrantyClaimRequest) (*dto.WarrantyClaimResponse, error) {
+	original, err := u.maintenanceItemRepo.GetByID(ctx, req.OriginalItemID)
+	if err != nil || original.WaitingList == nil {
+		return nil, ErrWarrantyNotFound
+	}
+	if original.Status != entities.MaintenanceItemStatusCompleted || original.WarrantyClaimID != nil {
+		return nil, errors.New("only completed work can be claimed under warranty")
+	}
+	if original.WaitingListID == req.WaitingListID {
+		return nil, errors.New("the repair must be done on a new ticket")
+	}
+	waitingList, err := u.waitingListRepo.GetByID(ctx, req.WaitingListID)
+	if err != nil {
+		return nil, errors.New("waiting list not found")
+	}
+	if waitingList.VehicleID != original.WaitingList.VehicleID {
+		return nil, errors.New("the ticket is for a different vehicle")
+	}
+	switch waitingList.Status {
+	case entities.WaitingListStatusWaiting, entities.WaitingListStatusCalled, entities.WaitingListStatusInService:
+	default:
+		return nil, errors.New("warranty claims can only be opened on active tickets")
+	}
+	mileage := req.Mileage
+	if mileage == 0 {
+		mileage = waitingList.Vehicle.Mileage
+	}
+	if !WarrantyActive(original, mileage, time.Now()) {
+		return nil, ErrWarrantyExpired
+	}
+	claims, err := u.claimRepo.GetByOriginalItemIDs(ctx, []types.MSSQLUUID{original.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, claim := range claims {
+		if claim.WaitingListID == waitingList.ID {
+			return nil, ErrWarrantyClaimExists
+		}
+	}
+	repair := &entities.MaintenanceItem{
+		WaitingListID: waitingList.ID,
+		ItemType:      entities.MaintenanceItemTypeInitial,
+		Status:        entities.MaintenanceItemStatusPending,
+		Category:      original.Category,
+		Name:          "Warranty: " + original.Name,
+		Description:   req.Description,
+		Priority:      "high",
+		LaborHours:    original.LaborHours,
+		Notes:         req.Reason,
+	}
+	u.maintenanceItemUsecase.priceLabor(ctx, repair, waitingList)
+	claim := &entities.WarrantyClaim{
+		OriginalItemID: original.ID,
+		VehicleID:      waitingList.VehicleID,
+		WaitingListID:  waitingList.ID,
+		OpenedByID:     staffID,
+		Reason:         req.Reason,
+		Mileage:        mileage,
+	}
+	if err := u.claimRepo.Create(ctx, claim, repair); err != nil {
+		if errors.Is(err, repositories.ErrWarrantyClaimExists) {
+			return nil, ErrWarrantyClaimExists
+		}
+		return nil, err
+	}
+	u.maintenanceItemUsecase.reviseEstimate(ctx, waitingList.ID)
+	created, err := u.claimRepo.GetByID(ctx, claim.ID)
+	if err != nil || created == nil {
+		return nil, ErrWarrantyNotFound
+	}
+	return u.buildClaimResponse(created), nil
+}
+// GetClaimsByVehicle returns every warranty claim opened for the vehicle,
+// newest first.
+func (u *WarrantyUsecase) GetClaimsByVehicle(ctx context.Context, vehicleID types.MSSQLUUID) ([]*dto.WarrantyClaimResponse, error) {
+	claims, err := u.claimRepo.GetByVehicleID(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*dto.WarrantyClaimResponse, len(claims))
+	for i, claim := range claims {
+		responses[i] = u.buildClaimResponse(claim)
+	}
+	return responses, nil
+}
+func (u *WarrantyUsecase) buildClaimResponse(claim *entities.WarrantyClaim) *dto.WarrantyClaimResponse {
+	resp := &dto.WarrantyClaimResponse{
+		ID:            claim.ID,
+		VehicleID:     claim.VehicleID,
+		WaitingListID: claim.WaitingListID,
+		Reason:        claim.Reason,
+		Mileage:       claim.Mileage,
+		OpenedByID:    claim.OpenedByID,
+		CreatedAt:     claim.CreatedAt,
+	}
+	if claim.OpenedBy != nil {
+		resp.OpenedByName = claim.OpenedBy.Name
+	}
+	if claim.OriginalItem != nil {
+		resp.OriginalItem = &u.maintenanceItemUsecase.buildItemResponses([]*entities.MaintenanceItem{claim.OriginalItem})[0]
+	}
+	if claim.RepairItem != nil {
+		resp.RepairItem = &u.maintenanceItemUsecase.buildItemResponses([]*entities.MaintenanceItem{claim.RepairItem})[0]
+	}
+	return resp
+}
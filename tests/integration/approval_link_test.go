@@ -0,0 +1,55 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/adapters/repositories/mssql"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailedLinkDecisionKeepsTokenUsable(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := mssql.NewMaintenanceItemApprovalRepository(db)
+
+	waitingListID := types.NewMSSQLUUID()
+	token := &entities.ApprovalToken{WaitingListID: waitingListID, ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, db.Create(token).Error)
+	item := &entities.MaintenanceItem{
+		WaitingListID: waitingListID,
+		ItemType:      entities.MaintenanceItemTypeDiscovered,
+		Status:        entities.MaintenanceItemStatusPending,
+		Category:      "Brakes",
+		Name:          "Replace pads",
+	}
+	require.NoError(t, db.Create(item).Error)
+	t.Cleanup(func() {
+		db.Where("waiting_list_id = ?", waitingListID).Delete(&entities.MaintenanceItemApproval{})
+		db.Unscoped().Delete(item)
+		db.Unscoped().Delete(token)
+	})
+	decide := func() error {
+		return repo.RecordLinkDecisions(ctx, token.ID, []*entities.MaintenanceItemApproval{{
+			MaintenanceItemID: item.ID,
+			WaitingListID:     waitingListID,
+			CustomerID:        types.NewMSSQLUUID(),
+			Decision:          entities.ApprovalDecisionApproved,
+			Channel:           entities.ApprovalChannelMagicLink,
+		}})
+	}
+
+	assert.ErrorIs(t, decide(), repositories.ErrItemNotAwaitingApproval)
+	var stored entities.ApprovalToken
+	require.NoError(t, db.First(&stored, "id = ?", token.ID).Error)
+	assert.Nil(t, stored.UsedAt, "a failed recording leaves the link usable")
+
+	require.NoError(t, db.Model(item).Update("status", entities.MaintenanceItemStatusInspected).Error)
+	assert.NoError(t, decide())
+	assert.ErrorIs(t, decide(), repositories.ErrApprovalTokenUsed)
+}
@@ -0,0 +1,46 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/adapters/repositories/mssql"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/clause"
+)
+
+func TestStaleTicketDoesNotBlockBay(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := mssql.NewWaitingListRepository(db)
+	serviceDate := time.Date(2099, 1, 2, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(time.Now().UnixNano()%3650))
+	bayID := types.NewMSSQLUUID()
+	ticket := func(date time.Time, number int, status entities.WaitingListStatus) *entities.WaitingList {
+		waitingList := &entities.WaitingList{
+			QueueNumber:  number,
+			VehicleID:    types.NewMSSQLUUID(),
+			CustomerID:   types.NewMSSQLUUID(),
+			ServiceDate:  date,
+			ServiceType:  "Oil Change",
+			ServiceBayID: &bayID,
+			Status:       status,
+		}
+		require.NoError(t, db.Omit(clause.Associations).Create(waitingList).Error)
+		t.Cleanup(func() { db.Unscoped().Delete(waitingList) })
+		return waitingList
+	}
+	ticket(serviceDate.AddDate(0, 0, -1), 1, entities.WaitingListStatusInService)
+	today := ticket(serviceDate, 1, entities.WaitingListStatusCalled)
+
+	today.Status = entities.WaitingListStatusInService
+	require.NoError(t, repo.StartServiceInBay(ctx, today, nil))
+
+	other := ticket(serviceDate, 2, entities.WaitingListStatusCalled)
+	other.Status = entities.WaitingListStatusInService
+	assert.ErrorIs(t, repo.StartServiceInBay(ctx, other, nil), repositories.ErrBayOccupied)
+}
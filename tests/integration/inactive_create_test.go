@@ -0,0 +1,62 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/adapters/repositories/mssql"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateInactive checks that is_active false survives the insert instead
+// of being replaced by a column default.
+func TestCreateInactive(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	code := fmt.Sprintf("INACTIVE_%d", time.Now().UnixNano()%1e9)
+
+	t.Run("service type", func(t *testing.T) {
+		repo := mssql.NewServiceTypeRepository(db)
+		serviceType := &entities.ServiceType{Code: code, Name: "Inactive type", Category: "General"}
+		require.NoError(t, repo.Create(ctx, serviceType))
+		t.Cleanup(func() { db.Unscoped().Delete(serviceType) })
+
+		stored, err := repo.GetByID(ctx, serviceType.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.IsActive)
+	})
+	t.Run("service bay", func(t *testing.T) {
+		repo := mssql.NewServiceBayRepository(db)
+		bay := &entities.ServiceBay{Code: code, Name: "Inactive bay"}
+		require.NoError(t, repo.Create(ctx, bay))
+		t.Cleanup(func() { db.Unscoped().Delete(bay) })
+
+		stored, err := repo.GetByID(ctx, bay.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.IsActive)
+	})
+	t.Run("service package", func(t *testing.T) {
+		repo := mssql.NewServicePackageRepository(db)
+		pkg := &entities.ServicePackage{Code: code, Name: "Inactive package"}
+		require.NoError(t, repo.Create(ctx, pkg))
+		t.Cleanup(func() { db.Unscoped().Delete(pkg) })
+
+		stored, err := repo.GetByID(ctx, pkg.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.IsActive)
+	})
+	t.Run("inspection template", func(t *testing.T) {
+		repo := mssql.NewInspectionTemplateRepository(db)
+		template := &entities.InspectionTemplate{Name: code}
+		require.NoError(t, repo.Create(ctx, template))
+		t.Cleanup(func() { db.Unscoped().Delete(template) })
+
+		stored, err := repo.GetByID(ctx, template.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.IsActive)
+	})
+}
@@ -0,0 +1,55 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/adapters/repositories/mssql"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindingsAreAddedOnce(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := mssql.NewInspectionRepository(db)
+	inspection := &entities.Inspection{
+		WaitingListID: types.NewMSSQLUUID(),
+		TemplateID:    types.NewMSSQLUUID(),
+		TemplateName:  "Basic",
+		MechanicID:    types.NewMSSQLUUID(),
+		Results: []entities.InspectionResult{
+			{Category: "Brakes", Name: "Front pads", Status: entities.InspectionResultRed},
+		},
+	}
+	require.NoError(t, repo.Create(ctx, inspection))
+	t.Cleanup(func() {
+		db.Where("inspection_id = ?", inspection.ID).Delete(&entities.InspectionResult{})
+		db.Unscoped().Where("waiting_list_id = ?", inspection.WaitingListID).Delete(&entities.MaintenanceItem{})
+		db.Unscoped().Delete(inspection)
+	})
+	addFindings := func() error {
+		item := &entities.MaintenanceItem{
+			WaitingListID: inspection.WaitingListID,
+			ItemType:      entities.MaintenanceItemTypeDiscovered,
+			Status:        entities.MaintenanceItemStatusInspected,
+			Category:      "Brakes",
+			Name:          "Front pads",
+		}
+		return repo.AddFindings(ctx, inspection, []*entities.InspectionResult{&inspection.Results[0]}, []*entities.MaintenanceItem{item})
+	}
+
+	require.NoError(t, addFindings())
+	assert.ErrorIs(t, addFindings(), repositories.ErrFindingsAlreadyAdded)
+
+	var items int64
+	require.NoError(t, db.Model(&entities.MaintenanceItem{}).Where("waiting_list_id = ?", inspection.WaitingListID).Count(&items).Error)
+	assert.Equal(t, int64(1), items)
+	stored, err := repo.GetByID(ctx, inspection.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, stored.FindingsAt)
+	assert.NotNil(t, stored.Results[0].MaintenanceItemID)
+}
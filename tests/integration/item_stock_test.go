@@ -0,0 +1,138 @@
+package integration_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/adapters/repositories/mssql"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newStockTestUsecase(db *gorm.DB) *usecases.MaintenanceItemUsecase {
+	productRepo := mssql.NewProductRepository(db)
+	settingUsecase := usecases.NewSettingUsecase(mssql.NewSettingRepository(db))
+	return usecases.NewMaintenanceItemUsecase(
+		mssql.NewMaintenanceItemRepository(db),
+		mssql.NewMaintenanceItemPartRepository(db),
+		mssql.NewWaitingListRepository(db),
+		mssql.NewUserRepository(db),
+		productRepo,
+		usecases.NewServicePackageUsecase(mssql.NewServicePackageRepository(db), productRepo),
+		settingUsecase,
+		mssql.NewApprovalTokenRepository(db),
+		mssql.NewMaintenanceItemApprovalRepository(db),
+		mssql.NewMaintenanceItemLogRepository(db),
+		mssql.NewEstimateRepository(db),
+		nil,
+		nil,
+		"",
+	)
+}
+
+// seedItemWithPart creates a product with stock units and an approved item
+// that uses quantity of it.
+func seedItemWithPart(t *testing.T, db *gorm.DB, stock, quantity int) (*entities.Product, *entities.MaintenanceItem) {
+	product := &entities.Product{Name: "Stock test filter", Price: 10, Stock: stock, IsActive: true}
+	require.NoError(t, db.Create(product).Error)
+	item := &entities.MaintenanceItem{
+		WaitingListID: types.NewMSSQLUUID(),
+		Status:        entities.MaintenanceItemStatusApproved,
+		Category:      "Engine",
+		Name:          "Replace filter",
+	}
+	require.NoError(t, db.Create(item).Error)
+	part := &entities.MaintenanceItemPart{MaintenanceItemID: item.ID, ProductID: product.ID, Quantity: quantity, UnitPrice: 10}
+	require.NoError(t, db.Omit("Product").Create(part).Error)
+	t.Cleanup(func() {
+		db.Unscoped().Delete(part)
+		db.Unscoped().Delete(item)
+		db.Delete(product)
+	})
+	return product, item
+}
+
+func stockOf(t *testing.T, db *gorm.DB, product *entities.Product) int {
+	var stored entities.Product
+	require.NoError(t, db.First(&stored, "id = ?", product.ID).Error)
+	return stored.Stock
+}
+
+func TestCompleteItemStock(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	uc := newStockTestUsecase(db)
+	itemRepo := mssql.NewMaintenanceItemRepository(db)
+
+	t.Run("short stock refuses completion", func(t *testing.T) {
+		product, item := seedItemWithPart(t, db, 2, 3)
+		err := uc.CompleteItem(ctx, item.ID, 0, false)
+		assert.ErrorIs(t, err, repositories.ErrInsufficientStock)
+		assert.Equal(t, 2, stockOf(t, db, product))
+		stored, err := itemRepo.GetByID(ctx, item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.MaintenanceItemStatusApproved, stored.Status)
+	})
+
+	t.Run("override completes and takes stock below zero", func(t *testing.T) {
+		product, item := seedItemWithPart(t, db, 2, 3)
+		require.NoError(t, uc.CompleteItem(ctx, item.ID, 0, true))
+		assert.Equal(t, -1, stockOf(t, db, product))
+		stored, err := itemRepo.GetByID(ctx, item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.MaintenanceItemStatusCompleted, stored.Status)
+	})
+
+	undo := map[string]func(types.MSSQLUUID) error{
+		"cancel": func(id types.MSSQLUUID) error { return uc.CancelItem(ctx, id) },
+		"delete": func(id types.MSSQLUUID) error { return uc.DeleteItem(ctx, id) },
+		"reopen": func(id types.MSSQLUUID) error {
+			return uc.UpdateItem(ctx, id, dto.UpdateMaintenanceItemRequest{Status: string(entities.MaintenanceItemStatusApproved)})
+		},
+	}
+	for name, fn := range undo {
+		t.Run(name+" returns stock", func(t *testing.T) {
+			product, item := seedItemWithPart(t, db, 5, 2)
+			require.NoError(t, uc.CompleteItem(ctx, item.ID, 0, false))
+			assert.Equal(t, 3, stockOf(t, db, product))
+
+			require.NoError(t, fn(item.ID))
+			assert.Equal(t, 5, stockOf(t, db, product))
+		})
+	}
+}
+
+func TestConcurrentCompletionTakesStockOnce(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	partRepo := mssql.NewMaintenanceItemPartRepository(db)
+	product, item := seedItemWithPart(t, db, 10, 2)
+
+	run := func(fn func(*entities.MaintenanceItem) error) {
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				copied := *item
+				assert.NoError(t, fn(&copied))
+			}()
+		}
+		wg.Wait()
+	}
+
+	item.Status = entities.MaintenanceItemStatusCompleted
+	run(func(item *entities.MaintenanceItem) error { return partRepo.CompleteItem(ctx, item, false) })
+	assert.Equal(t, 8, stockOf(t, db, product))
+
+	item.Status = entities.MaintenanceItemStatusCanceled
+	run(func(item *entities.MaintenanceItem) error { return partRepo.RestockItem(ctx, item) })
+	assert.Equal(t, 10, stockOf(t, db, product))
+}
@@ -0,0 +1,95 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/adapters/repositories/mssql"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/infrastructure/database"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// openTestDB connects to the SQL Server named by TEST_DB_HOST and skips the
+// test when it is not set.
+func openTestDB(t *testing.T) *gorm.DB {
+	host := os.Getenv("TEST_DB_HOST")
+	if host == "" {
+		t.Skip("TEST_DB_HOST not set, skipping SQL Server integration test")
+	}
+	db, err := database.NewConnection(database.Config{
+		Host:     host,
+		Port:     getEnv("TEST_DB_PORT", "1433"),
+		User:     getEnv("TEST_DB_USER", "sa"),
+		Password: os.Getenv("TEST_DB_PASSWORD"),
+		Database: getEnv("TEST_DB_DATABASE", "go_crud_test"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close(db) })
+	return db
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func TestSaveWithQueueNumberIsRaceFree(t *testing.T) {
+	db := openTestDB(t)
+	repo := mssql.NewWaitingListRepository(db)
+	serviceDate := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(time.Now().UnixNano()%3650))
+	t.Cleanup(func() {
+		db.Unscoped().Where("service_date = ?", serviceDate).Delete(&entities.WaitingList{})
+		db.Where("service_date = ?", serviceDate.Format("2006-01-02")).Delete(&entities.QueueCounter{})
+	})
+
+	const takes, maxTickets = 20, 15
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		numbers []int
+		limited int
+	)
+	for i := 0; i < takes; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ticket := &entities.WaitingList{
+				VehicleID:   types.NewMSSQLUUID(),
+				CustomerID:  types.NewMSSQLUUID(),
+				ServiceDate: serviceDate,
+				ServiceType: "Oil change",
+				Status:      entities.WaitingListStatusWaiting,
+			}
+			err := repo.SaveWithQueueNumber(context.Background(), ticket, repositories.DailyLimit{MaxTickets: maxTickets}, nil)
+			mu.Lock()
+			defer mu.Unlock()
+			if errors.Is(err, repositories.ErrDailyLimitReached) {
+				limited++
+				return
+			}
+			if assert.NoError(t, err) {
+				numbers = append(numbers, ticket.QueueNumber)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, numbers, maxTickets)
+	assert.Equal(t, takes-maxTickets, limited)
+	seen := make(map[int]bool)
+	for _, n := range numbers {
+		assert.False(t, seen[n], "queue number %d handed out twice", n)
+		seen[n] = true
+	}
+}
@@ -0,0 +1,80 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/adapters/repositories/mssql"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandbyEntryIsPromotedOnce(t *testing.T) {
+	db := openTestDB(t)
+	repo := mssql.NewStandbyRepository(db)
+	serviceDate := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(time.Now().UnixNano()%3650))
+	entry := &entities.StandbyEntry{
+		VehicleID:   types.NewMSSQLUUID(),
+		CustomerID:  types.NewMSSQLUUID(),
+		ServiceDate: serviceDate,
+		ServiceType: "Oil change",
+		Status:      entities.StandbyStatusWaiting,
+	}
+	require.NoError(t, repo.Create(context.Background(), entry))
+	t.Cleanup(func() {
+		db.Unscoped().Where("service_date = ?", serviceDate).Delete(&entities.WaitingList{})
+		db.Unscoped().Where("id = ?", entry.ID).Delete(&entities.StandbyEntry{})
+		db.Where("service_date = ?", serviceDate.Format("2006-01-02")).Delete(&entities.QueueCounter{})
+	})
+
+	const runs = 5
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		promoted int
+		taken    int
+	)
+	for i := 0; i < runs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each run works on its own copy, as concurrent promoteStandby
+			// calls would after reading the day's list.
+			claim := *entry
+			ticket := &entities.WaitingList{
+				VehicleID:   claim.VehicleID,
+				CustomerID:  claim.CustomerID,
+				ServiceDate: claim.ServiceDate,
+				ServiceType: claim.ServiceType,
+				Status:      entities.WaitingListStatusWaiting,
+			}
+			transition := &entities.WaitingListTransition{ToStatus: entities.WaitingListStatusWaiting, Reason: "promoted from standby"}
+			err := repo.Promote(context.Background(), &claim, ticket, transition, repositories.DailyLimit{})
+			mu.Lock()
+			defer mu.Unlock()
+			if errors.Is(err, repositories.ErrStandbyNotWaiting) {
+				taken++
+				return
+			}
+			if assert.NoError(t, err) {
+				promoted++
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, promoted)
+	assert.Equal(t, runs-1, taken)
+	var tickets int64
+	db.Model(&entities.WaitingList{}).Where("service_date = ?", serviceDate).Count(&tickets)
+	assert.Equal(t, int64(1), tickets, "losing runs must not leave a ticket behind")
+	stored, err := repo.GetByID(context.Background(), entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.StandbyStatusPromoted, stored.Status)
+}
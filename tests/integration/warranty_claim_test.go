@@ -0,0 +1,47 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/adapters/repositories/mssql"
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateWarrantyClaimIsRejected(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := mssql.NewWarrantyClaimRepository(db)
+	originalID, waitingListID := types.NewMSSQLUUID(), types.NewMSSQLUUID()
+	t.Cleanup(func() {
+		db.Unscoped().Where("original_item_id = ?", originalID).Delete(&entities.WarrantyClaim{})
+		db.Unscoped().Where("waiting_list_id = ?", waitingListID).Delete(&entities.MaintenanceItem{})
+	})
+	open := func() error {
+		claim := &entities.WarrantyClaim{
+			OriginalItemID: originalID,
+			VehicleID:      types.NewMSSQLUUID(),
+			WaitingListID:  waitingListID,
+			OpenedByID:     types.NewMSSQLUUID(),
+			Reason:         "Brake noise is back",
+		}
+		repair := &entities.MaintenanceItem{
+			WaitingListID: waitingListID,
+			Status:        entities.MaintenanceItemStatusPending,
+			Category:      "Brakes",
+			Name:          "Warranty: Replace pads",
+		}
+		return repo.Create(ctx, claim, repair)
+	}
+
+	require.NoError(t, open())
+	assert.ErrorIs(t, open(), repositories.ErrWarrantyClaimExists)
+
+	var repairs int64
+	require.NoError(t, db.Model(&entities.MaintenanceItem{}).Where("waiting_list_id = ?", waitingListID).Count(&repairs).Error)
+	assert.Equal(t, int64(1), repairs, "the second repair item is rolled back")
+}
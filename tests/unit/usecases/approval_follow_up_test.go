@@ -0,0 +1,76 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApprovalFollowUpNextStep(t *testing.T) {
+	rules := usecases.ApprovalFollowUp{
+		RemindAfter:   time.Hour,
+		EscalateAfter: 3 * time.Hour,
+		AutoSkip:      true,
+		SkipAfter:     4 * time.Hour,
+	}
+	since := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	none := map[entities.MaintenanceItemLogAction]bool{}
+
+	_, ok := rules.NextStep(since, none, false, since.Add(30*time.Minute))
+	assert.False(t, ok)
+
+	step, ok := rules.NextStep(since, none, false, since.Add(time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, entities.MaintenanceItemLogReminderSent, step)
+
+	reminded := map[entities.MaintenanceItemLogAction]bool{entities.MaintenanceItemLogReminderSent: true}
+	_, ok = rules.NextStep(since, reminded, false, since.Add(2*time.Hour))
+	assert.False(t, ok, "a reminder is sent once")
+
+	step, _ = rules.NextStep(since, reminded, false, since.Add(3*time.Hour))
+	assert.Equal(t, entities.MaintenanceItemLogEscalated, step)
+
+	// A run that missed the reminder window escalates without reminding.
+	step, _ = rules.NextStep(since, none, false, since.Add(5*time.Hour))
+	assert.Equal(t, entities.MaintenanceItemLogEscalated, step)
+
+	escalated := map[entities.MaintenanceItemLogAction]bool{entities.MaintenanceItemLogEscalated: true}
+	_, ok = rules.NextStep(since, escalated, false, since.Add(10*time.Hour))
+	assert.False(t, ok, "items are only skipped once the ticket's work is done")
+
+	_, ok = rules.NextStep(since, escalated, true, since.Add(3*time.Hour))
+	assert.False(t, ok, "too early to skip")
+
+	step, _ = rules.NextStep(since, escalated, true, since.Add(4*time.Hour))
+	assert.Equal(t, entities.MaintenanceItemLogAutoSkipped, step)
+
+	rules.AutoSkip = false
+	_, ok = rules.NextStep(since, escalated, true, since.Add(10*time.Hour))
+	assert.False(t, ok)
+}
+
+func TestApprovalFollowUpDisabledSteps(t *testing.T) {
+	rules := usecases.ApprovalFollowUp{EscalateAfter: 2 * time.Hour}
+	since := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	none := map[entities.MaintenanceItemLogAction]bool{}
+
+	_, ok := rules.NextStep(since, none, true, since.Add(time.Hour))
+	assert.False(t, ok, "reminders are off")
+
+	step, _ := rules.NextStep(since, none, true, since.Add(2*time.Hour))
+	assert.Equal(t, entities.MaintenanceItemLogEscalated, step)
+}
+
+func TestTicketWorkDone(t *testing.T) {
+	awaiting := &entities.MaintenanceItem{Status: entities.MaintenanceItemStatusInspected, RequiresApproval: true}
+	completed := &entities.MaintenanceItem{Status: entities.MaintenanceItemStatusCompleted}
+	rejected := &entities.MaintenanceItem{Status: entities.MaintenanceItemStatusRejected}
+
+	assert.True(t, usecases.TicketWorkDone([]*entities.MaintenanceItem{awaiting, completed, rejected}))
+	assert.False(t, usecases.TicketWorkDone([]*entities.MaintenanceItem{awaiting, {Status: entities.MaintenanceItemStatusApproved}}))
+	assert.False(t, usecases.TicketWorkDone([]*entities.MaintenanceItem{awaiting, {Status: entities.MaintenanceItemStatusPending}}))
+	assert.False(t, usecases.TicketWorkDone([]*entities.MaintenanceItem{{Status: entities.MaintenanceItemStatusInspected}}))
+}
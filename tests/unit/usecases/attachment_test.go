@@ -0,0 +1,108 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStorage keeps saved files in memory.
+type memoryStorage struct {
+	files map[string][]byte
+}
+
+func (s *memoryStorage) Save(_ context.Context, key string, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	s.files[key] = data
+	return err
+}
+func (s *memoryStorage) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(s.files[key])), nil
+}
+func (s *memoryStorage) Delete(_ context.Context, key string) error {
+	delete(s.files, key)
+	return nil
+}
+
+// stubItemRepo returns item for every GetByID. Other methods are not used.
+type stubItemRepo struct {
+	repositories.MaintenanceItemRepository
+	item *entities.MaintenanceItem
+}
+
+func (r *stubItemRepo) GetByID(context.Context, types.MSSQLUUID) (*entities.MaintenanceItem, error) {
+	return r.item, nil
+}
+
+// failingAttachmentRepo fails every Create.
+type failingAttachmentRepo struct {
+	repositories.MaintenanceItemAttachmentRepository
+}
+
+func (failingAttachmentRepo) Create(context.Context, *entities.MaintenanceItemAttachment) error {
+	return errors.New("database is down")
+}
+
+func TestClassifyAttachment(t *testing.T) {
+	kind, err := usecases.ClassifyAttachment("image/jpeg", 2<<20)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.AttachmentKindPhoto, kind)
+
+	kind, err = usecases.ClassifyAttachment("video/mp4", 40<<20)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.AttachmentKindVideo, kind)
+
+	kind, err = usecases.ClassifyAttachment("application/pdf", 1<<20)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.AttachmentKindDocument, kind)
+
+	_, err = usecases.ClassifyAttachment("image/png", 11<<20)
+	assert.ErrorIs(t, err, usecases.ErrAttachmentTooLarge)
+
+	_, err = usecases.ClassifyAttachment("application/x-msdownload", 1024)
+	assert.ErrorIs(t, err, usecases.ErrAttachmentTypeNotAllowed)
+
+	_, err = usecases.ClassifyAttachment("text/plain; charset=utf-8", 1024)
+	assert.ErrorIs(t, err, usecases.ErrAttachmentTypeNotAllowed)
+
+	assert.Equal(t, int64(50<<20), usecases.MaxAttachmentSize())
+}
+
+func TestBuildAttachmentResponse(t *testing.T) {
+	attachment := &entities.MaintenanceItemAttachment{
+		ID:          types.NewMSSQLUUID(),
+		Kind:        entities.AttachmentKindPhoto,
+		FileName:    "brake.jpg",
+		ContentType: "image/jpeg",
+	}
+	resp := usecases.BuildAttachmentResponse(attachment)
+	assert.Equal(t, "/api/v1/maintenance/attachments/"+attachment.ID.String(), resp.URL)
+	assert.Empty(t, resp.ThumbnailURL)
+
+	attachment.ThumbnailKey = "maintenance/x/y_thumb.jpg"
+	resp = usecases.BuildAttachmentResponse(attachment)
+	assert.Equal(t, resp.URL+"/thumbnail", resp.ThumbnailURL)
+}
+
+func TestUploadRemovesFilesWhenCreateFails(t *testing.T) {
+	var photo bytes.Buffer
+	require.NoError(t, png.Encode(&photo, image.NewRGBA(image.Rect(0, 0, 40, 40))))
+	storage := &memoryStorage{files: make(map[string][]byte)}
+	item := &stubItemRepo{item: &entities.MaintenanceItem{ID: types.NewMSSQLUUID()}}
+	uc := usecases.NewMaintenanceAttachmentUsecase(failingAttachmentRepo{}, item, nil, storage)
+
+	_, err := uc.Upload(context.Background(), types.NewMSSQLUUID(), item.item.ID, "brake.png", int64(photo.Len()), &photo)
+	assert.Error(t, err)
+	assert.Empty(t, storage.files, "the photo and its thumbnail are removed")
+}
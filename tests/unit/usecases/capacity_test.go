@@ -0,0 +1,47 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDayCapacityMinutes(t *testing.T) {
+	monday := time.Date(2025, 11, 17, 0, 0, 0, 0, time.UTC)
+	hours := usecases.BusinessHours{
+		Opening:     8 * time.Hour,
+		Closing:     17 * time.Hour,
+		WorkingDays: map[time.Weekday]bool{time.Monday: true},
+	}
+	at := func(clock string) time.Time {
+		t, _ := time.Parse("2006-01-02 15:04", "2025-11-17 "+clock)
+		return t
+	}
+
+	assert.Equal(t, 540, usecases.DayCapacityMinutes(hours, monday, 0, nil))
+	assert.Equal(t, 1620, usecases.DayCapacityMinutes(hours, monday, 3, nil))
+	assert.Equal(t, 0, usecases.DayCapacityMinutes(hours, monday.AddDate(0, 0, 1), 3, nil))
+
+	lunch := []usecases.TimeWindow{{Start: at("12:00"), End: at("13:00")}}
+	assert.Equal(t, 960, usecases.DayCapacityMinutes(hours, monday, 2, lunch))
+
+	early := []usecases.TimeWindow{{Start: at("06:00"), End: at("09:00")}}
+	assert.Equal(t, 480, usecases.DayCapacityMinutes(hours, monday, 1, early), "closure before opening is clipped")
+}
+
+func TestTicketMinutes(t *testing.T) {
+	entries := []*entities.WaitingList{
+		{Status: entities.WaitingListStatusWaiting, EstimatedTime: 20},
+		{Status: entities.WaitingListStatusInService, EstimatedTime: 240},
+		{Status: entities.WaitingListStatusCalled},
+		{Status: entities.WaitingListStatusCompleted, EstimatedTime: 60},
+		{Status: entities.WaitingListStatusCanceled, EstimatedTime: 60},
+	}
+
+	tickets, minutes := usecases.TicketMinutes(entries, 30)
+	assert.Equal(t, 3, tickets)
+	assert.Equal(t, 290, minutes)
+}
@@ -0,0 +1,88 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func estimateItems() []*entities.MaintenanceItem {
+	return []*entities.MaintenanceItem{
+		{ID: types.NewMSSQLUUID(), Name: "Oil change", Status: entities.MaintenanceItemStatusPending, EstimatedCost: 300000},
+		{ID: types.NewMSSQLUUID(), Name: "Brake pads", Status: entities.MaintenanceItemStatusApproved, LaborCost: 100000,
+			Parts: []entities.MaintenanceItemPart{{Quantity: 2, UnitPrice: 150000, Product: &entities.Product{Name: "Brake pad"}}}},
+		{ID: types.NewMSSQLUUID(), Name: "Wiper blades", Status: entities.MaintenanceItemStatusInspected, RequiresApproval: true, EstimatedCost: 80000},
+		{ID: types.NewMSSQLUUID(), Name: "Air filter", Status: entities.MaintenanceItemStatusRejected, EstimatedCost: 90000},
+	}
+}
+
+func TestBuildEstimate(t *testing.T) {
+	estimate := usecases.BuildEstimate(estimateItems(), 11)
+
+	assert.Len(t, estimate.Lines, 2, "items waiting for approval or rejected are left out")
+	assert.Equal(t, 300000.0, estimate.Lines[0].Amount)
+	assert.Equal(t, 400000.0, estimate.Lines[1].Amount, "parts plus labor when nothing was estimated")
+	assert.Equal(t, "Brake pad", estimate.Lines[1].Parts[0].Name)
+	assert.Equal(t, 700000.0, estimate.Subtotal)
+	assert.Equal(t, 77000.0, estimate.TaxAmount)
+	assert.Equal(t, 777000.0, estimate.Total)
+}
+
+func TestDiffEstimateLines(t *testing.T) {
+	items := estimateItems()
+	before := usecases.BuildEstimate(items, 11)
+
+	assert.Empty(t, usecases.DiffEstimateLines(before.Lines, usecases.BuildEstimate(items, 11).Lines))
+
+	items[0].EstimatedCost = 350000
+	items[1].Status = entities.MaintenanceItemStatusCanceled
+	items[2].Status = entities.MaintenanceItemStatusApproved
+	changes := usecases.DiffEstimateLines(before.Lines, usecases.BuildEstimate(items, 11).Lines)
+
+	assert.Len(t, changes, 3)
+	assert.Equal(t, "changed", changes[0].Change)
+	assert.Equal(t, 300000.0, changes[0].PreviousAmount)
+	assert.Equal(t, 350000.0, changes[0].Amount)
+	assert.Equal(t, "added", changes[1].Change)
+	assert.Equal(t, "Wiper blades", changes[1].Name)
+	assert.Equal(t, "removed", changes[2].Change)
+	assert.Equal(t, "Brake pads", changes[2].Name)
+}
+
+func TestEstimateDiffBase(t *testing.T) {
+	v1 := &entities.Estimate{Version: 1, Status: entities.EstimateStatusAccepted}
+	v2 := &entities.Estimate{Version: 2, Status: entities.EstimateStatusSuperseded}
+
+	assert.Nil(t, usecases.EstimateDiffBase(nil))
+	assert.Same(t, v1, usecases.EstimateDiffBase([]*entities.Estimate{v2, v1}), "the accepted version wins")
+
+	v1.Status = entities.EstimateStatusDeclined
+	assert.Same(t, v2, usecases.EstimateDiffBase([]*entities.Estimate{v2, v1}))
+}
+
+func TestEstimateOverrun(t *testing.T) {
+	items := estimateItems()
+	accepted := usecases.BuildEstimate(items, 11)
+	accepted.Version = 1
+
+	items[0].Status = entities.MaintenanceItemStatusCompleted
+	items[0].ActualCost = 360000
+	assert.Nil(t, usecases.EstimateOverrun(accepted, items, 10), "within the threshold")
+
+	items[0].ActualCost = 400000
+	warning := usecases.EstimateOverrun(accepted, items, 10)
+	if assert.NotNil(t, warning) {
+		assert.Equal(t, 1, warning.EstimateVersion)
+		assert.Equal(t, 888000.0, warning.FinalTotal)
+		assert.Equal(t, 111000.0, warning.Overrun)
+		assert.Equal(t, 14.29, warning.OverrunPercent)
+	}
+
+	// Items still waiting for approval do not count towards the final cost.
+	items[0].ActualCost = 300000
+	items[2].EstimatedCost = 500000
+	assert.Nil(t, usecases.EstimateOverrun(accepted, items, 10))
+}
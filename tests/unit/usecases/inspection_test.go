@@ -0,0 +1,78 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindingItemRequest(t *testing.T) {
+	ticket := types.NewMSSQLUUID()
+	depth := 1.6
+	red := &entities.InspectionResult{
+		Category:    "Tires",
+		Name:        "Front left tread depth",
+		Unit:        "mm",
+		Status:      entities.InspectionResultRed,
+		Measurement: &depth,
+		Notes:       "Below legal limit",
+	}
+	req, ok := usecases.FindingItemRequest(ticket, red, "/photo")
+	assert.True(t, ok)
+	assert.Equal(t, ticket, req.WaitingListID)
+	assert.Equal(t, "Tires", req.Category)
+	assert.Equal(t, "Front left tread depth", req.Name)
+	assert.Equal(t, "Front left tread depth: 1.6 mm (red)", req.Description)
+	assert.Equal(t, "urgent", req.Priority)
+	assert.True(t, req.RequiresApproval)
+	assert.Equal(t, "/photo", req.ImageURL)
+	assert.Equal(t, "Below legal limit", req.Notes)
+
+	yellow := &entities.InspectionResult{Category: "Lights", Name: "Brake lights", Status: entities.InspectionResultYellow}
+	req, ok = usecases.FindingItemRequest(ticket, yellow, "")
+	assert.True(t, ok)
+	assert.Equal(t, "Brake lights: yellow", req.Description)
+	assert.Equal(t, "normal", req.Priority)
+
+	_, ok = usecases.FindingItemRequest(ticket, &entities.InspectionResult{Status: entities.InspectionResultGreen}, "")
+	assert.False(t, ok)
+	_, ok = usecases.FindingItemRequest(ticket, &entities.InspectionResult{}, "")
+	assert.False(t, ok, "unchecked points are not findings")
+
+	itemID := types.NewMSSQLUUID()
+	red.MaintenanceItemID = &itemID
+	_, ok = usecases.FindingItemRequest(ticket, red, "")
+	assert.False(t, ok, "a finding becomes an item only once")
+}
+
+func TestCountInspectionResults(t *testing.T) {
+	green, yellow, red, unchecked := usecases.CountInspectionResults([]entities.InspectionResult{
+		{Status: entities.InspectionResultGreen},
+		{Status: entities.InspectionResultGreen},
+		{Status: entities.InspectionResultYellow},
+		{Status: entities.InspectionResultRed},
+		{},
+	})
+	assert.Equal(t, []int{2, 1, 1, 1}, []int{green, yellow, red, unchecked})
+}
+
+func TestGroupInspectionResults(t *testing.T) {
+	categories := usecases.GroupInspectionResults([]dto.InspectionResultResponse{
+		{Category: "Tires", Name: "Front left", Status: "green"},
+		{Category: "Brakes", Name: "Front pads", Status: "yellow"},
+		{Category: "Tires", Name: "Front right", Status: "red"},
+		{Category: "Tires", Name: "Rear left", Status: "yellow"},
+		{Category: "Brakes", Name: "Rear pads", Status: "green"},
+	})
+	assert.Len(t, categories, 2)
+	assert.Equal(t, "Tires", categories[0].Category)
+	assert.Equal(t, "red", categories[0].Status)
+	assert.Len(t, categories[0].Results, 3)
+	assert.Equal(t, "Brakes", categories[1].Category)
+	assert.Equal(t, "yellow", categories[1].Status)
+	assert.Len(t, categories[1].Results, 2)
+}
@@ -0,0 +1,129 @@
+package usecases_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKioskRepo keeps kiosk devices and activity in memory.
+type fakeKioskRepo struct {
+	devices  map[types.MSSQLUUID]*entities.KioskDevice
+	activity []*entities.KioskActivity
+}
+
+func newFakeKioskRepo() *fakeKioskRepo {
+	return &fakeKioskRepo{devices: make(map[types.MSSQLUUID]*entities.KioskDevice)}
+}
+func (r *fakeKioskRepo) Create(_ context.Context, device *entities.KioskDevice) error {
+	device.ID = types.NewMSSQLUUID()
+	stored := *device
+	r.devices[device.ID] = &stored
+	return nil
+}
+func (r *fakeKioskRepo) GetByID(_ context.Context, id types.MSSQLUUID) (*entities.KioskDevice, error) {
+	if device, ok := r.devices[id]; ok {
+		copied := *device
+		return &copied, nil
+	}
+	return nil, nil
+}
+func (r *fakeKioskRepo) GetByTokenHash(_ context.Context, tokenHash string) (*entities.KioskDevice, error) {
+	for _, device := range r.devices {
+		if device.TokenHash == tokenHash {
+			copied := *device
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+func (r *fakeKioskRepo) GetAll(context.Context) ([]*entities.KioskDevice, error) {
+	return nil, nil
+}
+func (r *fakeKioskRepo) Update(_ context.Context, device *entities.KioskDevice) error {
+	stored := *device
+	r.devices[device.ID] = &stored
+	return nil
+}
+func (r *fakeKioskRepo) CreateActivity(_ context.Context, activity *entities.KioskActivity) error {
+	r.activity = append(r.activity, activity)
+	return nil
+}
+func (r *fakeKioskRepo) GetActivity(context.Context, types.MSSQLUUID, int) ([]*entities.KioskActivity, error) {
+	return nil, nil
+}
+
+// unknownVehicleRepo finds no vehicle for any plate. Other methods are not used.
+type unknownVehicleRepo struct {
+	repositories.VehicleRepository
+}
+
+func (unknownVehicleRepo) GetByLicensePlate(context.Context, string) (*entities.Vehicle, error) {
+	return nil, nil
+}
+
+func TestMaskName(t *testing.T) {
+	assert.Equal(t, "B*** S***", usecases.MaskName("Budi Santoso"))
+	assert.Equal(t, "A***", usecases.MaskName("  Ani "))
+	assert.Equal(t, "", usecases.MaskName(""))
+}
+
+func TestKioskTokenIsStoredHashed(t *testing.T) {
+	repo := newFakeKioskRepo()
+	kiosk := usecases.NewKioskUsecase(repo, nil, nil, nil)
+
+	device, token, err := kiosk.RegisterDevice(context.Background(), " Front desk ")
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(token, "kiosk_"))
+	assert.Equal(t, "Front desk", device.Name)
+	assert.NotEmpty(t, device.TokenHash)
+	assert.NotContains(t, device.TokenHash, strings.TrimPrefix(token, "kiosk_"), "the token itself is never stored")
+
+	id, err := kiosk.AuthenticateKiosk(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, device.ID, id)
+
+	_, err = kiosk.AuthenticateKiosk(context.Background(), token+"x")
+	assert.ErrorIs(t, err, usecases.ErrKioskUnauthorized)
+	_, err = kiosk.AuthenticateKiosk(context.Background(), device.TokenHash)
+	assert.ErrorIs(t, err, usecases.ErrKioskUnauthorized, "the stored hash does not work as a token")
+}
+
+func TestRevokedKioskTokenIsRejected(t *testing.T) {
+	repo := newFakeKioskRepo()
+	kiosk := usecases.NewKioskUsecase(repo, nil, nil, nil)
+	device, token, err := kiosk.RegisterDevice(context.Background(), "Lobby tablet")
+	require.NoError(t, err)
+
+	require.NoError(t, kiosk.RevokeDevice(context.Background(), device.ID))
+	_, err = kiosk.AuthenticateKiosk(context.Background(), token)
+	assert.ErrorIs(t, err, usecases.ErrKioskUnauthorized)
+
+	revoked, _ := repo.GetByID(context.Background(), device.ID)
+	revokedAt := *revoked.RevokedAt
+	require.NoError(t, kiosk.RevokeDevice(context.Background(), device.ID), "revoking twice is a no-op")
+	revoked, _ = repo.GetByID(context.Background(), device.ID)
+	assert.Equal(t, revokedAt, *revoked.RevokedAt)
+}
+
+func TestFailedKioskTakeIsRecorded(t *testing.T) {
+	repo := newFakeKioskRepo()
+	kiosk := usecases.NewKioskUsecase(repo, unknownVehicleRepo{}, nil, nil)
+	device, _, err := kiosk.RegisterDevice(context.Background(), "Lobby tablet")
+	require.NoError(t, err)
+
+	err = kiosk.TakeQueue(context.Background(), device.ID, "B 1234 XYZ", &entities.WaitingList{})
+	assert.ErrorIs(t, err, usecases.ErrVehicleNotRegistered)
+	require.Len(t, repo.activity, 1)
+	assert.Equal(t, entities.KioskActionTakeQueue, repo.activity[0].Action)
+	assert.Equal(t, "B 1234 XYZ", repo.activity[0].LicensePlate)
+	assert.Equal(t, usecases.ErrVehicleNotRegistered.Error(), repo.activity[0].Error)
+}
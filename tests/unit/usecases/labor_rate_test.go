@@ -0,0 +1,35 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRateList(t *testing.T) {
+	rates := usecases.ParseRateList(" Engine:150000, brakes : 120000,bogus,Paint:-1,Tires:abc")
+	assert.Equal(t, map[string]float64{"engine": 150000, "brakes": 120000}, rates)
+	assert.Empty(t, usecases.ParseRateList(""))
+}
+
+func TestHourlyRate(t *testing.T) {
+	rates := usecases.LaborRates{
+		DefaultRate:        100000,
+		CategoryRates:      map[string]float64{"engine": 150000},
+		SkillMultipliers:   map[string]float64{"senior": 1.2},
+		OvertimeMultiplier: 1.5,
+		WeekendMultiplier:  2,
+	}
+	hours := usecases.BusinessHours{Opening: 8 * time.Hour, Closing: 18 * time.Hour}
+	monday := time.Date(2026, 10, 12, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 100000.0, rates.HourlyRate("Suspension", "", monday, hours))
+	assert.Equal(t, 150000.0, rates.HourlyRate("Engine", "junior", monday, hours), "unknown skill level pays the plain rate")
+	assert.Equal(t, 180000.0, rates.HourlyRate("ENGINE", "Senior", monday, hours))
+	assert.Equal(t, 150000.0, rates.HourlyRate("Brakes", "", monday.Add(9*time.Hour), hours), "after closing is overtime")
+	assert.Equal(t, 200000.0, rates.HourlyRate("Brakes", "", monday.AddDate(0, 0, 6).Add(9*time.Hour), hours), "weekend and overtime do not stack")
+
+	assert.Equal(t, 270000.0, usecases.LaborCost(1.5, 180000))
+}
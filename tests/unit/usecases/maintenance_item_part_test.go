@@ -0,0 +1,18 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartsCost(t *testing.T) {
+	parts := []entities.MaintenanceItemPart{
+		{Quantity: 4, UnitPrice: 60000},
+		{Quantity: 1, UnitPrice: 35000.555},
+	}
+	assert.Equal(t, 275000.56, usecases.PartsCost(parts))
+	assert.Equal(t, 0.0, usecases.PartsCost(nil))
+}
@@ -0,0 +1,46 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickLeastLoadedMechanic(t *testing.T) {
+	alice := &entities.User{ID: types.NewMSSQLUUID(), Name: "Alice"}
+	bob := &entities.User{ID: types.NewMSSQLUUID(), Name: "Bob"}
+	carol := &entities.User{ID: types.NewMSSQLUUID(), Name: "Carol"}
+	durations := usecases.ServiceDurations{DefaultMinutes: 30}
+	job := func(mechanic *entities.User, minutes int, status entities.WaitingListStatus) *entities.WaitingList {
+		return &entities.WaitingList{MechanicID: &mechanic.ID, EstimatedTime: minutes, Status: status}
+	}
+
+	t.Run("fewest estimated minutes wins over fewest tickets", func(t *testing.T) {
+		tickets := []*entities.WaitingList{
+			job(alice, 240, entities.WaitingListStatusInService),
+			job(bob, 20, entities.WaitingListStatusWaiting),
+			job(bob, 20, entities.WaitingListStatusWaiting),
+		}
+		assert.Equal(t, bob, usecases.PickLeastLoadedMechanic([]*entities.User{alice, bob}, tickets, durations))
+	})
+
+	t.Run("closed tickets do not count as load", func(t *testing.T) {
+		tickets := []*entities.WaitingList{
+			job(alice, 240, entities.WaitingListStatusCompleted),
+			job(bob, 20, entities.WaitingListStatusCalled),
+		}
+		assert.Equal(t, alice, usecases.PickLeastLoadedMechanic([]*entities.User{alice, bob}, tickets, durations))
+	})
+
+	t.Run("ties go to the mechanic with fewer tickets", func(t *testing.T) {
+		tickets := []*entities.WaitingList{
+			job(alice, 15, entities.WaitingListStatusWaiting),
+			job(alice, 15, entities.WaitingListStatusWaiting),
+			job(carol, 30, entities.WaitingListStatusWaiting),
+		}
+		assert.Equal(t, carol, usecases.PickLeastLoadedMechanic([]*entities.User{alice, carol}, tickets, durations))
+	})
+}
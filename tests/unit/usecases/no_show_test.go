@@ -0,0 +1,50 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverdueCalledTickets(t *testing.T) {
+	now := time.Date(2025, 11, 15, 10, 0, 0, 0, time.UTC)
+	longAgo := now.Add(-20 * time.Minute)
+	justNow := now.Add(-5 * time.Minute)
+	tickets := []*entities.WaitingList{
+		{QueueNumber: 1, Status: entities.WaitingListStatusCalled, CalledAt: &longAgo},
+		{QueueNumber: 2, Status: entities.WaitingListStatusCalled, CalledAt: &justNow},
+		{QueueNumber: 3, Status: entities.WaitingListStatusInService, CalledAt: &longAgo},
+		{QueueNumber: 4, Status: entities.WaitingListStatusCalled},
+		{QueueNumber: 5, Status: entities.WaitingListStatusCalled, CalledAt: &longAgo, ArrivedAt: &justNow},
+	}
+
+	overdue := usecases.OverdueCalledTickets(tickets, 15*time.Minute, now)
+
+	if assert.Len(t, overdue, 1, "checked-in customers are not no-shows") {
+		assert.Equal(t, 1, overdue[0].QueueNumber)
+	}
+}
+
+func TestNextWaitingTicket(t *testing.T) {
+	now := time.Date(2025, 11, 15, 10, 0, 0, 0, time.UTC)
+	due := now.Add(-10 * time.Minute)
+	later := now.Add(2 * time.Hour)
+
+	walkIns := []*entities.WaitingList{
+		{QueueNumber: 5, Status: entities.WaitingListStatusWaiting},
+		{QueueNumber: 2, Status: entities.WaitingListStatusCanceled},
+		{QueueNumber: 3, Status: entities.WaitingListStatusWaiting},
+	}
+	assert.Equal(t, 3, usecases.NextWaitingTicket(walkIns, now).QueueNumber)
+
+	withAppointments := append(walkIns,
+		&entities.WaitingList{QueueNumber: 7, Status: entities.WaitingListStatusWaiting, AppointmentAt: &later},
+		&entities.WaitingList{QueueNumber: 9, Status: entities.WaitingListStatusWaiting, AppointmentAt: &due},
+	)
+	assert.Equal(t, 9, usecases.NextWaitingTicket(withAppointments, now).QueueNumber)
+
+	assert.Nil(t, usecases.NextWaitingTicket(nil, now))
+}
@@ -0,0 +1,48 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/dto"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskLicensePlate(t *testing.T) {
+	tests := []struct {
+		plate    string
+		expected string
+	}{
+		{"B 1234 XYZ", "B 1*** *YZ"},
+		{"ABC123", "AB**23"},
+		{"AB123", "AB*23"},
+		{"AB12", "****"},
+		{"B 1", "* *"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.plate, func(t *testing.T) {
+			assert.Equal(t, tt.expected, usecases.MaskLicensePlate(tt.plate))
+		})
+	}
+}
+
+func TestQueueBoard_PublishFansOutToSubscribers(t *testing.T) {
+	board := usecases.NewQueueBoard()
+	first, unsubscribeFirst := board.Subscribe()
+	second, unsubscribeSecond := board.Subscribe()
+	defer unsubscribeSecond()
+
+	board.Publish(dto.QueueBoardEntry{QueueNumber: 3, Status: "called"})
+	assert.Equal(t, 3, (<-first).QueueNumber)
+	assert.Equal(t, 3, (<-second).QueueNumber)
+
+	unsubscribeFirst()
+	unsubscribeFirst()
+	_, open := <-first
+	assert.False(t, open)
+
+	board.Publish(dto.QueueBoardEntry{QueueNumber: 4, Status: "in_service"})
+	assert.Equal(t, 4, (<-second).QueueNumber)
+}
@@ -0,0 +1,66 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/domain/repositories"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDurations_For(t *testing.T) {
+	durations := usecases.ServiceDurations{
+		Averages: repositories.ServiceAverages{
+			ByCode: map[string]float64{"OIL_CHANGE": 25},
+			ByName: map[string]float64{"oil change": 20},
+		},
+		DefaultMinutes: 30,
+	}
+
+	assert.Equal(t, 25*time.Minute, durations.For(&entities.WaitingList{ServiceTypeCode: "OIL_CHANGE", ServiceType: "Ganti Oli", EstimatedTime: 90}))
+	assert.Equal(t, 20*time.Minute, durations.For(&entities.WaitingList{ServiceTypeCode: "TUNE_UP", ServiceType: "Oil Change", EstimatedTime: 90}))
+	assert.Equal(t, 20*time.Minute, durations.For(&entities.WaitingList{ServiceType: " Oil Change", EstimatedTime: 90}))
+	assert.Equal(t, 90*time.Minute, durations.For(&entities.WaitingList{ServiceType: "Engine", EstimatedTime: 90}))
+	assert.Equal(t, 30*time.Minute, durations.For(&entities.WaitingList{ServiceType: "Engine"}))
+}
+
+func TestEstimateQueue(t *testing.T) {
+	now := time.Date(2025, 11, 17, 9, 0, 0, 0, time.UTC)
+	startedAt := now.Add(-10 * time.Minute)
+	durations := usecases.ServiceDurations{
+		Averages:       repositories.ServiceAverages{ByName: map[string]float64{"oil change": 20, "engine": 120}},
+		DefaultMinutes: 30,
+	}
+	ticket := func(number int, serviceType string, status entities.WaitingListStatus) *entities.WaitingList {
+		return &entities.WaitingList{ID: types.NewMSSQLUUID(), QueueNumber: number, ServiceType: serviceType, Status: status}
+	}
+	inService := ticket(1, "engine", entities.WaitingListStatusInService)
+	inService.ServiceStartAt = &startedAt
+	ahead := ticket(2, "oil change", entities.WaitingListStatusWaiting)
+	mine := ticket(3, "oil change", entities.WaitingListStatusWaiting)
+	day := []*entities.WaitingList{inService, ahead, mine}
+
+	t.Run("single lane waits for every ticket ahead", func(t *testing.T) {
+		estimate := usecases.EstimateQueue(mine, day, durations, 1, now)
+		assert.Equal(t, 1, estimate.WaitingAhead)
+		assert.Equal(t, 1, estimate.CurrentlyServing)
+		assert.Equal(t, 130, estimate.WaitMinutes) // 110 remaining on the engine job + 20 oil change
+		assert.Equal(t, now.Add(150*time.Minute), *estimate.EstimatedDoneAt)
+	})
+
+	t.Run("parallel lanes pick the earliest free one", func(t *testing.T) {
+		estimate := usecases.EstimateQueue(mine, day, durations, 2, now)
+		assert.Equal(t, 20, estimate.WaitMinutes)
+		assert.Equal(t, now.Add(20*time.Minute), *estimate.EstimatedCalledAt)
+	})
+
+	t.Run("in-service ticket only gets a completion estimate", func(t *testing.T) {
+		estimate := usecases.EstimateQueue(inService, day, durations, 1, now)
+		assert.Equal(t, 0, estimate.WaitMinutes)
+		assert.Nil(t, estimate.EstimatedCalledAt)
+		assert.Equal(t, startedAt.Add(120*time.Minute), *estimate.EstimatedDoneAt)
+	})
+}
@@ -0,0 +1,30 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildBayOverview(t *testing.T) {
+	bay1 := &entities.ServiceBay{ID: types.NewMSSQLUUID(), Code: "BAY-1", Name: "Bay 1"}
+	bay2 := &entities.ServiceBay{ID: types.NewMSSQLUUID(), Code: "BAY-2", Name: "Bay 2"}
+	tickets := []*entities.WaitingList{
+		{QueueNumber: 1, Status: entities.WaitingListStatusCompleted, ServiceBayID: &bay2.ID},
+		{QueueNumber: 2, Status: entities.WaitingListStatusInService, ServiceBayID: &bay1.ID, ServiceType: "Oil change",
+			Vehicle: entities.Vehicle{LicensePlate: "B 1234 XYZ"}},
+		{QueueNumber: 3, Status: entities.WaitingListStatusWaiting},
+	}
+
+	overview := usecases.BuildBayOverview([]*entities.ServiceBay{bay1, bay2}, tickets)
+
+	assert.Equal(t, 2, overview.TotalBays)
+	assert.Equal(t, 1, overview.FreeBays)
+	assert.True(t, overview.Bays[0].Busy)
+	assert.Equal(t, 2, overview.Bays[0].QueueNumber)
+	assert.Equal(t, "B 1*** *YZ", overview.Bays[0].LicensePlate)
+	assert.False(t, overview.Bays[1].Busy)
+}
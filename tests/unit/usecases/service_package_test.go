@@ -0,0 +1,55 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandPackage(t *testing.T) {
+	oil := types.NewMSSQLUUID()
+	prices := map[types.MSSQLUUID]float64{oil: 60000}
+	pkg := &entities.ServicePackage{
+		Code:            "10K_SERVICE",
+		DiscountPercent: 10,
+		Items: []entities.ServicePackageItem{
+			{Category: "Engine", Name: "Oil change", EstimatedCost: 30000, LaborHours: 0.5, Parts: []entities.ServicePackagePart{{ProductID: oil, Quantity: 4}}},
+			{Category: "Brakes", Name: "Brake check", EstimatedCost: 50000},
+			{Category: "Tires", Name: "Tire rotation", EstimatedCost: 40000},
+		},
+	}
+
+	assert.Equal(t, 360000.0, usecases.PackageListPrice(pkg, prices))
+
+	items, err := usecases.ExpandPackage(pkg, prices, nil)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, 243000.0, items[0].EstimatedCost)
+	assert.Equal(t, 27000.0, items[0].DiscountAmount)
+	assert.Equal(t, 0.5, items[0].LaborHours)
+	assert.Equal(t, "10K_SERVICE", items[0].PackageCode)
+	assert.Equal(t, entities.MaintenanceItemTypeInitial, items[0].ItemType)
+	require.Len(t, items[0].Parts, 1)
+	assert.Equal(t, oil, items[0].Parts[0].ProductID)
+	assert.Equal(t, 4, items[0].Parts[0].Quantity)
+	assert.Equal(t, 60000.0, items[0].Parts[0].UnitPrice)
+	assert.Empty(t, items[1].Parts)
+
+	items, err = usecases.ExpandPackage(pkg, prices, []string{"brake CHECK"})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Tire rotation", items[1].Name)
+
+	_, err = usecases.ExpandPackage(pkg, prices, []string{"Wiper blades"})
+	assert.ErrorIs(t, err, usecases.ErrPackageNotApplicable)
+}
+
+func TestPackageDiscountRate(t *testing.T) {
+	assert.Equal(t, 0.1, usecases.PackageDiscountRate(&entities.ServicePackage{DiscountPercent: 10}, 1000))
+	assert.InDelta(t, 0.25, usecases.PackageDiscountRate(&entities.ServicePackage{DiscountPercent: 10, FixedPrice: 750}, 1000), 1e-9)
+	assert.Equal(t, 0.0, usecases.PackageDiscountRate(&entities.ServicePackage{FixedPrice: 1200}, 1000), "fixed price above list price is no discount")
+}
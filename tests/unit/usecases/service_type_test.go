@@ -0,0 +1,22 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeServiceTypeCode(t *testing.T) {
+	tests := map[string]string{
+		"OIL_CHANGE":      "OIL_CHANGE",
+		"oil change":      "OIL_CHANGE",
+		"  Oil-Change  ":  "OIL_CHANGE",
+		"brake / service": "BRAKE_SERVICE",
+		"4x4 check":       "4X4_CHECK",
+		"--":              "",
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, usecases.NormalizeServiceTypeCode(input), input)
+	}
+}
@@ -0,0 +1,41 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func closureDate(value string) time.Time {
+	parsed, _ := time.Parse("2006-01-02", value)
+	return parsed
+}
+
+func TestShopClosureCoversDateRange(t *testing.T) {
+	closure := &entities.ShopClosure{Name: "Renovation", StartDate: closureDate("2025-03-10"), EndDate: closureDate("2025-03-12")}
+
+	assert.True(t, closure.IsFullDay())
+	assert.False(t, closure.Covers(closureDate("2025-03-09")))
+	assert.True(t, closure.Covers(closureDate("2025-03-10")))
+	assert.True(t, closure.Covers(time.Date(2025, 3, 12, 15, 0, 0, 0, time.UTC)))
+	assert.False(t, closure.Covers(closureDate("2025-03-13")))
+	assert.False(t, closure.Covers(closureDate("2026-03-11")))
+}
+
+func TestShopClosureRecurringWrapsNewYear(t *testing.T) {
+	closure := &entities.ShopClosure{Name: "Year-end break", StartDate: closureDate("2024-12-31"), EndDate: closureDate("2025-01-01"), IsRecurring: true}
+
+	assert.True(t, closure.Covers(closureDate("2030-12-31")))
+	assert.True(t, closure.Covers(closureDate("2031-01-01")))
+	assert.False(t, closure.Covers(closureDate("2031-01-02")))
+	assert.False(t, closure.Covers(closureDate("2030-12-30")))
+}
+
+func TestShopClosurePartialDay(t *testing.T) {
+	closure := &entities.ShopClosure{Name: "Staff meeting", StartDate: closureDate("2025-05-02"), EndDate: closureDate("2025-05-02"), StartTime: "12:00", EndTime: "14:00"}
+
+	assert.False(t, closure.IsFullDay())
+	assert.True(t, closure.Covers(closureDate("2025-05-02")))
+}
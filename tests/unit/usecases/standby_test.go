@@ -0,0 +1,19 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandbyPosition(t *testing.T) {
+	first, second, other := types.NewMSSQLUUID(), types.NewMSSQLUUID(), types.NewMSSQLUUID()
+	entries := []*entities.StandbyEntry{{ID: first}, {ID: second}}
+
+	assert.Equal(t, 1, usecases.StandbyPosition(entries, first))
+	assert.Equal(t, 2, usecases.StandbyPosition(entries, second))
+	assert.Equal(t, 0, usecases.StandbyPosition(entries, other))
+}
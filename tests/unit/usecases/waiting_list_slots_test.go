@@ -0,0 +1,84 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeSlots(t *testing.T) {
+	date := time.Date(2025, 11, 17, 0, 0, 0, 0, time.UTC) // Monday
+	hours := usecases.BusinessHours{
+		Opening:     8 * time.Hour,
+		Closing:     11 * time.Hour,
+		WorkingDays: map[time.Weekday]bool{time.Monday: true},
+	}
+	at := func(clock string) *time.Time {
+		t, _ := time.Parse("2006-01-02 15:04", "2025-11-17 "+clock)
+		return &t
+	}
+	past := date.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name     string
+		duration int
+		entries  []*entities.WaitingList
+		now      time.Time
+		expected []string
+	}{
+		{
+			name:     "empty day offers every slot",
+			duration: 60,
+			now:      past,
+			expected: []string{"08:00", "08:30", "09:00", "09:30", "10:00"},
+		},
+		{
+			name:     "booked appointment blocks overlapping slots",
+			duration: 60,
+			entries: []*entities.WaitingList{
+				{AppointmentAt: at("09:00"), EstimatedTime: 30, Status: entities.WaitingListStatusWaiting},
+			},
+			now:      past,
+			expected: []string{"08:00", "09:30", "10:00"},
+		},
+		{
+			name:     "walk-ins and canceled appointments do not block",
+			duration: 60,
+			entries: []*entities.WaitingList{
+				{EstimatedTime: 120, Status: entities.WaitingListStatusWaiting},
+				{AppointmentAt: at("08:00"), EstimatedTime: 180, Status: entities.WaitingListStatusCanceled},
+			},
+			now:      past,
+			expected: []string{"08:00", "08:30", "09:00", "09:30", "10:00"},
+		},
+		{
+			name:     "appointment without estimate occupies one interval",
+			duration: 30,
+			entries: []*entities.WaitingList{
+				{AppointmentAt: at("08:30"), Status: entities.WaitingListStatusCalled},
+			},
+			now:      past,
+			expected: []string{"08:00", "09:00", "09:30", "10:00", "10:30"},
+		},
+		{
+			name:     "slots in the past are skipped",
+			duration: 60,
+			now:      *at("09:15"),
+			expected: []string{"09:30", "10:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slots := usecases.FreeSlots(date, hours, 30, tt.duration, tt.entries, tt.now)
+			actual := make([]string, len(slots))
+			for i, slot := range slots {
+				actual[i] = slot.Format("15:04")
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitingListStatusTransitions(t *testing.T) {
+	tests := []struct {
+		from, to entities.WaitingListStatus
+		allowed  bool
+	}{
+		{entities.WaitingListStatusWaiting, entities.WaitingListStatusCalled, true},
+		{entities.WaitingListStatusWaiting, entities.WaitingListStatusCanceled, true},
+		{entities.WaitingListStatusWaiting, entities.WaitingListStatusInService, false},
+		{entities.WaitingListStatusCalled, entities.WaitingListStatusInService, true},
+		{entities.WaitingListStatusCalled, entities.WaitingListStatusNoShow, true},
+		{entities.WaitingListStatusInService, entities.WaitingListStatusCompleted, true},
+		{entities.WaitingListStatusInService, entities.WaitingListStatusNoShow, false},
+		{entities.WaitingListStatusCompleted, entities.WaitingListStatusCanceled, false},
+		{entities.WaitingListStatusNoShow, entities.WaitingListStatusCalled, false},
+		{entities.WaitingListStatusCanceled, entities.WaitingListStatusWaiting, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.allowed, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/domain/entities"
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWarrantyTerms(t *testing.T) {
+	terms := usecases.ParseWarrantyTerms(" Brakes:6/10000, tires:12/0,engine:x/1000,:3/100,Battery:-1/0,Oil")
+
+	assert.Len(t, terms, 2, "malformed and negative entries are skipped")
+	assert.Equal(t, usecases.WarrantyTerms{Months: 6, Km: 10000}, terms["brakes"])
+	assert.Equal(t, usecases.WarrantyTerms{Months: 12}, terms["tires"])
+	assert.Empty(t, usecases.ParseWarrantyTerms(""))
+}
+
+func TestWarrantyPolicyFor(t *testing.T) {
+	policy := usecases.WarrantyPolicy{
+		Default:    usecases.WarrantyTerms{Months: 3, Km: 5000},
+		Categories: usecases.ParseWarrantyTerms("Brakes:6/10000"),
+	}
+
+	assert.Equal(t, usecases.WarrantyTerms{Months: 6, Km: 10000}, policy.For(" BRAKES "))
+	assert.Equal(t, usecases.WarrantyTerms{Months: 3, Km: 5000}, policy.For("Engine"))
+}
+
+func TestApplyWarranty(t *testing.T) {
+	completedAt := time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC)
+	item := &entities.MaintenanceItem{Status: entities.MaintenanceItemStatusCompleted, CompletedAt: &completedAt}
+
+	usecases.ApplyWarranty(item, usecases.WarrantyTerms{Months: 6, Km: 10000}, 42000)
+	assert.Equal(t, 6, item.WarrantyMonths)
+	assert.Equal(t, 10000, item.WarrantyKm)
+	if assert.NotNil(t, item.WarrantyUntil) {
+		assert.Equal(t, completedAt.AddDate(0, 6, 0), *item.WarrantyUntil)
+	}
+	assert.Equal(t, 52000, item.WarrantyUntilKm)
+
+	usecases.ApplyWarranty(item, usecases.WarrantyTerms{Months: 3}, 42000)
+	assert.Equal(t, 0, item.WarrantyUntilKm, "no mileage limit")
+
+	usecases.ApplyWarranty(item, usecases.WarrantyTerms{}, 0)
+	assert.Nil(t, item.WarrantyUntil, "zero terms clear the warranty")
+	assert.Equal(t, 0, item.WarrantyMonths)
+
+	claimID := types.NewMSSQLUUID()
+	repair := &entities.MaintenanceItem{Status: entities.MaintenanceItemStatusCompleted, CompletedAt: &completedAt, WarrantyClaimID: &claimID}
+	usecases.ApplyWarranty(repair, usecases.WarrantyTerms{Months: 6, Km: 10000}, 42000)
+	assert.Nil(t, repair.WarrantyUntil, "warranty repairs get no terms of their own")
+	assert.Equal(t, 0, repair.WarrantyUntilKm)
+}
+
+func TestWarrantyActive(t *testing.T) {
+	completedAt := time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC)
+	item := &entities.MaintenanceItem{Status: entities.MaintenanceItemStatusCompleted, CompletedAt: &completedAt}
+	usecases.ApplyWarranty(item, usecases.WarrantyTerms{Months: 6, Km: 10000}, 42000)
+
+	assert.True(t, usecases.WarrantyActive(item, 50000, completedAt.AddDate(0, 2, 0)))
+	assert.False(t, usecases.WarrantyActive(item, 52001, completedAt.AddDate(0, 2, 0)), "past the mileage limit")
+	assert.False(t, usecases.WarrantyActive(item, 45000, completedAt.AddDate(0, 7, 0)), "past the date limit")
+
+	usecases.ApplyWarranty(item, usecases.WarrantyTerms{}, 0)
+	assert.False(t, usecases.WarrantyActive(item, 42000, completedAt), "no terms recorded")
+
+	usecases.ApplyWarranty(item, usecases.WarrantyTerms{Km: 1000}, 42000)
+	item.Status = entities.MaintenanceItemStatusCanceled
+	assert.False(t, usecases.WarrantyActive(item, 42000, completedAt))
+}
+
+func TestEstimateSkipsWarrantyRepairs(t *testing.T) {
+	claimID := types.NewMSSQLUUID()
+	repair := &entities.MaintenanceItem{Status: entities.MaintenanceItemStatusPending, EstimatedCost: 250000, WarrantyClaimID: &claimID}
+
+	assert.Equal(t, 0.0, usecases.EstimateLineAmount(repair), "warranty repairs are not billed")
+}
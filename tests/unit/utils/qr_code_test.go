@@ -0,0 +1,15 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQRCodeSVG(t *testing.T) {
+	svg, err := utils.QRCodeSVG("hello", 128)
+	assert.NoError(t, err)
+	assert.Contains(t, svg, `width="128"`)
+	assert.Contains(t, svg, "<rect")
+}
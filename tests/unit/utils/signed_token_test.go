@@ -0,0 +1,46 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/types"
+	"github.com/kuahbanyak/go-crud/internal/shared/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignedToken(t *testing.T) {
+	service := utils.NewSignedTokenService("secret", "ticket-check-in")
+	id := types.NewMSSQLUUID()
+	now := time.Date(2025, 11, 15, 9, 0, 0, 0, time.UTC)
+	token := service.Sign(id, now.Add(time.Hour))
+
+	verified, err := service.Verify(token, now)
+	assert.NoError(t, err)
+	assert.Equal(t, id, verified)
+
+	_, err = service.Verify(token, now.Add(2*time.Hour))
+	assert.ErrorIs(t, err, utils.ErrSignedTokenExpired)
+
+	_, err = utils.NewSignedTokenService("other", "ticket-check-in").Verify(token, now)
+	assert.ErrorIs(t, err, utils.ErrSignedTokenInvalid)
+
+	_, err = utils.NewSignedTokenService("secret", "maintenance-approval").Verify(token, now)
+	assert.ErrorIs(t, err, utils.ErrSignedTokenInvalid, "tokens do not verify for another purpose")
+
+	_, err = service.Verify(token+"x", now)
+	assert.ErrorIs(t, err, utils.ErrSignedTokenInvalid)
+}
+
+func TestSignedTokenPurposeErrors(t *testing.T) {
+	id := types.NewMSSQLUUID()
+	now := time.Date(2025, 11, 15, 9, 0, 0, 0, time.UTC)
+	checkIn := utils.NewCheckInTokenService("secret")
+	approvals := utils.NewApprovalTokenService("secret")
+
+	_, err := checkIn.Verify(checkIn.Sign(id, now.Add(-time.Minute)), now)
+	assert.ErrorIs(t, err, utils.ErrCheckInTokenExpired)
+
+	_, err = approvals.Verify(checkIn.Sign(id, now.Add(time.Hour)), now)
+	assert.ErrorIs(t, err, utils.ErrApprovalTokenInvalid)
+}
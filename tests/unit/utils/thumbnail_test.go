@@ -0,0 +1,59 @@
+package utils_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/kuahbanyak/go-crud/internal/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownscale(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 800, 400))
+	for x := 0; x < 800; x++ {
+		for y := 0; y < 400; y++ {
+			src.Set(x, y, color.RGBA{R: 200, A: 255})
+		}
+	}
+	dst := utils.Downscale(src, 200)
+	assert.Equal(t, image.Rect(0, 0, 200, 100), dst.Bounds())
+	r, _, _, _ := dst.At(10, 10).RGBA()
+	assert.Equal(t, uint32(200), r>>8)
+
+	small := image.NewRGBA(image.Rect(0, 0, 50, 80))
+	assert.Same(t, small, utils.Downscale(small, 200))
+}
+
+func TestJPEGThumbnail(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 300, 600))))
+
+	thumb, err := utils.JPEGThumbnail(buf.Bytes(), 120)
+	require.NoError(t, err)
+	img, err := jpeg.Decode(bytes.NewReader(thumb))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 60, 120), img.Bounds())
+
+	_, err = utils.JPEGThumbnail([]byte("not an image"), 120)
+	assert.Error(t, err)
+}
+
+func TestJPEGThumbnailRejectsHugeDimensions(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))))
+	data := buf.Bytes()
+	// Rewrite the IHDR chunk to declare 100000x100000 pixels.
+	binary.BigEndian.PutUint32(data[16:], 100000)
+	binary.BigEndian.PutUint32(data[20:], 100000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+
+	_, err := utils.JPEGThumbnail(data, 120)
+	assert.ErrorIs(t, err, utils.ErrImageTooLarge)
+}